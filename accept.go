@@ -0,0 +1,143 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AcceptRange is a single media range of an HTTP Accept header
+type AcceptRange struct {
+	// Type is the lower-cased media range, such as "text/html" or "*/*"
+	Type string
+	// Params are the media type parameters, those before the q weight
+	Params map[string]string
+	// Quality is the q weight of the media range, 1 when not given
+	Quality float64
+	// Extensions are the accept-ext parameters, those after the q weight,
+	// such as "level" or "profile"
+	Extensions map[string]string
+}
+
+// String returns the AcceptRange formatted as an Accept header element
+func (a AcceptRange) String() string {
+	var buf strings.Builder
+	buf.WriteString(a.Type)
+	writeParams(&buf, a.Params)
+	if a.Quality != 1 || len(a.Extensions) > 0 {
+		buf.WriteString(";q=" + strconv.FormatFloat(a.Quality, 'f', -1, 64))
+	}
+	writeParams(&buf, a.Extensions)
+	return buf.String()
+}
+
+// ParseAccept parses the given HTTP Accept `header` value into its media
+// ranges, sorted by descending Quality while preserving the header order of
+// equal weights. Parameters before the q weight are media type Params and
+// parameters after it are accept-ext Extensions, as described by RFC 7231
+// section 5.3.2. Malformed media ranges are skipped
+func ParseAccept(header string) (ranges []AcceptRange) {
+	for _, element := range splitQuoted(header, ',') {
+		parts := splitQuoted(element, ';')
+		mediaRange := strings.ToLower(strings.TrimSpace(parts[0]))
+		if t, s, ok := strings.Cut(mediaRange, "/"); !ok || t == "" || s == "" || (t == "*" && s != "*") {
+			continue
+		}
+		ar := AcceptRange{Type: mediaRange, Quality: 1}
+		weighted := false
+		for _, param := range parts[1:] {
+			key, value, _ := strings.Cut(param, "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			value = unquote(strings.TrimSpace(value))
+			if key == "" {
+				continue
+			} else if key == "q" && !weighted {
+				weighted = true
+				if q, err := strconv.ParseFloat(value, 64); err == nil && q >= 0 && q <= 1 {
+					ar.Quality = q
+				}
+			} else if weighted {
+				if ar.Extensions == nil {
+					ar.Extensions = make(map[string]string)
+				}
+				ar.Extensions[key] = value
+			} else {
+				if ar.Params == nil {
+					ar.Params = make(map[string]string)
+				}
+				ar.Params[key] = value
+			}
+		}
+		ranges = append(ranges, ar)
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].Quality > ranges[j].Quality
+	})
+	return
+}
+
+// splitQuoted splits `s` on `sep` where `sep` is not within a quoted-string
+func splitQuoted(s string, sep byte) (parts []string) {
+	var quoted, escaped bool
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote removes the surrounding quotes and escapes of a quoted-string
+func unquote(value string) (unquoted string) {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+	var buf strings.Builder
+	for i := 1; i < len(value)-1; i++ {
+		if value[i] == '\\' && i+1 < len(value)-1 {
+			i += 1
+		}
+		buf.WriteByte(value[i])
+	}
+	return buf.String()
+}
+
+// writeParams writes the `params` to `buf` in sorted order, quoting values
+// as needed
+func writeParams(buf *strings.Builder, params map[string]string) {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := params[k]
+		if v == "" || strings.ContainsAny(v, " \t\"\\,;=()<>@:/[]?{}") {
+			v = strconv.Quote(v)
+		}
+		buf.WriteString(";" + k + "=" + v)
+	}
+}
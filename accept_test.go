@@ -0,0 +1,62 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseAccept(t *testing.T) {
+	Convey("ParseAccept", t, func() {
+		Convey("weights and order", func() {
+			ranges := ParseAccept("text/*;q=0.3, TEXT/HTML;q=0.7, text/html;level=1, */*;q=0.3, bogus, */html")
+			So(ranges, ShouldHaveLength, 4)
+			So(ranges[0].Type, ShouldEqual, "text/html")
+			So(ranges[0].Params, ShouldResemble, map[string]string{"level": "1"})
+			So(ranges[0].Quality, ShouldEqual, 1)
+			So(ranges[1].Type, ShouldEqual, "text/html")
+			So(ranges[1].Quality, ShouldEqual, 0.7)
+			So(ranges[2].Type, ShouldEqual, "text/*")
+			So(ranges[3].Type, ShouldEqual, "*/*")
+		})
+
+		Convey("accept-ext parameters", func() {
+			ranges := ParseAccept(`application/ld+json;charset=utf-8;q=0.9;profile="http://www.w3.org/ns/json-ld#compacted";level=1, application/hal+json;q=bad`)
+			So(ranges, ShouldHaveLength, 2)
+			So(ranges[0].Type, ShouldEqual, "application/hal+json")
+			So(ranges[0].Quality, ShouldEqual, 1)
+			So(ranges[1].Params, ShouldResemble, map[string]string{"charset": "utf-8"})
+			So(ranges[1].Quality, ShouldEqual, 0.9)
+			So(ranges[1].Extensions, ShouldResemble, map[string]string{
+				"profile": "http://www.w3.org/ns/json-ld#compacted",
+				"level":   "1",
+			})
+			So(ranges[1].String(), ShouldEqual, `application/ld+json;charset=utf-8;q=0.9;level=1;profile="http://www.w3.org/ns/json-ld#compacted"`)
+			So(ranges[0].String(), ShouldEqual, "application/hal+json")
+		})
+
+		Convey("quoted separators", func() {
+			ranges := ParseAccept(`text/plain;q=0.5;note="a, b; c", text/csv`)
+			So(ranges, ShouldHaveLength, 2)
+			So(ranges[1].Extensions["note"], ShouldEqual, "a, b; c")
+		})
+
+		Convey("empty header", func() {
+			So(ParseAccept(""), ShouldBeEmpty)
+		})
+	})
+}
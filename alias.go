@@ -0,0 +1,92 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"fmt"
+	goMime "mime"
+)
+
+// defaultAliases returns the built-in legacy names of a new Registry
+func defaultAliases() (l *lookup) {
+	return newLookup(map[string]string{
+		"text/x-markdown":          MarkdownMimeType,
+		"application/javascript":   JavaScriptMimeType,
+		"application/x-javascript": JavaScriptMimeType,
+		"text/x-javascript":        JavaScriptMimeType,
+		"text/json":                JsonMimeType,
+		"application/x-json":       JsonMimeType,
+		"text/xml":                 "application/xml",
+		"image/jpg":                "image/jpeg",
+		"image/x-png":              "image/png",
+		"audio/mp3":                Mp3MimeType,
+		"application/x-gzip":       GzipMimeType,
+		"application/x-zip":        ZipMimeType,
+	})
+}
+
+// RegisterAlias is a checked version of SetAlias, registering the `alias` as
+// a legacy name of the `canonical` mime type. RegisterAlias returns an error
+// when either argument does not parse, when the two are the same type or
+// when the registration would chain aliases, which means the `canonical` is
+// itself an alias or the `alias` is already the canonical type of another
+// alias
+func (r *Registry) RegisterAlias(alias, canonical string) (err error) {
+	defer r.logRegistration(alias, &err)
+	if err = checkMimeType(alias); err != nil {
+		return
+	} else if err = checkMimeType(canonical); err != nil {
+		return
+	}
+	a, c := PruneCharset(alias), PruneCharset(canonical)
+	if a == c {
+		return fmt.Errorf("alias %q refers to itself", alias)
+	} else if other, ok := r.aliases.get(c); ok {
+		return fmt.Errorf("canonical type %q is an alias of %q", c, other)
+	}
+	for key, value := range r.aliases.snapshot() {
+		if value == a {
+			return fmt.Errorf("alias %q is the canonical type of %q", a, key)
+		}
+	}
+	r.SetAlias(a, c)
+	return
+}
+
+// Canonical returns the normalized form of the given `mime` with any
+// registered alias replaced by its canonical type, for example
+// "text/x-markdown; charset=UTF-8" returns "text/markdown; charset=utf-8".
+// Parameters are preserved, see Normalize
+func (r *Registry) Canonical(mime string) (canonical string) {
+	canonical = Normalize(mime)
+	if mediatype, params, err := goMime.ParseMediaType(canonical); err == nil {
+		if target, ok := r.GetAlias(mediatype); ok {
+			canonical = goMime.FormatMediaType(target, params)
+		}
+	}
+	return
+}
+
+// RegisterAlias registers the `alias` as a legacy name of the `canonical`
+// mime type within the default Registry, see Registry.RegisterAlias
+func RegisterAlias(alias, canonical string) (err error) {
+	return gRegistry.RegisterAlias(alias, canonical)
+}
+
+// Canonical returns the canonical form of the given `mime` using the default
+// Registry, see Registry.Canonical
+func Canonical(mime string) (canonical string) {
+	return gRegistry.Canonical(mime)
+}
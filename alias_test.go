@@ -0,0 +1,61 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAliases(t *testing.T) {
+	Convey("Canonical", t, func() {
+		So(Canonical("text/x-markdown; charset=UTF-8"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(Canonical("application/x-javascript"), ShouldEqual, JavaScriptMimeType)
+		So(Canonical("text/xml"), ShouldEqual, "application/xml")
+		So(Canonical("Image/PNG"), ShouldEqual, "image/png")
+		So(Canonical(""), ShouldEqual, "")
+	})
+
+	Convey("RegisterAlias", t, func() {
+		r := New()
+		So(r.RegisterAlias("text/x-njn", EnjinMimeType), ShouldBeNil)
+		So(r.Canonical("text/x-njn"), ShouldEqual, EnjinMimeType)
+		So(r.RegisterAlias("not-a-type", EnjinMimeType), ShouldNotBeNil)
+		So(r.RegisterAlias("text/x-other", "bad type"), ShouldNotBeNil)
+		So(r.RegisterAlias(EnjinMimeType, "text/enjin; charset=utf-8"), ShouldNotBeNil)
+		So(r.RegisterAlias("text/x-again", "text/x-njn"), ShouldNotBeNil)
+		So(r.RegisterAlias(EnjinMimeType, "text/x-enjin"), ShouldNotBeNil)
+		So(r.Validate(), ShouldBeNil)
+
+		So(RegisterAlias("text/x-alias-test", "text/alias-test"), ShouldBeNil)
+		So(Canonical("text/x-alias-test"), ShouldEqual, "text/alias-test")
+		SetAlias("text/x-alias-test", "")
+	})
+
+	Convey("Aliases resolve", t, func() {
+		r := New()
+		charset, ok := r.GetCharset("text/x-markdown")
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "utf-8")
+		So(r.IsPlainText("application/x-javascript"), ShouldBeTrue)
+		So(r.Key("text/json"), ShouldEqual, r.Key("application/json"))
+		So(Match("application/xml", "text/xml"), ShouldBeTrue)
+		So(Match("text/xml", "application/xml"), ShouldBeTrue)
+		So(Match("text/*", "text/xml"), ShouldBeTrue)
+		So(Match("image/jpeg", "image/jpg"), ShouldBeTrue)
+		So(Match("image/png", "image/jpg"), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+var (
+	// ErrNotArchive is returned when content given to ListArchive is not a
+	// supported archive format
+	ErrNotArchive = errors.New("not a supported archive")
+	// ErrDecompressionLimit is returned by ClassifyAt when the compressed
+	// archives being classified expand to more than the
+	// ClassifyLimits.MaxBytes
+	ErrDecompressionLimit = errors.New("decompression limit exceeded")
+)
+
+// ArchiveEntry describes a single member of an archive
+type ArchiveEntry struct {
+	// Name is the path of the member within the archive
+	Name string
+	// Size is the uncompressed size of the member
+	Size int64
+	// Mime is the detected mime type of the member
+	Mime string
+}
+
+// IsDir returns true if the entry is a directory
+func (e ArchiveEntry) IsDir() bool {
+	return e.Mime == DirectoryMimeType
+}
+
+// IsArchive returns true if the given `mime` is one of the archive formats
+// supported by ListArchive
+func IsArchive(mime string) bool {
+	switch PruneCharset(mime) {
+	case ZipMimeType, TarMimeType, GzipMimeType:
+		return true
+	}
+	return false
+}
+
+// ListArchive opens the archive file at the given `path` and uses
+// ListArchiveAt to enumerate its members. ListArchive returns ErrPathOnly
+// when path-only mode of the default Registry is enabled, see SetPathOnly
+func ListArchive(path string) (entries []ArchiveEntry, err error) {
+	var fh *os.File
+	if gRegistry.GetPathOnly() {
+		err = ErrPathOnly
+		return
+	} else if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	var info os.FileInfo
+	if info, err = fh.Stat(); err != nil {
+		return
+	}
+	return ListArchiveAt(fh, info.Size())
+}
+
+// ListArchiveAt enumerates the members of the zip (including the formats
+// stored within ZIP containers, see IsZipContainer), tar or gzip compressed
+// archive within `r`, which is `size` bytes long, and classifies each of the
+// members without extracting anything to disk. Members are classified using
+// FromPathOnly first and then by detecting the leading bytes of their
+// content, up to the read limit of the default Registry (see SetReadLimit).
+// A gzip stream that does not contain a tar archive is listed as a single
+// member. ListArchiveAt returns ErrNotArchive for any other type of content
+func ListArchiveAt(r io.ReaderAt, size int64) (entries []ArchiveEntry, err error) {
+	err = walkArchive(r, size, nil, func(entry ArchiveEntry, _ io.Reader) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	return
+}
+
+// archiveVisitor is called for each member of an archive, `content` is only
+// valid for the duration of the call and is nil for directories
+type archiveVisitor func(entry ArchiveEntry, content io.Reader) (err error)
+
+// walkArchive calls fn for each member of the archive within `r`, when
+// `remaining` is not nil, the bytes decompressed from gzip streams are
+// charged against it and the walk fails with ErrDecompressionLimit once it
+// is exhausted
+func walkArchive(r io.ReaderAt, size int64, remaining *int64, fn archiveVisitor) (err error) {
+	var mime string
+	if mime, err = DetectAt(r, size); err != nil {
+		return
+	}
+	switch mediatype := PruneCharset(mime); {
+	case IsZipContainer(mediatype):
+		return walkZip(r, size, fn)
+	case mediatype == TarMimeType:
+		return walkTar(io.NewSectionReader(r, 0, size), fn)
+	case mediatype == GzipMimeType:
+		return walkGzip(io.NewSectionReader(r, 0, size), remaining, fn)
+	}
+	return ErrNotArchive
+}
+
+func walkZip(r io.ReaderAt, size int64, fn archiveVisitor) (err error) {
+	var zr *zip.Reader
+	if zr, err = zip.NewReader(r, size); err != nil {
+		return
+	}
+	for _, file := range zr.File {
+		entry := ArchiveEntry{Name: file.Name, Size: int64(file.UncompressedSize64)}
+		if file.FileInfo().IsDir() {
+			entry.Mime = DirectoryMimeType
+			if err = fn(entry, nil); err != nil {
+				return
+			}
+			continue
+		}
+		var rc io.ReadCloser
+		if rc, err = file.Open(); err != nil {
+			return
+		}
+		err = visitMember(entry, rc, fn)
+		_ = rc.Close()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func walkTar(r io.Reader, fn archiveVisitor) (err error) {
+	tr := tar.NewReader(r)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return
+		}
+		entry := ArchiveEntry{Name: hdr.Name, Size: hdr.Size}
+		if hdr.Typeflag == tar.TypeDir {
+			entry.Mime = DirectoryMimeType
+			err = fn(entry, nil)
+		} else {
+			err = visitMember(entry, tr, fn)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func walkGzip(r io.Reader, remaining *int64, fn archiveVisitor) (err error) {
+	var gr *gzip.Reader
+	if gr, err = gzip.NewReader(r); err != nil {
+		return
+	}
+	defer gr.Close()
+	var decompressed io.Reader = gr
+	if remaining != nil {
+		decompressed = &budgetReader{r: gr, remaining: remaining}
+	}
+	limit := gRegistry.GetReadLimit()
+	br := bufio.NewReaderSize(decompressed, limit)
+	head, _ := br.Peek(limit)
+	if mimetype.Detect(head).Is(TarMimeType) {
+		return walkTar(br, fn)
+	}
+	entry := ArchiveEntry{Name: gr.Header.Name, Size: -1, Mime: Normalize(gRegistry.detect(head))}
+	if entry.Name != "" {
+		if mime := FromPathOnly(entry.Name); mime != "" {
+			entry.Mime = mime
+		}
+	}
+	return fn(entry, br)
+}
+
+// visitMember classifies the member content and calls fn with a reader that
+// still provides the complete content
+func visitMember(entry ArchiveEntry, content io.Reader, fn archiveVisitor) (err error) {
+	limit := gRegistry.GetReadLimit()
+	br := bufio.NewReaderSize(content, limit)
+	if entry.Mime = FromPathOnly(entry.Name); entry.Mime == "" {
+		head, _ := br.Peek(limit)
+		entry.Mime = Normalize(gRegistry.detect(head))
+	}
+	return fn(entry, br)
+}
+
+// budgetReader charges the bytes read from `r` against `remaining` and fails
+// with ErrDecompressionLimit when more than `remaining` bytes are available
+type budgetReader struct {
+	r         io.Reader
+	remaining *int64
+}
+
+func (b *budgetReader) Read(p []byte) (n int, err error) {
+	if *b.remaining <= 0 {
+		// only fail when there is actually more content to read
+		var one [1]byte
+		if n, err = b.r.Read(one[:]); n > 0 {
+			n, err = 0, ErrDecompressionLimit
+		}
+		return
+	}
+	if int64(len(p)) > *b.remaining {
+		p = p[:*b.remaining]
+	}
+	n, err = b.r.Read(p)
+	*b.remaining -= int64(n)
+	return
+}
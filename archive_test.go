@@ -0,0 +1,105 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testArchiveFiles = []struct{ name, body string }{
+	{"docs/", ""},
+	{"docs/README.md", "# readme"},
+	{"image", "\x89PNG\x0d\x0a\x1a\x0a"},
+}
+
+func makeTestZip() []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range testArchiveFiles {
+		w, _ := zw.Create(f.name)
+		_, _ = w.Write([]byte(f.body))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func makeTestTar() []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range testArchiveFiles {
+		hdr := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.body)), Typeflag: tar.TypeReg}
+		if f.body == "" {
+			hdr.Typeflag = tar.TypeDir
+		}
+		_ = tw.WriteHeader(hdr)
+		_, _ = tw.Write([]byte(f.body))
+	}
+	_ = tw.Close()
+	return buf.Bytes()
+}
+
+func makeTestTarGz() []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write(makeTestTar())
+	_ = gw.Close()
+	return buf.Bytes()
+}
+
+func TestListArchive(t *testing.T) {
+	expected := []ArchiveEntry{
+		{Name: "docs/", Size: 0, Mime: DirectoryMimeType},
+		{Name: "docs/README.md", Size: 8, Mime: "text/markdown; charset=utf-8"},
+		{Name: "image", Size: 8, Mime: "image/png"},
+	}
+
+	Convey("ListArchiveAt", t, func() {
+		for _, tc := range []struct {
+			label string
+			data  []byte
+		}{
+			{"zip", makeTestZip()},
+			{"tar", makeTestTar()},
+			{"tar.gz", makeTestTarGz()},
+		} {
+			Convey(tc.label, func() {
+				entries, err := ListArchiveAt(bytes.NewReader(tc.data), int64(len(tc.data)))
+				So(err, ShouldBeNil)
+				So(entries, ShouldResemble, expected)
+				So(entries[0].IsDir(), ShouldBeTrue)
+				So(entries[1].IsDir(), ShouldBeFalse)
+			})
+		}
+
+		Convey("not an archive", func() {
+			data := []byte("%PDF-1.4\n")
+			_, err := ListArchiveAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldEqual, ErrNotArchive)
+		})
+	})
+
+	Convey("ListArchive", t, func() {
+		_, err := ListArchive("./testdata/not-a-file")
+		So(err, ShouldNotBeNil)
+		_, err = ListArchive("./testdata/README.md")
+		So(err, ShouldEqual, ErrNotArchive)
+	})
+}
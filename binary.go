@@ -0,0 +1,115 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// gBinaryDetectors are the detectors given to RegisterBinaryType and
+// RegisterBinaryTypeFunc, most recently registered first
+var gBinaryDetectors = &textDetectorList{}
+
+// MagicDetector returns a detector which matches content having the given
+// `magic` bytes at the given `offset`, for use with RegisterBinaryTypeFunc
+// or as a Detector
+func MagicDetector(magic []byte, offset int) func(raw []byte, limit uint32) bool {
+	magic = bytes.Clone(magic)
+	return func(raw []byte, limit uint32) bool {
+		if limit > 0 && uint32(len(raw)) > limit {
+			raw = raw[:limit]
+		}
+		return offset >= 0 && len(raw) >= offset+len(magic) && bytes.Equal(raw[offset:offset+len(magic)], magic)
+	}
+}
+
+// RegisterBinaryType associates the given `mime` with the given `extension`
+// and registers a detector matching the `magic` bytes at the given `offset`
+// with BinaryMimeType as it's parent within the
+// github.com/gabriel-vasile/mimetype system, see RegisterBinaryTypeFunc
+func RegisterBinaryType(mime, extension string, magic []byte, offset int) (err error) {
+	if len(magic) == 0 || offset < 0 {
+		err = errors.New("magic must not be empty and offset must not be negative")
+		gRegistry.logRegistration(mime, &err)
+		return
+	}
+	return RegisterBinaryTypeFunc(mime, extension, MagicDetector(magic, offset))
+}
+
+// RegisterBinaryTypeFunc is the binary counterpart of RegisterTextType,
+// associating the given `mime` with the given `extension` and registering
+// the `detector` with BinaryMimeType as it's parent within the
+// github.com/gabriel-vasile/mimetype system, so that proprietary binary
+// formats are detected by content the same as the built-in ones. Unlike
+// RegisterTextType, no charset is associated and a `detector` is required
+func RegisterBinaryTypeFunc(mime, extension string, detector func(raw []byte, limit uint32) bool) (err error) {
+	defer gRegistry.logRegistration(mime, &err)
+	var mediatype string
+	if mediatype, extension, err = parseBinaryType(mime, extension, detector); err != nil {
+		return
+	}
+	SetExtension(extension, mediatype)
+	gBinaryDetectors.add(Detector{Mime: mediatype, Detect: detector})
+	graftDetector(nil, detector, mediatype, "."+extension)
+	err = addExtensionType("."+extension, mediatype)
+	return
+}
+
+// RegisterBinaryType is the Registry instance version of the package level
+// RegisterBinaryType, registering the `magic` detector with the Registry
+// detectors only
+func (r *Registry) RegisterBinaryType(mime, extension string, magic []byte, offset int) (err error) {
+	if len(magic) == 0 || offset < 0 {
+		err = errors.New("magic must not be empty and offset must not be negative")
+		r.logRegistration(mime, &err)
+		return
+	}
+	return r.RegisterBinaryTypeFunc(mime, extension, MagicDetector(magic, offset))
+}
+
+// RegisterBinaryTypeFunc is the Registry instance version of the package
+// level RegisterBinaryTypeFunc, associating the given `mime` with the given
+// `extension` and adding the `detector` to the Registry detectors only
+func (r *Registry) RegisterBinaryTypeFunc(mime, extension string, detector func(raw []byte, limit uint32) bool) (err error) {
+	defer r.logRegistration(mime, &err)
+	var mediatype string
+	if mediatype, extension, err = parseBinaryType(mime, extension, detector); err != nil {
+		return
+	}
+	r.SetExtension(extension, mediatype)
+	r.SetParent(mediatype, BinaryMimeType)
+	r.detectors.add(Detector{Mime: mediatype, Detect: detector})
+	return
+}
+
+// parseBinaryType validates the RegisterBinaryTypeFunc arguments, returning
+// the `mime` as a bare `mediatype` and the `extension` without any leading
+// period
+func parseBinaryType(mime, extension string, detector func(raw []byte, limit uint32) bool) (mediatype, ext string, err error) {
+	ext = strings.TrimPrefix(extension, ".")
+	if mime == "" || ext == "" {
+		err = errors.New("mime and extension arguments must not be empty")
+		return
+	} else if detector == nil {
+		err = errors.New("detector must not be nil")
+		return
+	} else if err = checkMimeType(mime); err != nil {
+		return
+	}
+	mediatype = PruneCharset(mime)
+	return
+}
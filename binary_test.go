@@ -0,0 +1,67 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRegisterBinaryType(t *testing.T) {
+	dir := t.TempDir()
+
+	Convey("MagicDetector", t, func() {
+		detect := MagicDetector([]byte("MAGIC"), 2)
+		So(detect([]byte("..MAGIC.."), 0), ShouldBeTrue)
+		So(detect([]byte("MAGIC...."), 0), ShouldBeFalse)
+		So(detect([]byte("..MAG"), 0), ShouldBeFalse)
+		So(detect([]byte("..MAGIC.."), 4), ShouldBeFalse)
+	})
+
+	Convey("RegisterBinaryType", t, func() {
+		So(RegisterBinaryType("", "cltb", []byte("CLTB"), 0), ShouldNotBeNil)
+		So(RegisterBinaryType("application/x-cltb", "", []byte("CLTB"), 0), ShouldNotBeNil)
+		So(RegisterBinaryType("application/x-cltb", "cltb", nil, 0), ShouldNotBeNil)
+		So(RegisterBinaryType("application/x-cltb", "cltb", []byte("CLTB"), -1), ShouldNotBeNil)
+		So(RegisterBinaryTypeFunc("application/x-cltb", "cltb", nil), ShouldNotBeNil)
+		So(RegisterBinaryType("not a mime type", "cltb", []byte("CLTB"), 0), ShouldNotBeNil)
+
+		So(RegisterBinaryType("application/x-cltb", ".cltb", []byte("CLTB\x00"), 0), ShouldBeNil)
+		mime, ok := GetExtension("cltb")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "application/x-cltb")
+
+		data := []byte("CLTB\x00\x01\x02\x03")
+		path := filepath.Join(dir, "payload")
+		So(os.WriteFile(path, data, 0644), ShouldBeNil)
+		So(Mime(path), ShouldEqual, "application/x-cltb")
+		So(DetectBytes(data), ShouldEqual, "application/x-cltb")
+		So(DetectHTTP(data), ShouldEqual, "application/x-cltb")
+		So(IsPlainText("application/x-cltb"), ShouldBeFalse)
+		So(gRegistry.Export().Types, ShouldContain, "application/x-cltb")
+	})
+
+	Convey("Registry.RegisterBinaryType", t, func() {
+		r := New()
+		So(r.RegisterBinaryType("application/x-offset", "off", []byte("SIG"), 4), ShouldBeNil)
+		So(r.DetectBytes([]byte("\x00\x00\x00\x00SIG\x00")), ShouldEqual, "application/x-offset")
+		So(DetectBytes([]byte("\x00\x00\x00\x00SIG\x00")), ShouldEqual, BinaryMimeType)
+		So(r.FromPathOnly("file.off"), ShouldEqual, "application/x-offset")
+		So(r.Export().Types, ShouldContain, "application/x-offset")
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Encoding identifies the Unicode encoding announced by a byte order mark
+type Encoding uint8
+
+const (
+	// EncodingNone indicates that there is no byte order mark
+	EncodingNone Encoding = iota
+	// EncodingUTF8 is the EF BB BF byte order mark
+	EncodingUTF8
+	// EncodingUTF16BE is the FE FF byte order mark
+	EncodingUTF16BE
+	// EncodingUTF16LE is the FF FE byte order mark
+	EncodingUTF16LE
+	// EncodingUTF32BE is the 00 00 FE FF byte order mark
+	EncodingUTF32BE
+	// EncodingUTF32LE is the FF FE 00 00 byte order mark
+	EncodingUTF32LE
+)
+
+// gByteOrderMarks are the byte order marks of each Encoding, longest first
+// so that UTF-32LE is not mistaken for UTF-16LE
+var gByteOrderMarks = []struct {
+	encoding Encoding
+	mark     []byte
+}{
+	{EncodingUTF32BE, []byte{0x00, 0x00, 0xfe, 0xff}},
+	{EncodingUTF32LE, []byte{0xff, 0xfe, 0x00, 0x00}},
+	{EncodingUTF8, []byte{0xef, 0xbb, 0xbf}},
+	{EncodingUTF16BE, []byte{0xfe, 0xff}},
+	{EncodingUTF16LE, []byte{0xff, 0xfe}},
+}
+
+// String returns the name of the Encoding
+func (e Encoding) String() string {
+	if e == EncodingNone {
+		return "none"
+	} else if charset := e.Charset(); charset != "" {
+		return charset
+	}
+	return "unknown"
+}
+
+// Charset returns the charset name of the Encoding, as reported by
+// DetectCharset, or an empty string for EncodingNone
+func (e Encoding) Charset() (charset string) {
+	switch e {
+	case EncodingUTF8:
+		return "utf-8"
+	case EncodingUTF16BE:
+		return "utf-16be"
+	case EncodingUTF16LE:
+		return "utf-16le"
+	case EncodingUTF32BE:
+		return "utf-32be"
+	case EncodingUTF32LE:
+		return "utf-32le"
+	}
+	return
+}
+
+// DetectBOM returns the Encoding of the byte order mark at the start of the
+// given `data` and the length of the byte order mark, which is zero when
+// there is none
+func DetectBOM(data []byte) (encoding Encoding, size int) {
+	for _, bom := range gByteOrderMarks {
+		if bytes.HasPrefix(data, bom.mark) {
+			return bom.encoding, len(bom.mark)
+		}
+	}
+	return EncodingNone, 0
+}
+
+// SkipBOM returns a reader of the content of `r` without any leading UTF-8,
+// UTF-16 or UTF-32 byte order mark, along with the Encoding the byte order
+// mark announced. The content is not transcoded, see ToUTF8 for converting
+// it. Errors encountered while looking for the byte order mark are returned
+// by the first Read of the returned reader
+func SkipBOM(r io.Reader) (reader io.Reader, encoding Encoding) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4)
+	var size int
+	if encoding, size = DetectBOM(head); size > 0 {
+		_, _ = br.Discard(size)
+	}
+	return br, encoding
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSkipBOM(t *testing.T) {
+	Convey("DetectBOM", t, func() {
+		for _, check := range []struct {
+			input    []byte
+			encoding Encoding
+			size     int
+		}{
+			{nil, EncodingNone, 0},
+			{[]byte("text"), EncodingNone, 0},
+			{[]byte{0xef, 0xbb, 0xbf, 'h'}, EncodingUTF8, 3},
+			{[]byte{0xfe, 0xff, 0, 'h'}, EncodingUTF16BE, 2},
+			{[]byte{0xff, 0xfe, 'h', 0}, EncodingUTF16LE, 2},
+			{[]byte{0, 0, 0xfe, 0xff}, EncodingUTF32BE, 4},
+			{[]byte{0xff, 0xfe, 0, 0, 'h', 0, 0, 0}, EncodingUTF32LE, 4},
+		} {
+			encoding, size := DetectBOM(check.input)
+			So(encoding, ShouldEqual, check.encoding)
+			So(size, ShouldEqual, check.size)
+		}
+		So(EncodingNone.String(), ShouldEqual, "none")
+		So(EncodingNone.Charset(), ShouldBeEmpty)
+		So(EncodingUTF32LE.String(), ShouldEqual, "utf-32le")
+		So(Encoding(99).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("SkipBOM", t, func() {
+		reader, encoding := SkipBOM(bytes.NewReader([]byte{0xef, 0xbb, 0xbf, 'h', 'i'}))
+		So(encoding, ShouldEqual, EncodingUTF8)
+		data, err := io.ReadAll(reader)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "hi")
+
+		reader, encoding = SkipBOM(iotest.OneByteReader(bytes.NewReader([]byte{0xff, 0xfe, 'h', 0})))
+		So(encoding, ShouldEqual, EncodingUTF16LE)
+		data, _ = io.ReadAll(reader)
+		So(data, ShouldResemble, []byte{'h', 0})
+
+		reader, encoding = SkipBOM(bytes.NewReader([]byte("hi")))
+		So(encoding, ShouldEqual, EncodingNone)
+		data, _ = io.ReadAll(reader)
+		So(string(data), ShouldEqual, "hi")
+
+		failure := errors.New("failure")
+		reader, encoding = SkipBOM(iotest.ErrReader(failure))
+		So(encoding, ShouldEqual, EncodingNone)
+		_, err = reader.Read(make([]byte, 1))
+		So(err, ShouldEqual, failure)
+	})
+
+	Convey("charset detection", t, func() {
+		utf32le := []byte{0xff, 0xfe, 0, 0, 'h', 0, 0, 0, 'i', 0, 0, 0}
+		So(DetectCharset(utf32le), ShouldEqual, "utf-32le")
+		So(PlainTextDetector(utf32le, 0), ShouldBeTrue)
+		So(AnalyzeText(utf32le).Script, ShouldEqual, "Latin")
+
+		converted, updated, err := ToUTF8("text/plain", utf32le)
+		So(err, ShouldBeNil)
+		So(string(converted), ShouldEqual, "hi")
+		So(updated, ShouldEqual, "text/plain; charset=utf-8")
+
+		converted, _, err = ToUTF8("text/plain; charset=iso-8859-1", []byte{0xfe, 0xff, 0, 'h'})
+		So(err, ShouldBeNil)
+		So(string(converted), ShouldEqual, "h")
+	})
+}
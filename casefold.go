@@ -0,0 +1,70 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+)
+
+// SetCaseFolding configures whether extensions are matched without regard
+// to case, which is the default, so that "README.TXT" and "notes.Md" are
+// classified the same as their lowercase spellings. Extensions registered
+// while case folding is enabled are stored lowercased
+func (r *Registry) SetCaseFolding(enabled bool) {
+	r.noCaseFolding.Store(!enabled)
+}
+
+// GetCaseFolding returns true if extensions are matched without regard to
+// case
+func (r *Registry) GetCaseFolding() (enabled bool) {
+	return !r.noCaseFolding.Load()
+}
+
+// SetCaseFolding configures extension case folding of the default Registry,
+// see Registry.SetCaseFolding
+func SetCaseFolding(enabled bool) {
+	gRegistry.SetCaseFolding(enabled)
+}
+
+// GetCaseFolding returns true if the default Registry matches extensions
+// without regard to case
+func GetCaseFolding() (enabled bool) {
+	return gRegistry.GetCaseFolding()
+}
+
+// normalizeExtension returns the `extension` without any leading period,
+// normalized according to the PathForm and lowercased when case folding is
+// enabled
+func (r *Registry) normalizeExtension(extension string) (normalized string) {
+	normalized = r.normalizePath(strings.TrimPrefix(extension, "."))
+	if r.GetCaseFolding() {
+		normalized = strings.ToLower(normalized)
+	}
+	return
+}
+
+// lookupExtension returns the mime type registered for the `extension`,
+// which is normalized with normalizeExtension. Extensions registered with
+// uppercase letters while case folding was disabled are still found by
+// their exact spelling
+func (r *Registry) lookupExtension(extension string) (mime string, ok bool) {
+	exact := r.normalizePath(strings.TrimPrefix(extension, "."))
+	if mime, ok = r.extensions.get(exact); !ok && r.GetCaseFolding() {
+		if folded := strings.ToLower(exact); folded != exact {
+			mime, ok = r.extensions.get(folded)
+		}
+	}
+	return
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCaseFolding(t *testing.T) {
+	Convey("case folding", t, func() {
+		r := New()
+		So(r.GetCaseFolding(), ShouldBeTrue)
+
+		expected, ok := r.GetExtension("md")
+		So(ok, ShouldBeTrue)
+		for _, extension := range []string{"Md", "MD", ".mD"} {
+			mime, found := r.GetExtension(extension)
+			So(found, ShouldBeTrue)
+			So(mime, ShouldEqual, expected)
+		}
+		So(r.FromPathOnly("README.MD"), ShouldEqual, expected)
+
+		r.SetExtension("CafÉ", "text/x-cafe")
+		So(r.FromPathOnly("menu.café"), ShouldEqual, "text/x-cafe")
+		So(r.FromPathOnly("menu.CAFÉ"), ShouldEqual, "text/x-cafe")
+		So(r.Export().Extensions, ShouldContainKey, "café")
+
+		r.SetCompoundExtension("tar.zst", "application/x-zstd-tar")
+		So(r.FromPathOnly("backup.TAR.ZST"), ShouldEqual, "application/x-zstd-tar")
+	})
+
+	Convey("disabled", t, func() {
+		r := New()
+		r.SetExtension("zq", "text/x-zq")
+		r.SetCaseFolding(false)
+		So(r.GetCaseFolding(), ShouldBeFalse)
+		_, ok := r.GetExtension("ZQ")
+		So(ok, ShouldBeFalse)
+
+		r.SetExtension("R", "text/x-r")
+		_, ok = r.GetExtension("r")
+		So(ok, ShouldBeFalse)
+
+		r.SetCaseFolding(true)
+		mime, found := r.GetExtension("R")
+		So(found, ShouldBeTrue)
+		So(mime, ShouldEqual, "text/x-r")
+
+		r.SetCaseFolding(false)
+		r.Reset()
+		So(r.GetCaseFolding(), ShouldBeTrue)
+	})
+
+	Convey("default registry", t, func() {
+		So(GetCaseFolding(), ShouldBeTrue)
+		SetCaseFolding(false)
+		So(GetCaseFolding(), ShouldBeFalse)
+		SetCaseFolding(true)
+	})
+}
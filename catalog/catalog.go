@@ -0,0 +1,133 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package catalog provides typed constants for commonly used mime types,
+// grouped by kind, so that downstream code need not scatter raw string
+// literals for common types
+package catalog
+
+import (
+	"github.com/go-corelibs/mime"
+)
+
+// MimeType is a mime type string, without any parameters
+type MimeType string
+
+// String returns the MimeType as a plain string
+func (m MimeType) String() string {
+	return string(m)
+}
+
+// Images
+const (
+	Avif MimeType = "image/avif"
+	Bmp  MimeType = "image/bmp"
+	Gif  MimeType = "image/gif"
+	Heic MimeType = "image/heic"
+	Ico  MimeType = "image/x-icon"
+	Jpeg MimeType = "image/jpeg"
+	Png  MimeType = "image/png"
+	Svg  MimeType = "image/svg+xml"
+	Tiff MimeType = "image/tiff"
+	Webp MimeType = "image/webp"
+)
+
+// Audio
+const (
+	Aac      MimeType = "audio/aac"
+	Flac     MimeType = "audio/flac"
+	Midi     MimeType = "audio/midi"
+	Mp3      MimeType = mime.Mp3MimeType
+	Mp4Audio MimeType = "audio/mp4"
+	OggAudio MimeType = "audio/ogg"
+	Wav      MimeType = "audio/wav"
+)
+
+// Video
+const (
+	Avi       MimeType = "video/x-msvideo"
+	Matroska  MimeType = "video/x-matroska"
+	Mp4       MimeType = "video/mp4"
+	Mpeg      MimeType = "video/mpeg"
+	OggVideo  MimeType = "video/ogg"
+	QuickTime MimeType = "video/quicktime"
+	Webm      MimeType = "video/webm"
+)
+
+// Documents
+const (
+	Doc  MimeType = "application/msword"
+	Docx MimeType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	Epub MimeType = "application/epub+zip"
+	Odp  MimeType = "application/vnd.oasis.opendocument.presentation"
+	Ods  MimeType = "application/vnd.oasis.opendocument.spreadsheet"
+	Odt  MimeType = "application/vnd.oasis.opendocument.text"
+	Pdf  MimeType = "application/pdf"
+	Ppt  MimeType = "application/vnd.ms-powerpoint"
+	Pptx MimeType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	Rtf  MimeType = "text/rtf"
+	Text MimeType = mime.TextMimeType
+	Xls  MimeType = "application/vnd.ms-excel"
+	Xlsx MimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Archives
+const (
+	Bzip2    MimeType = "application/x-bzip2"
+	Gzip     MimeType = mime.GzipMimeType
+	Iso      MimeType = mime.IsoMimeType
+	Rar      MimeType = "application/x-rar-compressed"
+	SevenZip MimeType = "application/x-7z-compressed"
+	Tar      MimeType = mime.TarMimeType
+	Xz       MimeType = "application/x-xz"
+	Zip      MimeType = mime.ZipMimeType
+	Zstd     MimeType = "application/zstd"
+)
+
+// Web
+const (
+	Atom       MimeType = "application/atom+xml"
+	Css        MimeType = mime.CssMimeType
+	Html       MimeType = mime.HtmlMimeType
+	JavaScript MimeType = mime.JavaScriptMimeType
+	Json       MimeType = mime.JsonMimeType
+	Rss        MimeType = "application/rss+xml"
+	Wasm       MimeType = "application/wasm"
+	Xml        MimeType = "text/xml"
+)
+
+// Go-Enjin formats
+const (
+	Enjin    MimeType = mime.EnjinMimeType
+	Markdown MimeType = mime.MarkdownMimeType
+	OrgMode  MimeType = mime.OrgModeMimeType
+	Scss     MimeType = mime.ScssMimeType
+)
+
+var (
+	// Images is the list of all image constants
+	Images = []MimeType{Avif, Bmp, Gif, Heic, Ico, Jpeg, Png, Svg, Tiff, Webp}
+	// Audio is the list of all audio constants
+	Audio = []MimeType{Aac, Flac, Midi, Mp3, Mp4Audio, OggAudio, Wav}
+	// Video is the list of all video constants
+	Video = []MimeType{Avi, Matroska, Mp4, Mpeg, OggVideo, QuickTime, Webm}
+	// Documents is the list of all document constants
+	Documents = []MimeType{Doc, Docx, Epub, Odp, Ods, Odt, Pdf, Ppt, Pptx, Rtf, Text, Xls, Xlsx}
+	// Archives is the list of all archive constants
+	Archives = []MimeType{Bzip2, Gzip, Iso, Rar, SevenZip, Tar, Xz, Zip, Zstd}
+	// Web is the list of all web constants
+	Web = []MimeType{Atom, Css, Html, JavaScript, Json, Rss, Wasm, Xml}
+	// EnjinFormats is the list of all Go-Enjin format constants
+	EnjinFormats = []MimeType{Enjin, Markdown, OrgMode, Scss}
+)
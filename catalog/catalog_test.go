@@ -0,0 +1,52 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-corelibs/mime"
+)
+
+func TestCatalog(t *testing.T) {
+	Convey("MimeType", t, func() {
+		So(Png.String(), ShouldEqual, "image/png")
+		So(mime.IsPlainText(Markdown.String()), ShouldBeTrue)
+	})
+
+	Convey("groups", t, func() {
+		check := func(group []MimeType, prefixes ...string) {
+			So(group, ShouldNotBeEmpty)
+			for _, m := range group {
+				So(mime.PruneCharset(m.String()), ShouldEqual, m.String())
+				found := false
+				for _, prefix := range prefixes {
+					found = found || strings.HasPrefix(m.String(), prefix)
+				}
+				So(found, ShouldBeTrue)
+			}
+		}
+		check(Images, "image/")
+		check(Audio, "audio/")
+		check(Video, "video/")
+		check(Documents, "application/", "text/")
+		check(Archives, "application/")
+		check(Web, "application/", "text/")
+		check(EnjinFormats, "text/")
+	})
+}
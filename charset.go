@@ -0,0 +1,52 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+// CharsetEqual returns true if the charset names `a` and `b` refer to the
+// same character encoding. Names are compared case-insensitively and
+// resolved through the IANA charset aliases, and then the WHATWG encoding
+// labels, so that "UTF-8", "utf8", "csUTF8" and "utf-8" are all equal. Names
+// unknown to both are equal when they match ignoring case, dashes and
+// underscores. Empty names are never equal
+func CharsetEqual(a, b string) bool {
+	a, b = canonicalCharset(a), canonicalCharset(b)
+	return a != "" && a == b
+}
+
+// canonicalCharset returns the canonical IANA name of the given charset
+func canonicalCharset(charset string) (canonical string) {
+	charset = strings.ToLower(strings.Trim(strings.TrimSpace(charset), `"`))
+	if charset == "" {
+		return
+	}
+	if enc, err := ianaindex.IANA.Encoding(charset); err == nil && enc != nil {
+		if canonical, err = ianaindex.IANA.Name(enc); err == nil {
+			return strings.ToLower(canonical)
+		}
+	}
+	if enc, err := htmlindex.Get(charset); err == nil {
+		if canonical, err = ianaindex.IANA.Name(enc); err == nil {
+			return strings.ToLower(canonical)
+		}
+	}
+	return strings.NewReplacer("-", "", "_", "").Replace(charset)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	goMime "mime"
+	"unicode/utf8"
+)
+
+// DetectCharset returns the charset of the given text `data`, determined by
+// byte order marks (see DetectBOM), UTF-8 validity and simple heuristics: UTF-16 text
+// without a byte order mark is recognized by the pattern of zero bytes and
+// other text that is not valid UTF-8 is reported as windows-1252 when it
+// uses the C1 range of bytes and iso-8859-1 otherwise. When `data` is a full
+// HeadWindow sample, a multi-byte rune cut off at the end is ignored. An
+// empty charset is returned for empty `data` and for content which appears
+// to be binary
+func DetectCharset(data []byte) (charset string) {
+	if len(data) == 0 {
+		return ""
+	} else if encoding, _ := DetectBOM(data); encoding != EncodingNone {
+		return encoding.Charset()
+	}
+
+	if bytes.IndexByte(data, 0) >= 0 {
+		var even, odd int
+		for idx, b := range data {
+			if b == 0 && idx%2 == 0 {
+				even += 1
+			} else if b == 0 {
+				odd += 1
+			}
+		}
+		half := len(data) / 2
+		switch {
+		case half > 0 && odd*10 >= half*9 && even == 0:
+			return "utf-16le"
+		case half > 0 && even*10 >= half*9 && odd == 0:
+			return "utf-16be"
+		}
+		return ""
+	}
+
+	valid := data
+	if len(valid) >= HeadWindow {
+		valid = trimPartialRune(valid)
+	}
+	if utf8.Valid(valid) {
+		return "utf-8"
+	}
+	for _, b := range data {
+		if b >= 0x80 && b <= 0x9f {
+			return "windows-1252"
+		}
+	}
+	return "iso-8859-1"
+}
+
+// trimPartialRune removes an incomplete UTF-8 sequence from the end of
+// `data`, which happens when a detection window splits a multi-byte rune
+func trimPartialRune(data []byte) (trimmed []byte) {
+	for idx := 1; idx <= utf8.UTFMax && idx <= len(data); idx++ {
+		if c := data[len(data)-idx]; c < 0x80 {
+			break
+		} else if utf8.RuneStart(c) {
+			if !utf8.FullRune(data[len(data)-idx:]) {
+				return data[:len(data)-idx]
+			}
+			break
+		}
+	}
+	return data
+}
+
+// withDetectedCharset sets the charset parameter of a textual `mime` to the
+// DetectCharset result for the `head` content
+func (r *Registry) withDetectedCharset(mime string, head []byte) (updated string) {
+	if !r.IsPlainText(mime) {
+		return mime
+	}
+	if len(head) >= r.GetReadLimit() {
+		// the detection window may have split a multi-byte rune
+		head = trimPartialRune(head)
+	}
+	charset := DetectCharset(head)
+	if charset == "" {
+		return mime
+	}
+	mediatype, params, err := goMime.ParseMediaType(mime)
+	if err != nil {
+		return mime
+	}
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["charset"] = charset
+	return goMime.FormatMediaType(mediatype, params)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectCharset(t *testing.T) {
+	Convey("DetectCharset", t, func() {
+		So(DetectCharset(nil), ShouldEqual, "")
+		So(DetectCharset([]byte("plain ascii")), ShouldEqual, "utf-8")
+		So(DetectCharset([]byte("caf\xc3\xa9")), ShouldEqual, "utf-8")
+		So(DetectCharset([]byte("caf\xc3")), ShouldEqual, "iso-8859-1")
+		So(DetectCharset(append(bytes.Repeat([]byte("a"), HeadWindow-1), 0xc3)), ShouldEqual, "utf-8")
+		So(DetectCharset([]byte("\xef\xbb\xbfbom")), ShouldEqual, "utf-8")
+		So(DetectCharset([]byte("\xfe\xff\x00h")), ShouldEqual, "utf-16be")
+		So(DetectCharset([]byte("\xff\xfeh\x00")), ShouldEqual, "utf-16le")
+		So(DetectCharset([]byte("h\x00i\x00!\x00")), ShouldEqual, "utf-16le")
+		So(DetectCharset([]byte("\x00h\x00i\x00!")), ShouldEqual, "utf-16be")
+		So(DetectCharset([]byte("caf\xe9")), ShouldEqual, "iso-8859-1")
+		So(DetectCharset([]byte("\x93quoted\x94")), ShouldEqual, "windows-1252")
+		So(DetectCharset([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")), ShouldEqual, "")
+
+		r := New()
+		So(r.RegisterTextType("text/x-notes", "notes", func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("NOTES"))
+		}), ShouldBeNil)
+		So(r.DetectBytes([]byte("NOTES caf\xe9")), ShouldEqual, "text/x-notes; charset=iso-8859-1")
+		So(r.DetectBytes([]byte("NOTES ascii")), ShouldEqual, "text/x-notes; charset=utf-8")
+
+		dir := t.TempDir()
+		So(os.WriteFile(filepath.Join(dir, "sample"), []byte("NOTES \x93hi\x94"), 0644), ShouldBeNil)
+		So(r.Mime(filepath.Join(dir, "sample")), ShouldEqual, "text/x-notes; charset=windows-1252")
+		So(os.WriteFile(filepath.Join(dir, "sample.notes"), []byte("caf\xe9"), 0644), ShouldBeNil)
+		So(r.Mime(filepath.Join(dir, "sample.notes")), ShouldEqual, "text/x-notes; charset=iso-8859-1")
+		So(os.WriteFile(filepath.Join(dir, "ascii.notes"), []byte("plain"), 0644), ShouldBeNil)
+		So(r.Mime(filepath.Join(dir, "ascii.notes")), ShouldEqual, "text/x-notes; charset=utf-8")
+		r.SetOutputPolicy(BareType)
+		So(r.Mime(filepath.Join(dir, "sample.notes")), ShouldEqual, "text/x-notes")
+
+		Convey("every variant", func() {
+			const expected = "text/plain; charset=iso-8859-1"
+			r := New()
+			path := filepath.Join(dir, "latin.txt")
+			So(os.WriteFile(path, []byte("caf\xe9 cr\xe8me"), 0644), ShouldBeNil)
+
+			So(r.Mime(path), ShouldEqual, expected)
+			So(r.MimeWith(path), ShouldEqual, expected)
+			So(r.MimeWith(path, ContentFirst()), ShouldEqual, expected)
+			So(r.MimeWith(path, WithStrategy(StrategyContentVerified)), ShouldEqual, expected)
+			mime, err := r.MimeE(path)
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, expected)
+			result, err := r.Detect(path)
+			So(err, ShouldBeNil)
+			So(result.Mime, ShouldEqual, expected)
+			So(result.Source, ShouldEqual, SourceExtension)
+			d, err := r.Describe(path)
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, expected)
+			So(NewPathCache(r, 4).Mime(path), ShouldEqual, expected)
+			So(r.MimeFS(os.DirFS(dir), "latin.txt"), ShouldEqual, expected)
+		})
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCharsetEqual(t *testing.T) {
+	Convey("CharsetEqual", t, func() {
+		So(CharsetEqual("UTF-8", "utf-8"), ShouldBeTrue)
+		So(CharsetEqual("utf8", "utf-8"), ShouldBeTrue)
+		So(CharsetEqual("csUTF8", `"UTF-8"`), ShouldBeTrue)
+		So(CharsetEqual("latin1", "ISO-8859-1"), ShouldBeTrue)
+		So(CharsetEqual("x_custom", "X-Custom"), ShouldBeTrue)
+		So(CharsetEqual("utf-8", "utf-16"), ShouldBeFalse)
+		So(CharsetEqual("", ""), ShouldBeFalse)
+	})
+
+	Convey("GetCharset with a charset parameter", t, func() {
+		r := New()
+		charset, ok := r.GetCharset("text/html; charset=UTF8")
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "utf-8")
+		charset, ok = r.GetCharset("text/html; charset=iso-8859-1")
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "iso-8859-1")
+		charset, ok = r.GetCharset("nope/nope; charset=utf-16")
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "utf-16")
+		_, ok = r.GetCharset("nope/nope")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultClassifyDepth is the MaxDepth used when ClassifyLimits.MaxDepth
+	// is zero
+	DefaultClassifyDepth = 4
+	// DefaultClassifyBytes is the MaxBytes used when ClassifyLimits.MaxBytes
+	// is zero
+	DefaultClassifyBytes = 64 << 20
+)
+
+// ClassifyLimits bounds the resources used by Classify
+type ClassifyLimits struct {
+	// MaxDepth is the maximum number of nested containers opened, the
+	// outermost container is depth one
+	MaxDepth int
+	// MaxBytes is the total number of bytes that may be buffered in memory
+	// while opening nested containers and, separately, the total number of
+	// bytes that may be decompressed from gzip streams while walking the
+	// containers, see ErrDecompressionLimit
+	MaxBytes int64
+}
+
+// Node is a single classified item within the tree returned by Classify
+type Node struct {
+	// Name is the path of the item, relative to its containing Node
+	Name string
+	// Size is the size of the item in bytes, -1 if not known
+	Size int64
+	// Mime is the detected mime type of the item
+	Mime string
+	// Children are the members of the item when it is a container
+	Children []*Node
+	// Truncated is true when the item is a container that was not opened
+	// due to the ClassifyLimits
+	Truncated bool
+}
+
+// Classify opens the file at the given `path` and uses ClassifyAt to build
+// a tree of the types of any containers-within-containers
+func Classify(path string, limits ClassifyLimits) (root *Node, err error) {
+	var fh *os.File
+	if gRegistry.GetPathOnly() {
+		err = ErrPathOnly
+		return
+	} else if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	var info os.FileInfo
+	if info, err = fh.Stat(); err != nil {
+		return
+	}
+	return ClassifyAt(fh, info.Size(), filepath.Base(path), limits)
+}
+
+// ClassifyAt detects the type of the content within `r`, which is `size`
+// bytes long, and when it is an archive supported by ListArchiveAt,
+// recursively classifies the members of the archive. Nested archives are
+// buffered in memory, bounded by the given `limits`, and any nested archive
+// that would exceed the limits is marked as Truncated instead of opened.
+// ClassifyAt stops with ErrDecompressionLimit when the compressed archives
+// expand to more than the limits allow
+func ClassifyAt(r io.ReaderAt, size int64, name string, limits ClassifyLimits) (root *Node, err error) {
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultClassifyDepth
+	}
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = DefaultClassifyBytes
+	}
+	root = &Node{Name: name, Size: size}
+	if root.Mime, err = DetectAt(r, size); err != nil {
+		return
+	}
+	budget, remaining := limits.MaxBytes, limits.MaxBytes
+	err = classifyContainer(root, r, size, 1, limits.MaxDepth, &budget, &remaining)
+	return
+}
+
+func classifyContainer(node *Node, r io.ReaderAt, size int64, depth, maxDepth int, budget, remaining *int64) (err error) {
+	if !IsArchive(node.Mime) {
+		return
+	}
+	if depth > maxDepth {
+		node.Truncated = true
+		return
+	}
+	err = walkArchive(r, size, remaining, func(entry ArchiveEntry, content io.Reader) (err error) {
+		child := &Node{Name: entry.Name, Size: entry.Size, Mime: entry.Mime}
+		node.Children = append(node.Children, child)
+		if content == nil || !IsArchive(child.Mime) {
+			return
+		}
+		if depth+1 > maxDepth || child.Size > *budget {
+			child.Truncated = true
+			return
+		}
+		var data []byte
+		if data, err = io.ReadAll(io.LimitReader(content, *budget+1)); err != nil {
+			return
+		} else if int64(len(data)) > *budget {
+			child.Truncated = true
+			return
+		}
+		*budget -= int64(len(data))
+		child.Size = int64(len(data))
+		return classifyContainer(child, bytes.NewReader(data), child.Size, depth+1, maxDepth, budget, remaining)
+	})
+	if err == ErrNotArchive {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func makeNestedTestZip() []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("inner")
+	_, _ = w.Write(makeTestTarGz())
+	w, _ = zw.Create("notes.txt")
+	_, _ = w.Write([]byte("notes"))
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// makeTestBomb returns a gzip compressed tar archive holding a single
+// member of `size` zero bytes
+func makeTestBomb(size int64) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	_ = tw.WriteHeader(&tar.Header{Name: "zeros", Mode: 0o644, Size: size})
+	_, _ = tw.Write(make([]byte, size))
+	_ = tw.Close()
+	_ = gw.Close()
+	return buf.Bytes()
+}
+
+func TestClassify(t *testing.T) {
+	data := makeNestedTestZip()
+
+	Convey("ClassifyAt", t, func() {
+		Convey("default limits", func() {
+			root, err := ClassifyAt(bytes.NewReader(data), int64(len(data)), "upload", ClassifyLimits{})
+			So(err, ShouldBeNil)
+			So(root.Name, ShouldEqual, "upload")
+			So(root.Mime, ShouldEqual, ZipMimeType)
+			So(root.Children, ShouldHaveLength, 2)
+			inner := root.Children[0]
+			So(inner.Mime, ShouldEqual, GzipMimeType)
+			So(inner.Truncated, ShouldBeFalse)
+			So(inner.Children, ShouldHaveLength, 3)
+			So(inner.Children[1].Name, ShouldEqual, "docs/README.md")
+			So(inner.Children[2].Mime, ShouldEqual, "image/png")
+			So(root.Children[1].Mime, ShouldEqual, "text/plain; charset=utf-8")
+		})
+
+		Convey("depth limit", func() {
+			root, err := ClassifyAt(bytes.NewReader(data), int64(len(data)), "upload", ClassifyLimits{MaxDepth: 1})
+			So(err, ShouldBeNil)
+			So(root.Children, ShouldHaveLength, 2)
+			So(root.Children[0].Truncated, ShouldBeTrue)
+			So(root.Children[0].Children, ShouldBeEmpty)
+		})
+
+		Convey("byte budget", func() {
+			root, err := ClassifyAt(bytes.NewReader(data), int64(len(data)), "upload", ClassifyLimits{MaxBytes: 10})
+			So(err, ShouldBeNil)
+			So(root.Children[0].Truncated, ShouldBeTrue)
+		})
+
+		Convey("decompression limit", func() {
+			bomb := makeTestBomb(4 << 20)
+			So(len(bomb), ShouldBeLessThan, 64<<10)
+			_, err := ClassifyAt(bytes.NewReader(bomb), int64(len(bomb)), "bomb", ClassifyLimits{MaxBytes: 1 << 20})
+			So(errors.Is(err, ErrDecompressionLimit), ShouldBeTrue)
+
+			root, err := ClassifyAt(bytes.NewReader(bomb), int64(len(bomb)), "bomb", ClassifyLimits{MaxBytes: 8 << 20})
+			So(err, ShouldBeNil)
+			So(root.Children, ShouldHaveLength, 1)
+			So(root.Children[0].Name, ShouldEqual, "zeros")
+
+			// the limit is only exceeded by content beyond it
+			exact := makeTestBomb(512)
+			root, err = ClassifyAt(bytes.NewReader(exact), int64(len(exact)), "exact", ClassifyLimits{MaxBytes: 2048})
+			So(err, ShouldBeNil)
+			So(root.Children, ShouldHaveLength, 1)
+		})
+
+		Convey("not a container", func() {
+			text := []byte("%PDF-1.4\n")
+			root, err := ClassifyAt(bytes.NewReader(text), int64(len(text)), "doc", ClassifyLimits{})
+			So(err, ShouldBeNil)
+			So(root.Mime, ShouldEqual, "application/pdf")
+			So(root.Children, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Classify", t, func() {
+		_, err := Classify("./testdata/not-a-file", ClassifyLimits{})
+		So(err, ShouldNotBeNil)
+		root, err := Classify("./testdata/empty-png", ClassifyLimits{})
+		So(err, ShouldBeNil)
+		So(root.Name, ShouldEqual, "empty-png")
+		So(root.Mime, ShouldEqual, "image/png")
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+)
+
+const (
+	// GzipTarMimeType defines the mime type used for gzip compressed tarballs
+	GzipTarMimeType = "application/x-compressed-tar"
+	// Bzip2TarMimeType defines the mime type used for bzip2 compressed
+	// tarballs
+	Bzip2TarMimeType = "application/x-bzip2-compressed-tar"
+	// XzTarMimeType defines the mime type used for xz compressed tarballs
+	XzTarMimeType = "application/x-xz-compressed-tar"
+	// ZstdTarMimeType defines the mime type used for zstd compressed tarballs
+	ZstdTarMimeType = "application/x-zstd-compressed-tar"
+)
+
+// gCompoundExtensions are the built-in multi-part extensions of a new
+// Registry
+var gCompoundExtensions = map[string]string{
+	"tar.gz":  GzipTarMimeType,
+	"tar.bz2": Bzip2TarMimeType,
+	"tar.xz":  XzTarMimeType,
+	"tar.zst": ZstdTarMimeType,
+}
+
+// SetCompoundExtension registers a multi-part `extension`, such as "tar.lz",
+// with the given mime type. Compound extensions are stored with the single
+// extensions, so this is the same as calling SetExtension. FromPathOnly
+// prefers the longest compound extension ending a file name over the final
+// extension alone. If `mime` is empty, the extension is cleared
+func (r *Registry) SetCompoundExtension(extension, mime string) {
+	r.SetExtension(extension, mime)
+}
+
+// SetCompoundExtension registers a multi-part `extension` with the default
+// Registry, see Registry.SetCompoundExtension
+func SetCompoundExtension(extension, mime string) {
+	gRegistry.SetCompoundExtension(extension, mime)
+}
+
+// fromCompound returns the mime type of the longest registered compound
+// extension ending the base name of the already normalized `full` path
+func (r *Registry) fromCompound(full string) (mime string, ok bool) {
+	base := strings.TrimLeft(r.baseName(full), ".")
+	for idx := strings.IndexByte(base, '.'); idx >= 0; {
+		suffix := base[idx+1:]
+		next := strings.IndexByte(suffix, '.')
+		if next < 0 {
+			// single extensions are handled by FromPathOnly
+			break
+		} else if mime, ok = r.lookupExtension(suffix); ok {
+			mime = r.output(mime)
+			return
+		}
+		idx += next + 1
+	}
+	return
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompoundExtensions(t *testing.T) {
+	Convey("Compound extensions", t, func() {
+		r := New()
+		So(r.FromPathOnly("release.tar.gz"), ShouldEqual, GzipTarMimeType)
+		So(r.FromPathOnly("dist/v1.2/release.tar.bz2"), ShouldEqual, Bzip2TarMimeType)
+		So(r.FromPathOnly("release-1.0.tar.xz"), ShouldEqual, XzTarMimeType)
+		So(r.FromPathOnly("release.tar.zst"), ShouldEqual, ZstdTarMimeType)
+		So(r.FromPathOnly("release.gz"), ShouldNotEqual, GzipTarMimeType)
+		So(r.FromPathOnly(".tar.gz"), ShouldNotEqual, GzipTarMimeType)
+		So(r.FromPathOnly("file.html.tmpl"), ShouldEqual, "text/html; charset=utf-8")
+
+		r.SetCompoundExtension("tar.lz", "application/x-lzip-compressed-tar")
+		So(r.FromPathOnly("release.tar.lz"), ShouldEqual, "application/x-lzip-compressed-tar")
+		r.SetCompoundExtension("backup.tar.gz", "application/x-backup")
+		So(r.FromPathOnly("site.backup.tar.gz"), ShouldEqual, "application/x-backup")
+		So(r.FromPathOnly("site.tar.gz"), ShouldEqual, GzipTarMimeType)
+		So(r.SuggestFilename("release", XzTarMimeType), ShouldEqual, "release.tar.xz")
+
+		r.SetCompoundExtension("tar.lz", "")
+		So(r.FromPathOnly("release.tar.lz"), ShouldNotEqual, "application/x-lzip-compressed-tar")
+
+		SetCompoundExtension("tar.testing", "application/x-testing-tar")
+		So(FromPathOnly("a.tar.testing"), ShouldEqual, "application/x-testing-tar")
+		SetCompoundExtension("tar.testing", "")
+	})
+}
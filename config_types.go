@@ -0,0 +1,150 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+)
+
+const (
+	// YamlMimeType is the RFC 9512 mime type of YAML documents
+	YamlMimeType = "application/yaml"
+	// TomlMimeType is the mime type of TOML documents
+	TomlMimeType = "application/toml"
+)
+
+const (
+	// YamlExtension defines the file extension associated with the
+	// YamlMimeType, the "yml" extension is associated as well
+	YamlExtension = "yaml"
+	// TomlExtension defines the file extension associated with the
+	// TomlMimeType
+	TomlExtension = "toml"
+)
+
+func registerConfigTypes() {
+	// both detectors match loosely structured text, so consult them after
+	// the other built-in detectors such as the EmailDetector
+	initError(RegisterTextTypePriority(YamlMimeType, YamlExtension, YamlDetector, -1))
+	SetExtension("yml", YamlMimeType+"; charset=utf-8")
+	initError(addExtensionType(".yml", YamlMimeType))
+	initError(RegisterTextTypePriority(TomlMimeType, TomlExtension, TomlDetector, -1))
+}
+
+// YamlDetector returns true if the given `raw` content starts with a YAML
+// directive or document marker, or if at least four in five of the lines
+// that are not blank or comments, including the first one, are YAML mapping
+// entries, list items or indented continuations of them
+func YamlDetector(raw []byte, limit uint32) bool {
+	lines := significantLines(raw, limit)
+	if len(lines) == 0 {
+		return false
+	} else if first := lines[0]; bytes.HasPrefix(first, []byte("%YAML ")) || bytes.Equal(first, []byte("---")) || bytes.HasPrefix(first, []byte("--- ")) {
+		return true
+	}
+	return structuredLines(lines, isYamlLine)
+}
+
+// TomlDetector returns true if at least four in five of the lines of the
+// given `raw` content that are not blank or comments, including the first
+// one, are TOML table headers or key = value pairs
+func TomlDetector(raw []byte, limit uint32) bool {
+	return structuredLines(significantLines(raw, limit), isTomlLine)
+}
+
+// significantLines returns the lines of the leading `limit` bytes of `raw`
+// which are not blank or comments, without a possibly truncated last line
+func significantLines(raw []byte, limit uint32) (lines [][]byte) {
+	if limit > 0 && len(raw) > int(limit) {
+		raw = raw[:limit]
+	}
+	_, size := DetectBOM(raw)
+	all := bytes.Split(raw[size:], []byte("\n"))
+	if len(all) > 1 && len(all[len(all)-1]) > 0 {
+		// possibly truncated by the limit
+		all = all[:len(all)-1]
+	}
+	for _, line := range all {
+		line = bytes.TrimRight(line, " \t\r")
+		if trimmed := bytes.TrimLeft(line, " \t"); len(trimmed) > 0 && trimmed[0] != '#' {
+			lines = append(lines, line)
+		}
+	}
+	return
+}
+
+// structuredLines returns true when there are at least two `lines`, the
+// first line matches and at least four in five lines match
+func structuredLines(lines [][]byte, match func(line []byte) bool) bool {
+	if len(lines) < 2 || !match(lines[0]) {
+		return false
+	}
+	var matched int
+	for _, line := range lines {
+		if match(line) {
+			matched += 1
+		}
+	}
+	return matched*5 >= len(lines)*4
+}
+
+// isYamlLine returns true for YAML mapping entries ("key: value" or
+// "key:"), list items ("- item") and indented lines
+func isYamlLine(line []byte) bool {
+	switch {
+	case line[0] == ' ':
+		return true
+	case bytes.Equal(line, []byte("-")), bytes.HasPrefix(line, []byte("- ")):
+		return true
+	case bytes.Equal(line, []byte("...")), bytes.Equal(line, []byte("---")):
+		return true
+	}
+	key, _, found := bytes.Cut(line, []byte(":"))
+	if !found || len(key) == 0 || !(len(line) == len(key)+1 || line[len(key)+1] == ' ') {
+		return false
+	}
+	return isConfigKey(key, true)
+}
+
+// isTomlLine returns true for TOML table headers ("[table]" and
+// "[[array]]") and key = value pairs
+func isTomlLine(line []byte) bool {
+	line = bytes.TrimLeft(line, " \t")
+	if line[0] == '[' {
+		return bytes.HasSuffix(line, []byte("]")) && len(bytes.Trim(line, "[]")) > 0
+	}
+	key, value, found := bytes.Cut(line, []byte("="))
+	key, value = bytes.TrimSpace(key), bytes.TrimSpace(value)
+	return found && len(key) > 0 && len(value) > 0 && isConfigKey(key, false)
+}
+
+// isConfigKey returns true if `key` is a quoted string or consists of
+// letters, digits, underscores, dashes and periods, and also spaces when
+// `spaces` is true
+func isConfigKey(key []byte, spaces bool) bool {
+	if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		return true
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '-', c == '.':
+		case c == ' ' && spaces:
+		default:
+			return false
+		}
+	}
+	return true
+}
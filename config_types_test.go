@@ -0,0 +1,78 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigTypes(t *testing.T) {
+	Convey("YamlDetector", t, func() {
+		for _, data := range []string{
+			"%YAML 1.2\n---\nname: value\n",
+			"---\nname: value\n",
+			"# settings\nname: example\nlist:\n  - one\n  - two\nnested:\n  key: value\n",
+			"- one\n- two\n- three\n",
+		} {
+			So(YamlDetector([]byte(data), 0), ShouldBeTrue)
+		}
+		for _, data := range []string{
+			"",
+			"name: value",
+			"# Title\n\nSome prose: with a colon.\nMore prose follows here.\nAnd here.\n",
+			"{\n  \"name\": \"value\",\n  \"list\": [1, 2]\n}\n",
+			"url=https://example.com\nkey: value\n",
+		} {
+			So(YamlDetector([]byte(data), 0), ShouldBeFalse)
+		}
+	})
+
+	Convey("TomlDetector", t, func() {
+		for _, data := range []string{
+			"title = \"example\"\n\n[owner]\nname = \"someone\"\n",
+			"# comment\n[[products]]\nname = \"Hammer\"\nsku = 738594937\n",
+			"\"quoted key\" = true\nplain.dotted = 1\n",
+		} {
+			So(TomlDetector([]byte(data), 0), ShouldBeTrue)
+		}
+		for _, data := range []string{
+			"",
+			"[only]",
+			"# Heading\n\nSome text = more text here\nand other prose\nand more prose\n",
+			"name: value\nother: value\n",
+		} {
+			So(TomlDetector([]byte(data), 0), ShouldBeFalse)
+		}
+	})
+
+	Convey("registration", t, func() {
+		for _, check := range []struct {
+			path string
+			mime string
+		}{
+			{"config.yaml", YamlMimeType},
+			{"config.yml", YamlMimeType},
+			{"Cargo.toml", TomlMimeType},
+		} {
+			So(PruneCharset(FromPathOnly(check.path)), ShouldEqual, check.mime)
+		}
+		So(PruneCharset(DetectBytes([]byte("name: example\nversion: 1\n"))), ShouldEqual, YamlMimeType)
+		So(PruneCharset(DetectBytes([]byte("[package]\nname = \"example\"\n"))), ShouldEqual, TomlMimeType)
+		So(IsPlainText(YamlMimeType), ShouldBeTrue)
+		So(Validate(), ShouldBeNil)
+	})
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// MaxContentTypesSize is the maximum number of bytes of the Office Open XML
+// "[Content_Types].xml" member read when matching ContainerRule values
+const MaxContentTypesSize = 64 << 10
+
+// ContainerRule identifies a format stored within a ZIP container by the
+// names and metadata of its members. When content is detected as a ZIP
+// container (see IsZipContainer) and the whole of the content is available,
+// such as with Mime and DetectAt, the members of the container are listed
+// and the first matching rule, highest Priority first, replaces the result.
+// All of the conditions given must match
+type ContainerRule struct {
+	// Mime is the mime type reported when the rule matches
+	Mime string
+	// Members are the member names that must all be present, names ending
+	// with a slash match any member within that directory
+	Members []string
+	// Mimetype, when not empty, must be the content of a "mimetype" member,
+	// as used by the EPUB and OpenDocument formats
+	Mimetype string
+	// ContentType, when not empty, must be the content type of one of the
+	// parts listed by the "[Content_Types].xml" member, as used by the Office
+	// Open XML formats
+	ContentType string
+	// Priority orders the rules, higher priorities are checked first
+	Priority int
+}
+
+type containerRuleList struct {
+	rules []ContainerRule
+	sync.RWMutex
+}
+
+// defaultContainerRules returns the built-in ContainerRule list of a new
+// Registry
+func defaultContainerRules() (l *containerRuleList) {
+	l = &containerRuleList{}
+	for _, rule := range []ContainerRule{
+		{Mime: "application/epub+zip", Mimetype: "application/epub+zip"},
+		{Mime: OdtMimeType, Mimetype: OdtMimeType},
+		{Mime: OttMimeType, Mimetype: OttMimeType},
+		{Mime: OdsMimeType, Mimetype: OdsMimeType},
+		{Mime: OtsMimeType, Mimetype: OtsMimeType},
+		{Mime: OdpMimeType, Mimetype: OdpMimeType},
+		{Mime: OtpMimeType, Mimetype: OtpMimeType},
+		{Mime: OdgMimeType, Mimetype: OdgMimeType},
+		// office open xml documents lacking a recognized main part
+		{Mime: DocxMimeType, Members: []string{"[Content_Types].xml", "word/"}},
+		{Mime: XlsxMimeType, Members: []string{"[Content_Types].xml", "xl/"}},
+		{Mime: PptxMimeType, Members: []string{"[Content_Types].xml", "ppt/"}},
+		// android packages are also java archives
+		{Mime: "application/vnd.android.package-archive", Members: []string{"AndroidManifest.xml", "classes.dex"}, Priority: 1},
+		{Mime: "application/jar", Members: []string{"META-INF/MANIFEST.MF"}},
+	} {
+		l.add(rule)
+	}
+	for _, mime := range sortedKeys(gOOXMLContentTypes) {
+		l.add(ContainerRule{Mime: mime, ContentType: gOOXMLContentTypes[mime], Priority: 2})
+	}
+	return
+}
+
+// add inserts the `rule` ordered by Priority, most recently added first
+// among equal priorities
+func (l *containerRuleList) add(rule ContainerRule) {
+	l.Lock()
+	defer l.Unlock()
+	idx := sort.Search(len(l.rules), func(i int) bool {
+		return l.rules[i].Priority <= rule.Priority
+	})
+	l.rules = append(l.rules, ContainerRule{})
+	copy(l.rules[idx+1:], l.rules[idx:])
+	l.rules[idx] = rule
+}
+
+func (l *containerRuleList) snapshot() (rules []ContainerRule) {
+	l.RLock()
+	defer l.RUnlock()
+	return append([]ContainerRule(nil), l.rules...)
+}
+
+// remove deletes all rules of the given `mediatype`, returning true if any
+// were present
+func (l *containerRuleList) remove(mediatype string) (removed bool) {
+	l.Lock()
+	defer l.Unlock()
+	kept := make([]ContainerRule, 0, len(l.rules))
+	for _, rule := range l.rules {
+		if PruneCharset(rule.Mime) == mediatype {
+			removed = true
+			continue
+		}
+		kept = append(kept, rule)
+	}
+	l.rules = kept
+	return
+}
+
+func (l *containerRuleList) replace(rules []ContainerRule) {
+	l.Lock()
+	defer l.Unlock()
+	l.rules = rules
+}
+
+// RegisterContainerRule adds the given ContainerRule to the Registry
+func (r *Registry) RegisterContainerRule(rule ContainerRule) (err error) {
+	defer r.logRegistration(rule.Mime, &err)
+	if rule.Mime == "" || (len(rule.Members) == 0 && rule.Mimetype == "" && rule.ContentType == "") {
+		return errors.New("container rule must have a mime type and something to match")
+	}
+	r.containers.add(rule)
+	return
+}
+
+// RegisterContainerRule adds the given ContainerRule to the default
+// Registry, see Registry.RegisterContainerRule
+func RegisterContainerRule(rule ContainerRule) (err error) {
+	return gRegistry.RegisterContainerRule(rule)
+}
+
+// IsZipContainer returns true if the given `mime` is ZipMimeType or a type
+// stored within a ZIP container, according to the ContainerRule list of the
+// Registry and the github.com/gabriel-vasile/mimetype hierarchy
+func (r *Registry) IsZipContainer(mime string) bool {
+	mediatype := PruneCharset(mime)
+	for _, rule := range r.containers.snapshot() {
+		if rule.Mime == mediatype {
+			return true
+		}
+	}
+	for mt := mimetype.Lookup(mediatype); mt != nil; mt = mt.Parent() {
+		if mt.Is(ZipMimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZipContainer returns true if the given `mime` is stored within a ZIP
+// container, see Registry.IsZipContainer
+func IsZipContainer(mime string) bool {
+	return gRegistry.IsZipContainer(mime)
+}
+
+// fromContainer returns the mime type of the first ContainerRule matching
+// the members of the ZIP content within `ra`
+func (r *Registry) fromContainer(ra io.ReaderAt, size int64) (mime string, ok bool) {
+	rules := r.containers.snapshot()
+	if len(rules) == 0 {
+		return
+	}
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return
+	}
+	members := make(map[string]*zip.File, len(zr.File))
+	dirs := make(map[string]struct{})
+	for _, file := range zr.File {
+		members[file.Name] = file
+		for idx := 0; idx < len(file.Name); idx++ {
+			if file.Name[idx] == '/' {
+				dirs[file.Name[:idx+1]] = struct{}{}
+			}
+		}
+	}
+	var mimetypeValue []byte
+	if file, present := members["mimetype"]; present {
+		mimetypeValue = readMember(file, 256)
+	}
+	var contentTypes map[string]struct{}
+	if file, present := members["[Content_Types].xml"]; present {
+		contentTypes = parseContentTypes(readMember(file, MaxContentTypesSize))
+	}
+
+	for _, rule := range rules {
+		if rule.Mimetype != "" && string(bytes.TrimSpace(mimetypeValue)) != rule.Mimetype {
+			continue
+		} else if _, present := contentTypes[strings.ToLower(rule.ContentType)]; rule.ContentType != "" && !present {
+			continue
+		}
+		matched := true
+		for _, name := range rule.Members {
+			if _, present := members[name]; !present {
+				if _, present = dirs[name]; !present {
+					matched = false
+					break
+				}
+			}
+		}
+		if matched {
+			return rule.Mime, true
+		}
+	}
+	return
+}
+
+// cContainerBufferLimit is the largest fs.FS file without io.ReaderAt
+// support that fromContainerFile buffers in memory
+const cContainerBufferLimit = 64 << 20
+
+// fromContainerFile is fromContainer for the file at `path` within `fsys`,
+// nil for the local filesystem, which is never opened in path-only mode.
+// Files of an fs.FS which do not implement io.ReaderAt are buffered in
+// memory, up to cContainerBufferLimit bytes
+func (r *Registry) fromContainerFile(fsys fs.FS, path string) (mime string, ok bool) {
+	var fh fs.File
+	var err error
+	if fsys == nil {
+		if r.GetPathOnly() {
+			return
+		}
+		fh, err = os.Open(path)
+	} else {
+		fh, err = fsys.Open(path)
+	}
+	if err != nil {
+		return
+	}
+	defer fh.Close()
+	var info fs.FileInfo
+	if info, err = fh.Stat(); err != nil {
+		return
+	} else if ra, isReaderAt := fh.(io.ReaderAt); isReaderAt {
+		return r.fromContainer(ra, info.Size())
+	} else if info.Size() > cContainerBufferLimit {
+		return
+	}
+	var data []byte
+	if data, err = io.ReadAll(io.LimitReader(fh, cContainerBufferLimit+1)); err != nil || len(data) > cContainerBufferLimit {
+		return
+	}
+	return r.fromContainer(bytes.NewReader(data), int64(len(data)))
+}
+
+// readMember returns up to `limit` leading bytes of the ZIP `file`
+func readMember(file *zip.File, limit int64) (data []byte) {
+	rc, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer rc.Close()
+	data, _ = io.ReadAll(io.LimitReader(rc, limit))
+	return
+}
+
+// parseContentTypes returns the lowercased content types declared by the
+// Default and Override elements of an Office Open XML "[Content_Types].xml"
+// member
+func parseContentTypes(data []byte) (types map[string]struct{}) {
+	var parsed struct {
+		Defaults []struct {
+			ContentType string `xml:"ContentType,attr"`
+		} `xml:"Default"`
+		Overrides []struct {
+			ContentType string `xml:"ContentType,attr"`
+		} `xml:"Override"`
+	}
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return
+	}
+	types = make(map[string]struct{})
+	for _, d := range parsed.Defaults {
+		types[strings.ToLower(d.ContentType)] = struct{}{}
+	}
+	for _, o := range parsed.Overrides {
+		types[strings.ToLower(o.ContentType)] = struct{}{}
+	}
+	return
+}
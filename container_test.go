@@ -0,0 +1,97 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// makeTestContainer returns a ZIP archive of the given name and content
+// pairs, preceded by a stored member large enough to hide the others from
+// the leading bytes checked by github.com/gabriel-vasile/mimetype
+func makeTestContainer(members ...string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	padding, _ := zw.CreateHeader(&zip.FileHeader{Name: "padding.bin", Method: zip.Store})
+	_, _ = padding.Write(bytes.Repeat([]byte{0x5a}, HeadWindow*2))
+	for idx := 0; idx+1 < len(members); idx += 2 {
+		w, _ := zw.Create(members[idx])
+		_, _ = w.Write([]byte(members[idx+1]))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func TestContainer(t *testing.T) {
+	Convey("container rules", t, func() {
+		for _, check := range []struct {
+			data []byte
+			mime string
+		}{
+			{makeTestContainer("[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>"), "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+			{makeTestContainer("[Content_Types].xml", "<Types/>", "xl/workbook.xml", "<workbook/>"), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+			{makeTestContainer("[Content_Types].xml", "<Types/>", "ppt/presentation.xml", "<p/>"), "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+			{makeTestContainer("mimetype", "application/epub+zip", "OEBPS/content.opf", "<package/>"), "application/epub+zip"},
+			{makeTestContainer("mimetype", "application/vnd.oasis.opendocument.spreadsheet", "content.xml", "<x/>"), "application/vnd.oasis.opendocument.spreadsheet"},
+			{makeTestContainer("META-INF/MANIFEST.MF", "Manifest-Version: 1.0\n"), "application/jar"},
+			{makeTestContainer("META-INF/MANIFEST.MF", "Manifest-Version: 1.0\n", "AndroidManifest.xml", "", "classes.dex", "dex"), "application/vnd.android.package-archive"},
+			{makeTestContainer("mimetype", "application/x-unknown", "readme.txt", "hello"), ZipMimeType},
+			{makeTestContainer("word/document.xml", "<w:document/>"), ZipMimeType},
+		} {
+			mime, err := DetectAt(bytes.NewReader(check.data), int64(len(check.data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, check.mime)
+		}
+	})
+
+	Convey("Mime", t, func() {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "upload")
+		So(os.WriteFile(path, makeTestContainer("[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>"), 0644), ShouldBeNil)
+		So(New().Mime(path), ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+
+		entries, err := ListArchive(path)
+		So(err, ShouldBeNil)
+		So(entries, ShouldHaveLength, 3)
+	})
+
+	Convey("RegisterContainerRule", t, func() {
+		r := New()
+		So(r.RegisterContainerRule(ContainerRule{}), ShouldNotBeNil)
+		So(r.RegisterContainerRule(ContainerRule{Mime: "application/x-test"}), ShouldNotBeNil)
+		So(r.RegisterContainerRule(ContainerRule{Mime: "application/x-sketch", Members: []string{"document.json", "pages/"}}), ShouldBeNil)
+		So(r.IsZipContainer("application/x-sketch"), ShouldBeTrue)
+		So(IsZipContainer("application/x-sketch"), ShouldBeFalse)
+		So(IsZipContainer("application/epub+zip"), ShouldBeTrue)
+		So(IsZipContainer(ZipMimeType), ShouldBeTrue)
+		So(IsZipContainer("image/png"), ShouldBeFalse)
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "design")
+		So(os.WriteFile(path, makeTestContainer("document.json", "{}", "pages/1.json", "{}"), 0644), ShouldBeNil)
+		So(r.Mime(path), ShouldEqual, "application/x-sketch")
+		So(New().Mime(path), ShouldEqual, ZipMimeType)
+
+		r.Reset()
+		So(r.Mime(path), ShouldEqual, ZipMimeType)
+		So(RegisterContainerRule(ContainerRule{}), ShouldNotBeNil)
+	})
+}
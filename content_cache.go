@@ -0,0 +1,113 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"crypto/sha256"
+	"sync"
+)
+
+// DefaultContentCacheSize is the size used when NewContentCache is given a
+// size of zero
+const DefaultContentCacheSize = 4096
+
+// ContentCache is a detection cache keyed by content hash instead of path,
+// so that the same bytes seen under many names are only detected once. When
+// full, the oldest entries are evicted first
+type ContentCache struct {
+	r      *Registry
+	size   int
+	m      map[string]string
+	order  []string
+	hits   uint64
+	misses uint64
+
+	sync.Mutex
+}
+
+// NewContentCache constructs a new ContentCache detecting with the given
+// Registry, or the default Registry if `r` is nil, and holding at most
+// `size` entries
+func NewContentCache(r *Registry, size int) (c *ContentCache) {
+	if r == nil {
+		r = gRegistry
+	}
+	if size <= 0 {
+		size = DefaultContentCacheSize
+	}
+	return &ContentCache{r: r, size: size, m: make(map[string]string)}
+}
+
+// Detect returns the mime type of the `head` content, keyed by the SHA-256
+// of the leading GetReadLimit bytes which are all that detection considers
+func (c *ContentCache) Detect(head []byte) (mime string) {
+	if limit := c.r.GetReadLimit(); len(head) > limit {
+		head = head[:limit]
+	}
+	sum := sha256.Sum256(head)
+	return c.DetectKey(string(sum[:]), head)
+}
+
+// DetectKey returns the mime type of the `head` content, keyed by the
+// caller-supplied `key`, typically a hash of the entire content already
+// computed by the caller
+func (c *ContentCache) DetectKey(key string, head []byte) (mime string) {
+	var ok bool
+	c.Lock()
+	if mime, ok = c.m[key]; ok {
+		c.hits += 1
+		c.Unlock()
+		return c.r.output(mime)
+	}
+	c.misses += 1
+	c.Unlock()
+
+	mime = c.r.detect(head)
+
+	c.Lock()
+	if _, present := c.m[key]; !present {
+		if len(c.order) >= c.size {
+			delete(c.m, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, key)
+	}
+	c.m[key] = mime
+	c.Unlock()
+	return c.r.output(mime)
+}
+
+// Len returns the number of entries in the cache
+func (c *ContentCache) Len() (count int) {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.m)
+}
+
+// Stats returns the number of cache hits and misses so far
+func (c *ContentCache) Stats() (hits, misses uint64) {
+	c.Lock()
+	defer c.Unlock()
+	return c.hits, c.misses
+}
+
+// Reset removes all entries from the cache and zeroes the Stats
+func (c *ContentCache) Reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.m = make(map[string]string)
+	c.order = nil
+	c.hits, c.misses = 0, 0
+}
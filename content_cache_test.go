@@ -0,0 +1,54 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContentCache(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	pdf := []byte("%PDF-1.4\n")
+
+	Convey("ContentCache", t, func() {
+		c := NewContentCache(nil, 1)
+		So(c.Detect(png), ShouldEqual, "image/png")
+		So(c.Detect(png), ShouldEqual, "image/png")
+		hits, misses := c.Stats()
+		So(hits, ShouldEqual, 1)
+		So(misses, ShouldEqual, 1)
+		So(c.Len(), ShouldEqual, 1)
+
+		So(c.Detect(pdf), ShouldEqual, "application/pdf")
+		So(c.Len(), ShouldEqual, 1)
+		So(c.Detect(png), ShouldEqual, "image/png")
+		_, misses = c.Stats()
+		So(misses, ShouldEqual, 3)
+
+		Convey("caller-supplied keys", func() {
+			c = NewContentCache(New(), 0)
+			So(c.DetectKey("doc", pdf), ShouldEqual, "application/pdf")
+			So(c.DetectKey("doc", png), ShouldEqual, "application/pdf")
+			hits, _ = c.Stats()
+			So(hits, ShouldEqual, 1)
+			c.Reset()
+			So(c.Len(), ShouldEqual, 0)
+			So(c.DetectKey("doc", png), ShouldEqual, "image/png")
+		})
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+type cRegistryKey struct{}
+
+// WithContext returns a copy of the given context with the Registry attached,
+// for use with FromContext and the context-aware lookup functions
+func WithContext(ctx context.Context, r *Registry) context.Context {
+	return context.WithValue(ctx, cRegistryKey{}, r)
+}
+
+// FromContext returns the Registry attached to the given context using
+// WithContext, or the default Registry if there is none
+func FromContext(ctx context.Context) (r *Registry) {
+	if ctx != nil {
+		if r, _ = ctx.Value(cRegistryKey{}).(*Registry); r != nil {
+			return
+		}
+	}
+	return gRegistry
+}
+
+// GetExtensionContext is the context-aware version of GetExtension
+func GetExtensionContext(ctx context.Context, extension string) (mime string, ok bool) {
+	return FromContext(ctx).GetExtension(extension)
+}
+
+// GetCharsetContext is the context-aware version of GetCharset
+func GetCharsetContext(ctx context.Context, mime string) (charset string, ok bool) {
+	return FromContext(ctx).GetCharset(mime)
+}
+
+// IsPlainTextContext is the context-aware version of IsPlainText
+func IsPlainTextContext(ctx context.Context, mime string) (yes bool) {
+	return FromContext(ctx).IsPlainText(mime)
+}
+
+// FromPathOnlyContext is the context-aware version of FromPathOnly
+func FromPathOnlyContext(ctx context.Context, path string) (mime string) {
+	return FromContext(ctx).FromPathOnly(path)
+}
+
+// MimeContext is the context-aware version of Mime, using the Registry
+// attached to the context, see Registry.MimeContext
+func MimeContext(ctx context.Context, path string) (mime string, err error) {
+	return FromContext(ctx).MimeContext(ctx, path)
+}
+
+// DetectReaderContext is the context-aware version of DetectReader, using
+// the Registry attached to the context, see Registry.DetectReaderContext
+func DetectReaderContext(ctx context.Context, r io.Reader) (mime string, rebuilt io.Reader, err error) {
+	return FromContext(ctx).DetectReaderContext(ctx, r)
+}
+
+// MimeContext is Mime bounded by the cancellation and deadline of the given
+// context. The file content is read in chunks on the calling goroutine and
+// the context is checked between the reads, returning the context error
+// once it ends. A read or filesystem call already in progress is not
+// interrupted, so the bound is approximate on a stalled filesystem
+func (r *Registry) MimeContext(ctx context.Context, path string) (mime string, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	return r.mime(ctx, path)
+}
+
+// DetectReaderContext is DetectReader bounded by the cancellation and
+// deadline of the given context. The leading bytes are read in chunks on
+// the calling goroutine and the context is checked between the reads. When
+// the context ends first, the context error is returned and the `rebuilt`
+// reader still replays the bytes consumed followed by the remainder of
+// `reader`. A read already in progress is not interrupted, so the bound is
+// approximate for a reader which blocks
+func (r *Registry) DetectReaderContext(ctx context.Context, reader io.Reader) (mime string, rebuilt io.Reader, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	var head []byte
+	head, err = readHeadContext(ctx, reader, r.GetReadLimit())
+	rebuilt = io.MultiReader(bytes.NewReader(head), reader)
+	if err == nil {
+		mime = r.DetectBytes(head)
+	}
+	return
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContext(t *testing.T) {
+	Convey("FromContext", t, func() {
+		var empty context.Context
+		So(FromContext(empty), ShouldEqual, gRegistry)
+		So(FromContext(context.Background()), ShouldEqual, gRegistry)
+
+		tenant := New()
+		tenant.SetExtension("page", "text/x-tenant-page")
+		tenant.SetCharset("text/x-tenant-page", "utf-8")
+		ctx := WithContext(context.Background(), tenant)
+		So(FromContext(ctx), ShouldEqual, tenant)
+
+		mime, ok := GetExtensionContext(ctx, "page")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "text/x-tenant-page")
+		_, ok = GetExtensionContext(context.Background(), "page")
+		So(ok, ShouldBeFalse)
+
+		charset, ok := GetCharsetContext(ctx, "text/x-tenant-page")
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "utf-8")
+		So(IsPlainTextContext(ctx, "text/x-tenant-page"), ShouldBeTrue)
+		So(FromPathOnlyContext(ctx, "index.page"), ShouldEqual, "text/x-tenant-page")
+		So(FromPathOnlyContext(context.Background(), "index.page"), ShouldBeEmpty)
+		mime, err := MimeContext(ctx, "./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+	})
+
+	Convey("MimeContext", t, func() {
+		canceled, cancel := context.WithCancel(context.Background())
+		cancel()
+		mime, err := MimeContext(canceled, "./testdata/empty-png")
+		So(errors.Is(err, context.Canceled), ShouldBeTrue)
+		So(mime, ShouldBeEmpty)
+
+		mime, err = New().MimeContext(context.Background(), "./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+	})
+
+	Convey("DetectReaderContext", t, func() {
+		ctx := WithContext(context.Background(), New())
+		mime, rebuilt, err := DetectReaderContext(ctx, strings.NewReader("%PDF-1.4\n"))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/pdf")
+		data, _ := io.ReadAll(rebuilt)
+		So(string(data), ShouldEqual, "%PDF-1.4\n")
+
+		slow := &slowReader{data: []byte(strings.Repeat("slow data\n", 400)), delay: 5 * time.Millisecond}
+		expired, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		mime, rebuilt, err = DetectReaderContext(expired, slow)
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		So(mime, ShouldBeEmpty)
+		// nothing reads from the stream after the call returns
+		reads := slow.reads
+		time.Sleep(20 * time.Millisecond)
+		So(slow.reads, ShouldEqual, reads)
+		So(rebuilt, ShouldNotBeNil)
+		slow.delay = 0
+		data, _ = io.ReadAll(rebuilt)
+		So(string(data), ShouldEqual, strings.Repeat("slow data\n", 400))
+	})
+}
+
+// slowReader returns one chunk of at most 16 bytes per Read, after a delay
+type slowReader struct {
+	data  []byte
+	delay time.Duration
+	reads int
+}
+
+func (s *slowReader) Read(p []byte) (n int, err error) {
+	if len(s.data) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(s.delay)
+	s.reads += 1
+	n = copy(p[:min(len(p), 16)], s.data)
+	s.data = s.data[n:]
+	return
+}
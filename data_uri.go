@@ -0,0 +1,151 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	goMime "mime"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDataURIMimeType is the RFC 2397 type of data: URIs which do not
+// specify one
+const DefaultDataURIMimeType = "text/plain; charset=us-ascii"
+
+// EncodeDataURI returns an RFC 2397 data: URI of the given `data` with the
+// given `mime` type. Textual types (see IsPlainText) with valid UTF-8 `data`
+// are percent-encoded so that they remain readable, all other content is
+// base64 encoded. An empty `mime` is encoded as BinaryMimeType
+func (r *Registry) EncodeDataURI(mime string, data []byte) (uri string) {
+	if mime == "" {
+		mime = BinaryMimeType
+	}
+	var buf strings.Builder
+	buf.WriteString("data:")
+	if mediatype, params, err := goMime.ParseMediaType(mime); err == nil {
+		buf.WriteString(mediatype)
+		for _, name := range sortedKeys(params) {
+			buf.WriteString(";" + name + "=" + url.PathEscape(params[name]))
+		}
+	} else {
+		buf.WriteString(url.PathEscape(mime))
+	}
+	if r.IsPlainText(mime) && utf8.Valid(data) {
+		buf.WriteString("," + escapeDataURI(string(data)))
+	} else {
+		buf.WriteString(";base64," + base64.StdEncoding.EncodeToString(data))
+	}
+	return buf.String()
+}
+
+// ParseDataURI decodes the given RFC 2397 data: `uri`, returning the `mime`
+// type, in the canonical form produced by Normalize, and the decoded `data`.
+// URIs without a type have the DefaultDataURIMimeType. Both padded and
+// unpadded base64 content is accepted
+func ParseDataURI(uri string) (mime string, data []byte, err error) {
+	rest, ok := cutPrefixFold(strings.TrimSpace(uri), "data:")
+	if !ok {
+		err = errors.New("not a data: URI")
+		return
+	}
+	header, payload, found := strings.Cut(rest, ",")
+	if !found {
+		err = errors.New("data: URI is missing the comma separator")
+		return
+	}
+
+	parts := strings.Split(header, ";")
+	encoded := len(parts) > 1 && strings.EqualFold(parts[len(parts)-1], "base64")
+	if encoded {
+		parts = parts[:len(parts)-1]
+	}
+	mediatype := strings.TrimSpace(parts[0])
+	params := map[string]string{}
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(part, "=")
+		if value, err = url.PathUnescape(value); err != nil {
+			err = fmt.Errorf("invalid data: URI parameter %q: %w", part, err)
+			return
+		}
+		params[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+	if mediatype == "" {
+		mime = DefaultDataURIMimeType
+		if charset, present := params["charset"]; present {
+			mime = goMime.FormatMediaType(TextMimeType, map[string]string{"charset": charset})
+		}
+	} else if mime = goMime.FormatMediaType(mediatype, params); mime == "" {
+		err = fmt.Errorf("invalid data: URI media type %q", header)
+		return
+	}
+	mime = Normalize(mime)
+
+	if encoded {
+		payload = strings.Map(func(r rune) rune {
+			if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+				return -1
+			}
+			return r
+		}, payload)
+		if unescaped, ee := url.PathUnescape(payload); ee == nil {
+			payload = unescaped
+		}
+		data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+	} else {
+		var unescaped string
+		if unescaped, err = url.PathUnescape(payload); err == nil {
+			data = []byte(unescaped)
+		}
+	}
+	if err != nil {
+		mime, data = "", nil
+	}
+	return
+}
+
+// EncodeDataURI returns an RFC 2397 data: URI using the default Registry,
+// see Registry.EncodeDataURI
+func EncodeDataURI(mime string, data []byte) (uri string) {
+	return gRegistry.EncodeDataURI(mime, data)
+}
+
+// escapeDataURI percent-encodes the data portion of a data: URI, keeping the
+// RFC 3986 unreserved and sub-delimiter characters as-is
+func escapeDataURI(value string) (escaped string) {
+	const safe = "-_.~!$&'()*+,;=:@/?"
+	var buf strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+			buf.WriteByte(b)
+		case strings.IndexByte(safe, b) >= 0:
+			buf.WriteByte(b)
+		default:
+			buf.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return buf.String()
+}
+
+func cutPrefixFold(s, prefix string) (after string, found bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDataURI(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	Convey("EncodeDataURI", t, func() {
+		So(EncodeDataURI("text/plain; charset=utf-8", []byte("Hello, world! 100% café")), ShouldEqual,
+			"data:text/plain;charset=utf-8,Hello,%20world!%20100%25%20caf%C3%A9")
+		So(EncodeDataURI("image/png", []byte{0x89, 'P', 'N', 'G'}), ShouldEqual, "data:image/png;base64,iVBORw==")
+		So(EncodeDataURI("text/plain", []byte{0xff, 0xfe}), ShouldEqual, "data:text/plain;base64,//4=")
+		So(EncodeDataURI("", []byte("x")), ShouldEqual, "data:application/octet-stream;base64,eA==")
+		So(EncodeDataURI(`text/plain; title="a;b"`, []byte("x")), ShouldEqual, "data:text/plain;title=a%3Bb,x")
+	})
+
+	Convey("ParseDataURI", t, func() {
+		mime, data, err := ParseDataURI("data:text/plain;charset=UTF-8,Hello,%20world!")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "text/plain; charset=utf-8")
+		So(string(data), ShouldEqual, "Hello, world!")
+
+		mime, data, err = ParseDataURI("DATA:,A%20brief%20note")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, DefaultDataURIMimeType)
+		So(string(data), ShouldEqual, "A brief note")
+
+		mime, _, err = ParseDataURI("data:;charset=iso-8859-7,%be%d3%be")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "text/plain; charset=iso-8859-7")
+
+		mime, data, err = ParseDataURI("data:image/png;base64,iVBORw")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+		So(data, ShouldResemble, []byte{0x89, 'P', 'N', 'G'})
+
+		uri := EncodeDataURI("image/png", png)
+		mime, data, err = ParseDataURI(uri)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+		So(data, ShouldResemble, png)
+
+		uri = EncodeDataURI(`text/markdown; charset=utf-8; title="a;b"`, []byte("# Title\n\n50% off, #1"))
+		mime, data, err = ParseDataURI(uri)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, `text/markdown; charset=utf-8; title="a;b"`)
+		So(string(data), ShouldEqual, "# Title\n\n50% off, #1")
+
+		_, _, err = ParseDataURI("http://example.com")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseDataURI("data:text/plain")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseDataURI("data:text/plain,%zz")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseDataURI("data:image/png;base64,!!!")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"os"
+
+	clPath "github.com/go-corelibs/path"
+)
+
+// Source identifies how a mime type was determined
+type Source string
+
+const (
+	// SourceNone indicates the mime type could not be determined
+	SourceNone Source = ""
+	// SourceDirectory indicates the path is a directory
+	SourceDirectory Source = "directory"
+	// SourceInode indicates the path is a symbolic link or special file
+	// reported according to SetFollowSymlinks and SetSpecialFiles
+	SourceInode Source = "inode"
+	// SourceExtension indicates the mime type was determined from the path
+	SourceExtension Source = "extension"
+	// SourceContent indicates the mime type was determined from the content
+	SourceContent Source = "content"
+	// SourceMagic indicates the mime type was determined from a file
+	// signature (magic bytes) within the content
+	SourceMagic Source = "magic"
+	// SourceDetector indicates the mime type was determined by a registered
+	// content Detector, plugin or RegisterTextType detector
+	SourceDetector Source = "detector"
+	// SourceOverride indicates the mime type was taken from a per-directory
+	// override file, see SetOverrideFile
+	SourceOverride Source = "override"
+	// SourceResolver indicates the mime type was provided by the Resolver
+	SourceResolver Source = "resolver"
+)
+
+// ArchiveSummary is a brief description of the contents of an archive
+type ArchiveSummary struct {
+	// Members is the total number of members, including directories
+	Members int
+	// Types is the count of members per mime type
+	Types map[string]int
+}
+
+// Description is the classification report returned by Describe
+type Description struct {
+	// Path is the path described
+	Path string
+	// Size is the size of the file in bytes
+	Size int64
+	// Mime is the same mime type returned by Mime, including any override,
+	// Resolver answer, container refinement and detected charset
+	Mime string
+	// Source is how Mime was determined
+	Source Source
+	// ContentMime is the mime type detected from the content alone
+	ContentMime string
+	// Charset is the charset parameter of Mime, or the registered charset
+	Charset string
+	// Category is the top-level type of Mime (text, image, inode and so on)
+	Category string
+	// Textual is true when Mime is a text type
+	Textual bool
+	// Extensions are the file extensions found in the Path
+	Extensions []string
+	// Archive summarizes the contents when the file is a supported archive
+	Archive *ArchiveSummary
+	// Flags are the CheckPath results for the Path
+	Flags PathFlag
+	// Mismatch is true when the extension based and content based mime types
+	// are known and incompatible with each other
+	Mismatch bool
+}
+
+// Describe returns a comprehensive classification report for the given
+// `path`, combining the results of Mime, content detection, CheckPath and
+// ListArchive into one structure
+func (r *Registry) Describe(path string) (d *Description, err error) {
+	if r.GetPathOnly() {
+		err = ErrPathOnly
+		return
+	} else if inode, ok := r.inodeMime(path); ok {
+		d = &Description{Path: path, Mime: inode, Source: SourceInode, Category: "inode", Flags: CheckPath(path)}
+		return
+	}
+	var info os.FileInfo
+	if info, err = os.Stat(path); err != nil {
+		return
+	}
+
+	d = &Description{Path: path, Size: info.Size(), Flags: CheckPath(path)}
+	if a, b := clPath.ExtExt(path); b != "" {
+		d.Extensions = []string{b, a}
+	} else if a != "" {
+		d.Extensions = []string{a}
+	}
+
+	if info.IsDir() {
+		d.Mime, d.Source, d.Category = DirectoryMimeType, SourceDirectory, "inode"
+		return
+	} else if !info.Mode().IsRegular() {
+		// special files are never opened, reading may block indefinitely
+		return
+	}
+
+	if detected, e := r.detectFile(path); e == nil {
+		d.ContentMime = Normalize(detected)
+	}
+	if d.Mime = r.Mime(path); d.Mime == "" {
+		return
+	}
+	if _, ok := r.fromOverrides(path); ok {
+		d.Source = SourceOverride
+	} else if byExtension, _ := r.FromPathChecked(path); byExtension != "" {
+		d.Source = SourceExtension
+		d.Mismatch = r.mismatched(d.Mime, d.ContentMime)
+	} else if PruneCharset(d.Mime) == PruneCharset(d.ContentMime) || r.IsZipContainer(d.ContentMime) {
+		// including the container types refined from the archive members
+		d.Source = SourceContent
+	} else {
+		d.Source = SourceResolver
+	}
+
+	d.Category = TopLevel(d.Mime)
+	tli, _ := GetTopLevelInfo(d.Mime)
+	d.Textual = tli.Textual || r.IsPlainText(d.Mime)
+	if d.Charset = parameter(d.Mime, "charset"); d.Charset == "" {
+		d.Charset, _ = r.GetCharset(d.Mime)
+	}
+
+	if IsArchive(d.ContentMime) {
+		if entries, e := ListArchive(path); e == nil {
+			d.Archive = &ArchiveSummary{Members: len(entries), Types: make(map[string]int)}
+			for _, entry := range entries {
+				d.Archive.Types[entry.Mime] += 1
+			}
+		}
+	}
+	return
+}
+
+// Describe returns a comprehensive classification report for the given
+// `path` using the default Registry
+func Describe(path string) (d *Description, err error) {
+	return gRegistry.Describe(path)
+}
+
+// mismatched returns true if the extension and content based mime types are
+// both known, differ and the content type is neither generic nor an ancestor
+// of the extension type. Any textual content type is compatible with an
+// extension type of TextMimeType, as text/plain is the generic text type
+func (r *Registry) mismatched(byExtension, byContent string) bool {
+	byExtension, byContent = PruneCharset(byExtension), PruneCharset(byContent)
+	if byExtension == "" || byContent == "" || byExtension == byContent {
+		return false
+	}
+	switch byContent {
+	case TextMimeType, BinaryMimeType:
+		return false
+	}
+	if byExtension == TextMimeType && r.IsTextual(byContent) {
+		return false
+	}
+	return !r.IsKindOf(byExtension, byContent)
+}
+
+// parameter returns the named parameter value of the given `mime`
+func parameter(mime, name string) (value string) {
+	if _, params, err := goMime.ParseMediaType(mime); err == nil {
+		value = params[name]
+	}
+	return
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDescribe(t *testing.T) {
+	dir := t.TempDir()
+	png, _ := os.ReadFile("./testdata/empty-png")
+	_ = os.WriteFile(filepath.Join(dir, "image.txt"), png, 0644)
+	_ = os.WriteFile(filepath.Join(dir, "bundle"), makeTestZip(), 0644)
+
+	Convey("Describe", t, func() {
+		Convey("missing", func() {
+			_, err := Describe(filepath.Join(dir, "missing"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("directory", func() {
+			d, err := Describe(dir)
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, DirectoryMimeType)
+			So(d.Source, ShouldEqual, SourceDirectory)
+			So(d.Category, ShouldEqual, "inode")
+		})
+
+		Convey("extension", func() {
+			d, err := Describe("./testdata/README.md")
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, "text/markdown; charset=utf-8")
+			So(d.Source, ShouldEqual, SourceExtension)
+			So(d.Charset, ShouldEqual, "utf-8")
+			So(d.Category, ShouldEqual, "text")
+			So(d.Textual, ShouldBeTrue)
+			So(d.Extensions, ShouldResemble, []string{"md"})
+			So(d.Flags.Suspicious(), ShouldBeFalse)
+		})
+
+		Convey("content", func() {
+			d, err := Describe("./testdata/empty-png")
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, "image/png")
+			So(d.Source, ShouldEqual, SourceContent)
+			So(d.Category, ShouldEqual, "image")
+			So(d.Textual, ShouldBeFalse)
+			So(d.Extensions, ShouldBeEmpty)
+		})
+
+		Convey("mismatch", func() {
+			d, err := Describe(filepath.Join(dir, "image.txt"))
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, "text/plain; charset=utf-8")
+			So(d.ContentMime, ShouldEqual, "image/png")
+			So(d.Mismatch, ShouldBeTrue)
+		})
+
+		Convey("override and container rules", func() {
+			r := New()
+			So(os.WriteFile(filepath.Join(dir, DefaultOverrideFile), []byte("image.txt image/png\n"), 0644), ShouldBeNil)
+			defer os.Remove(filepath.Join(dir, DefaultOverrideFile))
+			r.SetOverrideFile(DefaultOverrideFile)
+			d, err := r.Describe(filepath.Join(dir, "image.txt"))
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, r.Mime(filepath.Join(dir, "image.txt")))
+			So(d.Mime, ShouldEqual, "image/png")
+			So(d.Source, ShouldEqual, SourceOverride)
+			So(d.Mismatch, ShouldBeFalse)
+
+			path := filepath.Join(dir, "design")
+			So(os.WriteFile(path, makeTestContainer("document.json", "{}", "pages/1.json", "{}"), 0644), ShouldBeNil)
+			So(r.RegisterContainerRule(ContainerRule{Mime: "application/x-sketch", Members: []string{"document.json", "pages/"}}), ShouldBeNil)
+			d, err = r.Describe(path)
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, "application/x-sketch")
+			So(d.ContentMime, ShouldEqual, ZipMimeType)
+			So(d.Source, ShouldEqual, SourceContent)
+
+			notes := filepath.Join(dir, "notes.md")
+			So(os.WriteFile(notes, []byte("caf\xe9\n"), 0644), ShouldBeNil)
+			d, err = r.Describe(notes)
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, "text/markdown; charset=iso-8859-1")
+			So(d.Charset, ShouldEqual, "iso-8859-1")
+		})
+
+		Convey("archive", func() {
+			d, err := Describe(filepath.Join(dir, "bundle"))
+			So(err, ShouldBeNil)
+			So(d.Mime, ShouldEqual, ZipMimeType)
+			So(d.Archive, ShouldNotBeNil)
+			So(d.Archive.Members, ShouldEqual, 3)
+			So(d.Archive.Types["image/png"], ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+const (
+	// HeadWindow is the number of leading bytes DetectAt reads, which is the
+	// same as the default github.com/gabriel-vasile/mimetype read limit
+	HeadWindow = 3072
+	// TailWindow is the number of trailing bytes DetectAt reads, large enough
+	// to contain a ZIP end of central directory record with a maximum length
+	// comment
+	TailWindow = 22 + 65535
+)
+
+// trailer is a signature check that needs more than the leading bytes of the
+// content to confirm
+type trailer struct {
+	mime  string
+	check func(r io.ReaderAt, size int64, tail []byte) bool
+}
+
+var gTrailers = []trailer{
+	{mime: ZipMimeType, check: func(r io.ReaderAt, size int64, tail []byte) bool {
+		// end of central directory record
+		return bytes.LastIndex(tail, []byte("PK\x05\x06")) >= 0
+	}},
+	{mime: IsoMimeType, check: func(r io.ReaderAt, size int64, tail []byte) bool {
+		// primary volume descriptor, just past the 32KiB system area
+		return readAt(r, size, 0x8001, 5) == "CD001"
+	}},
+	{mime: Mp3MimeType, check: func(r io.ReaderAt, size int64, tail []byte) bool {
+		// ID3v1 tag
+		return len(tail) >= 128 && bytes.HasPrefix(tail[len(tail)-128:], []byte("TAG"))
+	}},
+}
+
+// DetectAt detects the mime type of the content within `r`, which is `size`
+// bytes long, by reading both the leading bytes, up to the read limit of the
+// default Registry (see SetReadLimit), and the trailing TailWindow bytes.
+// When the leading bytes alone are not enough to identify the content (the
+// result is BinaryMimeType), trailing signatures such as the ZIP central
+// directory and ID3v1 tags are checked, along with the ISO 9660 volume
+// descriptor. ZIP containers are refined further with the ContainerRule list
+// of the default Registry, see RegisterContainerRule. See WithProgress and
+// WithByteBudget for the `options` available
+func DetectAt(r io.ReaderAt, size int64, options ...DetectOption) (mime string, err error) {
+	if r == nil || size < 0 {
+		err = errors.New("a non-nil reader and non-negative size are required")
+		return
+	}
+	tr := &trackedReaderAt{r: r, c: newDetectConfig(options), size: size, stage: "head"}
+
+	var head []byte
+	if head, err = readWindow(tr, 0, min(size, int64(gRegistry.GetReadLimit()))); err != nil {
+		return
+	}
+	mime = Normalize(gRegistry.detect(head))
+	if gRegistry.IsZipContainer(mime) {
+		return tr.container(mime, size)
+	} else if mime != BinaryMimeType {
+		return
+	}
+
+	var tail []byte
+	offset := max(size-TailWindow, 0)
+	tr.stage = "tail"
+	if tail, err = readWindow(tr, offset, size-offset); err != nil {
+		mime = ""
+		return
+	}
+	tr.stage = "probe"
+	for _, t := range gTrailers {
+		if t.check(tr, size, tail) {
+			if t.mime == ZipMimeType {
+				return tr.container(t.mime, size)
+			}
+			mime = t.mime
+			return
+		} else if tr.err != nil {
+			mime, err = "", tr.err
+			return
+		}
+	}
+	return
+}
+
+// container refines the `detected` ZIP container type with the
+// ContainerRule list of the default Registry
+func (t *trackedReaderAt) container(detected string, size int64) (mime string, err error) {
+	t.stage = "container"
+	if contained, ok := gRegistry.fromContainer(t, size); ok {
+		return contained, nil
+	} else if t.err != nil {
+		return "", t.err
+	}
+	return detected, nil
+}
+
+func readWindow(r io.ReaderAt, offset, length int64) (data []byte, err error) {
+	data = make([]byte, length)
+	var n int
+	if n, err = r.ReadAt(data, offset); err == io.EOF {
+		err = nil
+	}
+	data = data[:n]
+	return
+}
+
+func readAt(r io.ReaderAt, size, offset, length int64) (value string) {
+	if offset+length <= size {
+		if data, err := readWindow(r, offset, length); err == nil {
+			value = string(data)
+		}
+	}
+	return
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectAt(t *testing.T) {
+	Convey("DetectAt", t, func() {
+		Convey("invalid arguments", func() {
+			_, err := DetectAt(nil, 0)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("head detection", func() {
+			data := []byte("%PDF-1.4\n")
+			mime, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, "application/pdf")
+		})
+
+		Convey("zip with a prepended stub", func() {
+			var buf bytes.Buffer
+			buf.Write(make([]byte, HeadWindow*2))
+			zw := zip.NewWriter(&buf)
+			w, _ := zw.Create("file.txt")
+			_, _ = w.Write([]byte("contents"))
+			So(zw.Close(), ShouldBeNil)
+			data := buf.Bytes()
+			mime, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, ZipMimeType)
+		})
+
+		Convey("id3v1 tagged audio", func() {
+			data := append(make([]byte, 1024), append([]byte("TAG"), make([]byte, 125)...)...)
+			mime, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, Mp3MimeType)
+		})
+
+		Convey("iso image", func() {
+			data := make([]byte, 0x9000)
+			copy(data[0x8001:], "CD001")
+			mime, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, IsoMimeType)
+		})
+
+		Convey("unknown binary", func() {
+			data := make([]byte, 64)
+			mime, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, BinaryMimeType)
+		})
+	})
+}
+
+func TestDetectOptions(t *testing.T) {
+	data := make([]byte, 0x9000)
+	copy(data[0x8001:], "CD001")
+
+	Convey("WithProgress", t, func() {
+		var stages []string
+		var last Progress
+		mime, err := DetectAt(bytes.NewReader(data), int64(len(data)), WithProgress(func(p Progress) bool {
+			stages = append(stages, p.Stage)
+			last = p
+			return true
+		}))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, IsoMimeType)
+		So(stages, ShouldResemble, []string{"head", "tail", "probe"})
+		So(last.Size, ShouldEqual, int64(len(data)))
+		So(last.BytesRead, ShouldEqual, int64(HeadWindow+len(data)+5))
+
+		mime, err = DetectAt(bytes.NewReader(data), int64(len(data)), WithProgress(func(p Progress) bool {
+			return p.Stage == "head"
+		}))
+		So(err, ShouldEqual, ErrAborted)
+		So(mime, ShouldBeEmpty)
+	})
+
+	Convey("WithByteBudget", t, func() {
+		mime, err := DetectAt(bytes.NewReader(data), int64(len(data)), WithByteBudget(HeadWindow))
+		So(err, ShouldEqual, ErrBudgetExceeded)
+		So(mime, ShouldBeEmpty)
+		mime, err = DetectAt(bytes.NewReader(data), int64(len(data)), WithByteBudget(1<<20), nil)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, IsoMimeType)
+	})
+
+	Convey("DetectFile", t, func() {
+		mime, err := DetectFile("./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+		_, err = DetectFile("./testdata/not-a-file")
+		So(err, ShouldNotBeNil)
+		_, err = DetectFile("./testdata/empty-png", WithByteBudget(1))
+		So(err, ShouldEqual, ErrBudgetExceeded)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+// DetectBytes returns the mime type of the given in-memory `data`, using the
+// same content detection pipeline as Mime: the Registry detectors, plugin
+// detectors and then github.com/gabriel-vasile/mimetype. Only the leading
+// GetReadLimit bytes are inspected and text types carry the charset found
+// by DetectCharset
+func (r *Registry) DetectBytes(data []byte) (mime string) {
+	if limit := r.GetReadLimit(); len(data) > limit {
+		data = data[:limit]
+	}
+	return r.output(r.withDetectedCharset(r.detect(data), data))
+}
+
+// DetectBytes returns the mime type of the given in-memory `data` using the
+// default Registry, see Registry.DetectBytes
+func DetectBytes(data []byte) (mime string) {
+	return gRegistry.DetectBytes(data)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectBytes(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	Convey("DetectBytes", t, func() {
+		So(DetectBytes(png), ShouldEqual, "image/png")
+		So(DetectBytes([]byte("%PDF-1.4\n")), ShouldEqual, "application/pdf")
+		So(DetectBytes(append(append([]byte{}, png...), bytes.Repeat([]byte{0}, 2*HeadWindow)...)), ShouldEqual, "image/png")
+
+		r := New()
+		r.SetOutputPolicy(BareType)
+		So(r.RegisterDetector(Detector{Mime: "application/x-thing; version=1", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("THING"))
+		}}), ShouldBeNil)
+		So(r.DetectBytes([]byte("THING")), ShouldEqual, "application/x-thing")
+		So(DetectBytes([]byte("THING")), ShouldNotStartWith, "application/x-thing")
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"net/http"
+)
+
+// DetectHTTP returns the mime type of the given `data` with results that are
+// compatible with net/http.DetectContentType. Whenever DetectContentType
+// identifies a specific type from its standard sniff set, that exact value is
+// returned. Only when it falls back to the generic "text/plain" or
+// BinaryMimeType are the Registry detectors, plugin detectors and, for the
+// default Registry, the detectors given to RegisterTextType and
+// RegisterBinaryType consulted, and a registered textual type is only
+// returned in place of "text/plain". This lets servers switch from the
+// standard library without changing what browsers observe for the
+// well-known formats
+func (r *Registry) DetectHTTP(data []byte) (mime string) {
+	mime = http.DetectContentType(data)
+	limit := r.GetReadLimit()
+	if len(data) > limit {
+		data = data[:limit]
+	}
+	generic := PruneCharset(mime)
+	if generic != TextMimeType && generic != BinaryMimeType {
+		return
+	}
+
+	registered, ok := r.detectors.detect(data, uint32(limit))
+	if !ok {
+		registered, ok = detectPlugins(data, uint32(limit))
+	}
+	if !ok && r == gRegistry {
+		registered, ok = gTextDetectors.detect(data, uint32(limit))
+	}
+	if !ok && r == gRegistry && generic == BinaryMimeType {
+		registered, ok = gBinaryDetectors.detect(data, uint32(limit))
+	}
+	if !ok || (generic == TextMimeType && !r.IsPlainText(registered)) {
+		return
+	}
+
+	if generic == TextMimeType {
+		// keep the charset that DetectContentType found
+		_, params, _ := goMime.ParseMediaType(mime)
+		if mt, err := ParseMediaType(registered); err == nil && params["charset"] != "" {
+			return mt.WithParam("charset", params["charset"]).String()
+		}
+	}
+	return Normalize(registered)
+}
+
+// DetectHTTP returns the net/http.DetectContentType compatible mime type of
+// the given `data` using the default Registry, see Registry.DetectHTTP
+func DetectHTTP(data []byte) (mime string) {
+	return gRegistry.DetectHTTP(data)
+}
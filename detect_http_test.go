@@ -0,0 +1,67 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectHTTP(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	Convey("DetectHTTP", t, func() {
+		for _, data := range [][]byte{
+			png,
+			[]byte("%PDF-1.4\n"),
+			[]byte("<!DOCTYPE html><html></html>"),
+			[]byte("<?xml version=\"1.0\"?><root/>"),
+			[]byte("GIF89a"),
+			[]byte("PK\x03\x04"),
+			[]byte("\x1f\x8b\x08"),
+			[]byte("{\"json\": true}"),
+			[]byte("plain text"),
+			{},
+			{0x00, 0x01, 0x02},
+		} {
+			So(DetectHTTP(data), ShouldEqual, http.DetectContentType(data))
+		}
+
+		r := New()
+		So(r.RegisterDetector(Detector{Mime: "text/x-notes", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("NOTES"))
+		}}), ShouldBeNil)
+		So(r.RegisterDetector(Detector{Mime: "application/x-thing", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("THING")) || bytes.HasPrefix(raw, []byte("NOT"))
+		}}), ShouldBeNil)
+		So(r.DetectHTTP([]byte("THING\x00\x01")), ShouldEqual, "application/x-thing")
+		// binary types never replace a text/plain result
+		So(r.DetectHTTP([]byte("NOTES about things")), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.DetectHTTP(png), ShouldEqual, "image/png")
+
+		r = New()
+		So(r.RegisterDetector(Detector{Mime: "text/x-notes", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("NOTES"))
+		}}), ShouldBeNil)
+		r.SetCharset("text/x-notes", "utf-8")
+		So(r.DetectHTTP([]byte("NOTES about things")), ShouldEqual, "text/x-notes; charset=utf-8")
+
+		So(DetectHTTP([]byte("BEGIN:VCARD\r\nVERSION:4.0\r\n")), ShouldEqual, "text/vcard; charset=utf-8")
+	})
+}
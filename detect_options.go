@@ -0,0 +1,131 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+var (
+	// ErrBudgetExceeded is returned when a detection would read more bytes
+	// than allowed by WithByteBudget
+	ErrBudgetExceeded = errors.New("detection byte budget exceeded")
+	// ErrAborted is returned when a ProgressFunc aborts a detection
+	ErrAborted = errors.New("detection aborted")
+)
+
+// Progress is the information given to a ProgressFunc
+type Progress struct {
+	// Stage is the name of the detection stage performing the read, one of
+	// "head", "tail", "probe" or "container"
+	Stage string
+	// BytesRead is the total number of bytes read so far
+	BytesRead int64
+	// Size is the total size of the content being detected
+	Size int64
+}
+
+// ProgressFunc is called after each read performed during detection, return
+// false to abort the detection with ErrAborted
+type ProgressFunc func(p Progress) (proceed bool)
+
+// DetectOption configures the behaviour of DetectAt, DetectFile and
+// RemoteMime
+type DetectOption func(c *detectConfig)
+
+type detectConfig struct {
+	progress ProgressFunc
+	budget   int64
+}
+
+// WithProgress configures a ProgressFunc to be called after each read
+func WithProgress(fn ProgressFunc) DetectOption {
+	return func(c *detectConfig) {
+		c.progress = fn
+	}
+}
+
+// WithByteBudget limits the total number of bytes read during detection,
+// detections needing more than `limit` bytes fail with ErrBudgetExceeded
+func WithByteBudget(limit int64) DetectOption {
+	return func(c *detectConfig) {
+		c.budget = limit
+	}
+}
+
+// report calls the configured ProgressFunc, if any, returning ErrAborted when
+// it aborts the detection
+func (c *detectConfig) report(p Progress) (err error) {
+	if c.progress != nil && !c.progress(p) {
+		err = ErrAborted
+	}
+	return
+}
+
+func newDetectConfig(options []DetectOption) (c *detectConfig) {
+	c = &detectConfig{}
+	for _, option := range options {
+		if option != nil {
+			option(c)
+		}
+	}
+	return
+}
+
+// DetectFile opens the file at the given `path` and uses DetectAt to detect
+// the mime type of its content
+func DetectFile(path string, options ...DetectOption) (mime string, err error) {
+	var fh *os.File
+	if gRegistry.GetPathOnly() {
+		err = ErrPathOnly
+		return
+	} else if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	var info os.FileInfo
+	if info, err = fh.Stat(); err != nil {
+		return
+	}
+	return DetectAt(fh, info.Size(), options...)
+}
+
+// trackedReaderAt enforces the detectConfig budget and reports progress
+type trackedReaderAt struct {
+	r     io.ReaderAt
+	c     *detectConfig
+	size  int64
+	read  int64
+	stage string
+	err   error
+}
+
+func (t *trackedReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if t.err != nil {
+		return 0, t.err
+	}
+	if t.c.budget > 0 && t.read+int64(len(p)) > t.c.budget {
+		t.err = ErrBudgetExceeded
+		return 0, t.err
+	}
+	n, err = t.r.ReadAt(p, off)
+	t.read += int64(n)
+	if t.err = t.c.report(Progress{Stage: t.stage, BytesRead: t.read, Size: t.size}); t.err != nil {
+		return n, t.err
+	}
+	return
+}
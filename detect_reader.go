@@ -0,0 +1,41 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"io"
+)
+
+// DetectReader reads the leading GetReadLimit bytes of `r` to detect the mime
+// type of the stream, see DetectBytes, and returns a `rebuilt` reader which
+// replays the bytes consumed followed by the remainder of `r`. Callers must
+// use the `rebuilt` reader in place of `r` afterwards. When reading fails,
+// `rebuilt` still replays whatever was read before the error
+func (r *Registry) DetectReader(reader io.Reader) (mime string, rebuilt io.Reader, err error) {
+	var head []byte
+	head, err = readHeadN(reader, r.GetReadLimit())
+	rebuilt = io.MultiReader(bytes.NewReader(head), reader)
+	if err == nil {
+		mime = r.DetectBytes(head)
+	}
+	return
+}
+
+// DetectReader detects the mime type of a stream using the default Registry,
+// see Registry.DetectReader
+func DetectReader(r io.Reader) (mime string, rebuilt io.Reader, err error) {
+	return gRegistry.DetectReader(r)
+}
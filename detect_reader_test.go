@@ -0,0 +1,60 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectReader(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	Convey("DetectReader", t, func() {
+		Convey("replays the stream", func() {
+			data := append(append([]byte{}, png...), bytes.Repeat([]byte("x"), 2*HeadWindow)...)
+			mime, rebuilt, err := DetectReader(iotest.OneByteReader(bytes.NewReader(data)))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, "image/png")
+			replayed, err := io.ReadAll(rebuilt)
+			So(err, ShouldBeNil)
+			So(replayed, ShouldResemble, data)
+		})
+
+		Convey("short streams", func() {
+			mime, rebuilt, err := DetectReader(bytes.NewReader([]byte("%PDF-1.4\n")))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, "application/pdf")
+			replayed, _ := io.ReadAll(rebuilt)
+			So(string(replayed), ShouldEqual, "%PDF-1.4\n")
+		})
+
+		Convey("read errors", func() {
+			broken := errors.New("broken")
+			mime, rebuilt, err := DetectReader(io.MultiReader(bytes.NewReader([]byte("abc")), iotest.ErrReader(broken)))
+			So(err, ShouldEqual, broken)
+			So(mime, ShouldBeEmpty)
+			replayed := make([]byte, 3)
+			_, _ = io.ReadFull(rebuilt, replayed)
+			So(string(replayed), ShouldEqual, "abc")
+		})
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// DetectResponse reconciles the Content-Type header of the given `resp` with
+// its body. When the header is present and more specific than
+// BinaryMimeType, it is returned as-is (shaped by the OutputPolicy).
+// Otherwise the leading bytes of the body, up to the read limit, are
+// sniffed, see DetectReader, and `resp.Body` is replaced with a reader which
+// replays those bytes, so the response remains fully readable and closable.
+// Bodies with a Content-Encoding that the http.Transport did not decode are
+// never sniffed
+func (r *Registry) DetectResponse(resp *http.Response) (mime string, err error) {
+	if resp == nil {
+		err = errors.New("nil response")
+		return
+	}
+	declared := resp.Header.Get("Content-Type")
+	if declared != "" && PruneCharset(declared) != BinaryMimeType {
+		mime = r.output(declared)
+		return
+	} else if resp.Body == nil || resp.Body == http.NoBody ||
+		(resp.Header.Get("Content-Encoding") != "" && !resp.Uncompressed) {
+		if declared != "" {
+			mime = r.output(declared)
+		}
+		return
+	}
+
+	var rebuilt io.Reader
+	mime, rebuilt, err = r.DetectReader(resp.Body)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{Reader: rebuilt, Closer: resp.Body}
+	return
+}
+
+// DetectResponse reconciles the Content-Type header of the given `resp` with
+// its body using the default Registry, see Registry.DetectResponse
+func DetectResponse(resp *http.Response) (mime string, err error) {
+	return gRegistry.DetectResponse(resp)
+}
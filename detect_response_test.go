@@ -0,0 +1,76 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectResponse(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	response := func(contentType string, body []byte) (resp *http.Response) {
+		resp = &http.Response{Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(body))}
+		if contentType != "" {
+			resp.Header.Set("Content-Type", contentType)
+		}
+		return
+	}
+
+	Convey("DetectResponse", t, func() {
+		resp := response("", png)
+		mime, err := DetectResponse(resp)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+		data, _ := io.ReadAll(resp.Body)
+		So(data, ShouldResemble, png)
+		So(resp.Body.Close(), ShouldBeNil)
+
+		resp = response(BinaryMimeType, []byte("%PDF-1.4\n"))
+		mime, err = DetectResponse(resp)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/pdf")
+		data, _ = io.ReadAll(resp.Body)
+		So(string(data), ShouldEqual, "%PDF-1.4\n")
+
+		resp = response("Text/HTML; charset=UTF-8", png)
+		mime, err = DetectResponse(resp)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "text/html; charset=utf-8")
+		data, _ = io.ReadAll(resp.Body)
+		So(data, ShouldResemble, png)
+
+		resp = response("", png)
+		resp.Header.Set("Content-Encoding", "br")
+		mime, err = DetectResponse(resp)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "")
+
+		resp = response("", nil)
+		resp.Body = http.NoBody
+		mime, err = DetectResponse(resp)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "")
+
+		_, err = DetectResponse(nil)
+		So(err, ShouldNotBeNil)
+	})
+}
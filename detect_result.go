@@ -0,0 +1,159 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"fmt"
+	"os"
+)
+
+// Result is a classification returned by Detect
+type Result struct {
+	// Mime is the chosen mime type
+	Mime string
+	// Confidence is a score between zero and one of how reliable the Mime is
+	Confidence float64
+	// Source is the evidence the Mime was chosen from
+	Source Source
+}
+
+const (
+	// ConfidenceCertain is the Result.Confidence of directories and
+	// override files
+	ConfidenceCertain = 1.0
+	// ConfidenceConfirmed is the Result.Confidence of an extension which
+	// agrees with a specific content type
+	ConfidenceConfirmed = 0.95
+	// ConfidenceMagic is the Result.Confidence of a file signature match
+	ConfidenceMagic = 0.9
+	// ConfidenceDetector is the Result.Confidence of a registered Detector
+	// match
+	ConfidenceDetector = 0.8
+	// ConfidenceResolver is the Result.Confidence of a Resolver answer
+	ConfidenceResolver = 0.7
+	// ConfidenceExtension is the Result.Confidence of an extension when the
+	// content is generic or unreadable
+	ConfidenceExtension = 0.6
+	// ConfidenceText is the Result.Confidence of content which is only known
+	// to be text
+	ConfidenceText = 0.5
+	// ConfidenceMismatch is the Result.Confidence of an extension which
+	// disagrees with a specific content type
+	ConfidenceMismatch = 0.3
+	// ConfidenceUnknown is the Result.Confidence of content which is only
+	// known to be binary data
+	ConfidenceUnknown = 0.1
+)
+
+// Detect classifies the file or directory at `path` the same way as Mime,
+// choosing the override file entry first, then the extension and then the
+// content, but also reports the evidence used and a confidence score so that
+// pipelines can treat low-confidence classifications differently. An
+// extension confirmed by a specific content type scores higher than either
+// alone and an extension contradicted by the content scores lowest. When
+// there is no extension type and the content is only known to be text or
+// binary data, the Resolver, if any, is consulted
+func (r *Registry) Detect(path string) (result Result, err error) {
+	var info os.FileInfo
+	if r.GetPathOnly() {
+		return r.detectPathOnly(path)
+	} else if inode, ok := r.inodeMime(path); ok {
+		result = Result{Mime: inode, Confidence: ConfidenceCertain, Source: SourceInode}
+		return
+	} else if info, err = os.Stat(path); err != nil {
+		return
+	} else if info.IsDir() {
+		result = Result{Mime: DirectoryMimeType, Confidence: ConfidenceCertain, Source: SourceDirectory}
+		return
+	} else if !info.Mode().IsRegular() {
+		err = fmt.Errorf("%w: %s", ErrNotAFile, path)
+		return
+	} else if overridden, ok := r.fromOverrides(path); ok {
+		result = Result{Mime: r.output(overridden), Confidence: ConfidenceCertain, Source: SourceOverride}
+		return
+	}
+
+	byExtension, _ := r.FromPathChecked(path)
+	head, readErr := r.readFileHead(path)
+	var content string
+	var source Source
+	if readErr == nil {
+		content, source = r.detectSource(head)
+		content = r.output(r.withDetectedCharset(content, head))
+		if byExtension != "" {
+			byExtension = r.extensionMime(byExtension, head)
+		}
+	}
+
+	switch {
+	case byExtension != "" && (readErr != nil || source == SourceContent):
+		result = Result{Mime: byExtension, Confidence: ConfidenceExtension, Source: SourceExtension}
+	case byExtension != "" && r.typeMismatch(byExtension, content):
+		r.logWarn("mime: extension contradicted by content", "path", path, "extension", byExtension, "detected", content)
+		result = Result{Mime: byExtension, Confidence: ConfidenceMismatch, Source: SourceExtension}
+	case byExtension != "":
+		result = Result{Mime: byExtension, Confidence: ConfidenceConfirmed, Source: SourceExtension}
+	case readErr != nil:
+		err = readErr
+	case source == SourceMagic:
+		result = Result{Mime: content, Confidence: ConfidenceMagic, Source: SourceMagic}
+	case source == SourceDetector:
+		result = Result{Mime: content, Confidence: ConfidenceDetector, Source: SourceDetector}
+	default:
+		if resolver := r.GetResolver(); resolver != nil {
+			if resolved, ok := resolver(path, head); ok {
+				result = Result{Mime: r.output(resolved), Confidence: ConfidenceResolver, Source: SourceResolver}
+				return
+			}
+		}
+		result = Result{Mime: content, Confidence: ConfidenceText, Source: SourceContent}
+		if PruneCharset(content) == BinaryMimeType {
+			r.logDebug("mime: content not recognized", "path", path, "mime", content)
+			result.Confidence = ConfidenceUnknown
+		}
+	}
+	return
+}
+
+// detectPathOnly is Detect in path-only mode, reporting the PathMime result
+// or ErrUnknownExtension
+func (r *Registry) detectPathOnly(path string) (result Result, err error) {
+	switch mime := r.PathMime(path); {
+	case mime == DirectoryMimeType:
+		result = Result{Mime: mime, Confidence: ConfidenceCertain, Source: SourceDirectory}
+	case mime != "":
+		result = Result{Mime: mime, Confidence: ConfidenceExtension, Source: SourceExtension}
+	default:
+		err = ErrUnknownExtension
+	}
+	return
+}
+
+// Detect classifies the file or directory at `path` using the default
+// Registry, see Registry.Detect
+func Detect(path string) (result Result, err error) {
+	return gRegistry.Detect(path)
+}
+
+func (l *textDetectorList) has(mime string) (present bool) {
+	l.RLock()
+	defer l.RUnlock()
+	for _, d := range l.list {
+		if PruneCharset(d.Mime) == mime {
+			return true
+		}
+	}
+	return
+}
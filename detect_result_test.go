@@ -0,0 +1,82 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetect(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	dir := t.TempDir()
+	write := func(name string, data []byte) (path string) {
+		path = filepath.Join(dir, name)
+		So(os.WriteFile(path, data, 0644), ShouldBeNil)
+		return
+	}
+
+	Convey("Detect", t, func() {
+		r := New()
+
+		result, err := r.Detect(dir)
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: DirectoryMimeType, Confidence: ConfidenceCertain, Source: SourceDirectory})
+
+		result, err = r.Detect(write("logo.png", png))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "image/png", Confidence: ConfidenceConfirmed, Source: SourceExtension})
+
+		result, err = r.Detect(write("report.pdf", png))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "application/pdf", Confidence: ConfidenceMismatch, Source: SourceExtension})
+
+		result, err = r.Detect(write("notes.csv", []byte("a,b\n1,2\n")))
+		So(err, ShouldBeNil)
+		So(result.Mime, ShouldEqual, "text/csv; charset=utf-8")
+		So(result.Source, ShouldEqual, SourceExtension)
+
+		result, err = r.Detect(write("logo", png))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "image/png", Confidence: ConfidenceMagic, Source: SourceMagic})
+
+		result, err = r.Detect(write("blob", []byte{0x00, 0x01, 0x02, 0x03}))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: BinaryMimeType, Confidence: ConfidenceUnknown, Source: SourceContent})
+
+		r.SetResolver(func(path string, peek []byte) (mime string, ok bool) {
+			return "application/x-blob", filepath.Base(path) == "blob"
+		})
+		result, err = r.Detect(filepath.Join(dir, "blob"))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "application/x-blob", Confidence: ConfidenceResolver, Source: SourceResolver})
+
+		_, err = r.Detect(filepath.Join(dir, "missing"))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Detect with detectors", t, func() {
+		r := New()
+		r.RegisterDetector(Detector{Mime: "application/x-custom", Detect: func(raw []byte, limit uint32) bool {
+			return len(raw) > 4 && string(raw[:4]) == "CUST"
+		}})
+		result, err := r.Detect(write("custom", []byte("CUST\x00\x01")))
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "application/x-custom", Confidence: ConfidenceDetector, Source: SourceDetector})
+	})
+}
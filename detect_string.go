@@ -0,0 +1,147 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type textDetectorList struct {
+	list []Detector
+	sync.RWMutex
+}
+
+// gTextDetectors are the non-nil detectors given to RegisterTextType, in
+// Detector.Priority order
+var gTextDetectors = &textDetectorList{}
+
+// gCatchAllDetectors are the PlainTextDetector instances of the types given
+// to RegisterTextType without a detector, which claim any text content and
+// are therefore only consulted after all gTextDetectors
+var gCatchAllDetectors = &textDetectorList{}
+
+// add inserts the Detector ahead of any others with the same or a lower
+// Priority, keeping the list in the order it is consulted
+func (l *textDetectorList) add(d Detector) {
+	l.Lock()
+	defer l.Unlock()
+	idx := 0
+	for idx < len(l.list) && l.list[idx].Priority > d.Priority {
+		idx += 1
+	}
+	l.list = append(l.list[:idx], append([]Detector{d}, l.list[idx:]...)...)
+}
+
+// remove deletes all the detectors of the given `mediatype`
+func (l *textDetectorList) remove(mediatype string) (removed bool) {
+	l.Lock()
+	defer l.Unlock()
+	kept := l.list[:0:0]
+	for _, d := range l.list {
+		if PruneCharset(d.Mime) == mediatype {
+			removed = true
+		} else {
+			kept = append(kept, d)
+		}
+	}
+	l.list = kept
+	return
+}
+
+// replace sets the list to a copy of the given detectors
+func (l *textDetectorList) replace(list []Detector) {
+	l.Lock()
+	defer l.Unlock()
+	l.list = append([]Detector(nil), list...)
+}
+
+// snapshot returns a copy of the list
+func (l *textDetectorList) snapshot() (list []Detector) {
+	l.RLock()
+	defer l.RUnlock()
+	return append(list, l.list...)
+}
+
+func (l *textDetectorList) detect(raw []byte, limit uint32) (mime string, ok bool) {
+	l.RLock()
+	defer l.RUnlock()
+	for _, d := range l.list {
+		if d.Detect(raw, limit) {
+			return d.Mime, true
+		}
+	}
+	return
+}
+
+// DetectString returns the mime type of the given `s`, which is already known
+// to be decoded text, such as editor input within a CMS. Binary magic number
+// checks are skipped entirely, Registry detectors, plugin detectors and the
+// detectors given to RegisterTextType are checked first, followed by
+// heuristics for JSON, HTML, XML, org-mode and markdown. Only the leading
+// read limit of `s` is inspected (see SetReadLimit), with JSON cut short by
+// the limit accepted when valid up to that point. Text which is not more
+// specifically identified is TextMimeType with a utf-8 charset
+func (r *Registry) DetectString(s string) (mime string) {
+	limit := r.GetReadLimit()
+	raw := []byte(s)
+	if len(raw) > limit {
+		// the read limit may have split a multi-byte rune
+		raw = trimPartialRune(raw[:limit])
+	}
+	head := string(raw)
+	var ok bool
+	if mime, ok = r.detectors.detect(raw, uint32(limit)); ok && r.IsPlainText(mime) {
+		return r.output(mime)
+	} else if mime, ok = detectPlugins(raw, uint32(limit)); ok && r.IsPlainText(mime) {
+		return r.output(mime)
+	} else if mime, ok = gTextDetectors.detect(raw, uint32(limit)); ok {
+		return r.output(mime)
+	} else if trimmed := bytes.TrimSpace(raw); bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		// only the leading read limit is checked, accepting a value cut short
+		if json.Valid(trimmed) || (len(raw) < len(s) && validJSONPrefix(trimmed)) {
+			return r.output(JsonMimeType)
+		}
+	}
+	switch sniffed := http.DetectContentType(raw); PruneCharset(sniffed) {
+	case HtmlMimeType, "text/xml":
+		return r.output(sniffed)
+	}
+	for _, line := range strings.Split(head, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#+") && strings.Contains(line, ":"):
+			return r.output(OrgModeMimeType + "; charset=utf-8")
+		case strings.HasPrefix(line, "```"), isMarkdownHeading(line):
+			return r.output(MarkdownMimeType + "; charset=utf-8")
+		}
+	}
+	return r.output(TextMimeType + "; charset=utf-8")
+}
+
+// isMarkdownHeading returns true if the `line` is an ATX heading
+func isMarkdownHeading(line string) bool {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	return level >= 1 && level <= 6 && len(line) > level && line[level] == ' '
+}
+
+// DetectString returns the mime type of the given text using the default
+// Registry, see Registry.DetectString
+func DetectString(s string) (mime string) {
+	return gRegistry.DetectString(s)
+}
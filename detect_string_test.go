@@ -0,0 +1,74 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectString(t *testing.T) {
+	Convey("DetectString", t, func() {
+		So(DetectString("BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Someone\r\nEND:VCARD\r\n"), ShouldEqual, VCardMimeType+"; charset=utf-8")
+		So(DetectString("BEGIN:VCALENDAR\nVERSION:2.0\nEND:VCALENDAR\n"), ShouldEqual, CalendarMimeType+"; charset=utf-8")
+		So(DetectString("From: someone@example.com\nTo: other@example.com\n\nhi\n"), ShouldEqual, EmailMimeType)
+		So(DetectString(` {"key": "value"}`), ShouldEqual, JsonMimeType)
+		So(DetectString(`{"key": `), ShouldEqual, TextMimeType+"; charset=utf-8")
+		So(DetectString("<!DOCTYPE html><html><body></body></html>"), ShouldEqual, HtmlMimeType+"; charset=utf-8")
+		So(DetectString("<?xml version=\"1.0\"?><root/>"), ShouldEqual, "text/xml; charset=utf-8")
+		So(DetectString("#+TITLE: Notes\n\n* Heading\n"), ShouldEqual, OrgModeMimeType+"; charset=utf-8")
+		So(DetectString("Intro\n\n## Heading\n"), ShouldEqual, MarkdownMimeType+"; charset=utf-8")
+		So(DetectString("```go\npackage main\n```\n"), ShouldEqual, MarkdownMimeType+"; charset=utf-8")
+		So(DetectString("#hashtag and more"), ShouldEqual, TextMimeType+"; charset=utf-8")
+		So(DetectString("%PDF-1.4 is how pdf files start"), ShouldEqual, TextMimeType+"; charset=utf-8")
+		So(DetectString(""), ShouldEqual, TextMimeType+"; charset=utf-8")
+
+		Convey("read limit", func() {
+			var seen []byte
+			var seenLimit uint32
+			r := New()
+			So(r.RegisterTextType("text/x-limited", "limited", func(raw []byte, limit uint32) bool {
+				seen, seenLimit = raw, limit
+				return false
+			}), ShouldBeNil)
+			r.SetReadLimit(16)
+			// the 2-byte é straddles the 16 byte limit
+			So(r.DetectString(strings.Repeat("a", 15)+"é and more text"), ShouldEqual, TextMimeType+"; charset=utf-8")
+			So(seenLimit, ShouldEqual, 16)
+			So(string(seen), ShouldEqual, strings.Repeat("a", 15))
+			So(utf8.Valid(seen), ShouldBeTrue)
+
+			r.SetReadLimit(HeadWindow * 2)
+			long := strings.Repeat("x\n", HeadWindow/2) + "## Heading\n"
+			So(r.DetectString(long), ShouldEqual, MarkdownMimeType+"; charset=utf-8")
+			So(seenLimit, ShouldEqual, HeadWindow*2)
+			So(len(seen), ShouldEqual, len(long))
+		})
+
+		Convey("json within the read limit", func() {
+			r := New()
+			r.SetReadLimit(16)
+			So(r.DetectString(`{"key": "a value running past the limit"}`), ShouldEqual, JsonMimeType)
+			// content beyond the limit is never inspected
+			So(r.DetectString(`{"key": "value", `+strings.Repeat("x", HeadWindow)), ShouldEqual, JsonMimeType)
+			So(r.DetectString(`{"key": value running past the limit}`), ShouldEqual, TextMimeType+"; charset=utf-8")
+			So(r.DetectString(`{"key": 1}`), ShouldEqual, JsonMimeType)
+			So(r.DetectString(`{"key": 1`), ShouldEqual, TextMimeType+"; charset=utf-8")
+		})
+	})
+}
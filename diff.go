@@ -0,0 +1,117 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffKind describes the nature of a DiffEntry
+type DiffKind uint8
+
+const (
+	// DiffAdded indicates the mapping is present only in the second Registry
+	DiffAdded DiffKind = iota
+	// DiffRemoved indicates the mapping is present only in the first Registry
+	DiffRemoved
+	// DiffChanged indicates the mapping is present in both but with
+	// different values
+	DiffChanged
+)
+
+// String returns the single character prefix used by DiffReport.String
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "+"
+	case DiffRemoved:
+		return "-"
+	case DiffChanged:
+		return "~"
+	}
+	return "?"
+}
+
+// DiffEntry is a single mapping difference between two Registry instances
+type DiffEntry struct {
+	Table  Table
+	Kind   DiffKind
+	Key    string
+	Before string
+	After  string
+}
+
+// DiffReport is the list of differences returned by Diff, sorted by Table
+// and then Key
+type DiffReport []DiffEntry
+
+// Empty returns true if there are no differences
+func (d DiffReport) Empty() bool {
+	return len(d) == 0
+}
+
+// String returns a line-oriented, human-readable summary of the report
+func (d DiffReport) String() string {
+	var buf strings.Builder
+	for _, e := range d {
+		switch e.Kind {
+		case DiffAdded:
+			buf.WriteString(fmt.Sprintf("%s %s %q: %q\n", e.Kind, e.Table, e.Key, e.After))
+		case DiffRemoved:
+			buf.WriteString(fmt.Sprintf("%s %s %q: %q\n", e.Kind, e.Table, e.Key, e.Before))
+		default:
+			buf.WriteString(fmt.Sprintf("%s %s %q: %q => %q\n", e.Kind, e.Table, e.Key, e.Before, e.After))
+		}
+	}
+	return buf.String()
+}
+
+// Diff compares the extension, charset, alias, filename and glob mappings of
+// the `a` and `b` Registry instances and reports what changes would turn `a`
+// into `b`. A nil Registry is treated as having no mappings at all
+func Diff(a, b *Registry) (report DiffReport) {
+	for _, t := range []Table{ExtensionTable, CharsetTable, AliasTable, FilenameTable, GlobTable} {
+		before, after := a.snapshot(t), b.snapshot(t)
+		var entries DiffReport
+		for k, bv := range before {
+			if av, present := after[k]; !present {
+				entries = append(entries, DiffEntry{Table: t, Kind: DiffRemoved, Key: k, Before: bv})
+			} else if av != bv {
+				entries = append(entries, DiffEntry{Table: t, Kind: DiffChanged, Key: k, Before: bv, After: av})
+			}
+		}
+		for k, av := range after {
+			if _, present := before[k]; !present {
+				entries = append(entries, DiffEntry{Table: t, Kind: DiffAdded, Key: k, After: av})
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Key < entries[j].Key
+		})
+		report = append(report, entries...)
+	}
+	return
+}
+
+func (r *Registry) snapshot(t Table) (m map[string]string) {
+	if r != nil {
+		if l := r.table(t); l != nil {
+			return l.snapshot()
+		}
+	}
+	return map[string]string{}
+}
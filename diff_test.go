@@ -0,0 +1,58 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiff(t *testing.T) {
+	Convey("Diff", t, func() {
+		Convey("identical registries", func() {
+			So(Diff(New(), New()).Empty(), ShouldBeTrue)
+		})
+
+		Convey("added, removed and changed", func() {
+			a, b := New(), New()
+			b.SetExtension("njk", "text/x-nunjucks")
+			b.SetExtension("txt", "text/plain")
+			b.SetCharset("text/css", "")
+			b.SetAlias("text/x-md", "text/markdown")
+			report := Diff(a, b)
+			So(report, ShouldResemble, DiffReport{
+				{Table: ExtensionTable, Kind: DiffAdded, Key: "njk", After: "text/x-nunjucks"},
+				{Table: ExtensionTable, Kind: DiffChanged, Key: "txt", Before: "text/plain; charset=utf-8", After: "text/plain"},
+				{Table: CharsetTable, Kind: DiffRemoved, Key: "text/css", Before: "utf-8"},
+				{Table: AliasTable, Kind: DiffAdded, Key: "text/x-md", After: "text/markdown"},
+			})
+			So(report.String(), ShouldEqual, `+ extension "njk": "text/x-nunjucks"
+~ extension "txt": "text/plain; charset=utf-8" => "text/plain"
+- charset "text/css": "utf-8"
++ alias "text/x-md": "text/markdown"
+`)
+		})
+
+		Convey("nil registries", func() {
+			So(Diff(nil, nil).Empty(), ShouldBeTrue)
+			report := Diff(nil, New())
+			So(report.Empty(), ShouldBeFalse)
+			for _, e := range report {
+				So(e.Kind, ShouldEqual, DiffAdded)
+			}
+		})
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidDisposition is returned by ParseContentDisposition for values
+// which are media types instead of disposition types
+var ErrInvalidDisposition = errors.New("invalid content disposition")
+
+// Disposition is the disposition type of a Content-Disposition header
+type Disposition string
+
+const (
+	// DispositionInline asks the client to display the content
+	DispositionInline Disposition = "inline"
+	// DispositionAttachment asks the client to download the content
+	DispositionAttachment Disposition = "attachment"
+)
+
+// DispositionFor returns the Disposition to use when serving content of the
+// given `mime` type. Images, audio, video, plain text and PDF documents are
+// displayed inline, everything else is an attachment. Types that can run
+// scripts in a browser, such as text/html and image/svg+xml, are always
+// attachments so that serving untrusted uploads does not enable cross-site
+// scripting
+func (r *Registry) DispositionFor(mime string) (disposition Disposition) {
+	key := r.Key(PruneCharset(mime))
+	switch key {
+	case "image/svg+xml":
+		return DispositionAttachment
+	case TextMimeType, "application/pdf":
+		return DispositionInline
+	}
+	switch TopLevel(key) {
+	case "image", "audio", "video":
+		return DispositionInline
+	}
+	return DispositionAttachment
+}
+
+// ContentDisposition returns an RFC 6266 Content-Disposition header value
+// for content of the given `mime` type, with the Disposition decided by
+// DispositionFor. The `filename` is cleaned with SuggestFilename and
+// non-ASCII names are also given in the RFC 5987 filename* form, see
+// MediaType.Header
+func (r *Registry) ContentDisposition(filename, mime string) (header string) {
+	return MediaType{Type: string(r.DispositionFor(mime))}.
+		WithParam("filename", r.SuggestFilename(filename, mime)).
+		Header()
+}
+
+// DispositionFor returns the Disposition for the given `mime` type using
+// the default Registry, see Registry.DispositionFor
+func DispositionFor(mime string) (disposition Disposition) {
+	return gRegistry.DispositionFor(mime)
+}
+
+// ContentDisposition returns a Content-Disposition header value using the
+// default Registry, see Registry.ContentDisposition
+func ContentDisposition(filename, mime string) (header string) {
+	return gRegistry.ContentDisposition(filename, mime)
+}
+
+// ParseContentDisposition parses a Content-Disposition header `value`,
+// returning the disposition type and the filename. The filename* form is
+// preferred over the plain filename parameter and any directory portion of
+// the filename is removed. Unknown disposition types are reported as
+// DispositionAttachment, as required by RFC 6266
+func ParseContentDisposition(value string) (disposition Disposition, filename string, err error) {
+	var mt MediaType
+	if mt, err = ParseMediaType(value); err != nil {
+		return
+	} else if mt.Subtype != "" {
+		err = ErrInvalidDisposition
+		return
+	}
+	// mime.ParseMediaType decodes filename* into the filename parameter
+	filename = mt.Param("filename")
+	if idx := strings.LastIndexAny(filename, `/\`); idx >= 0 {
+		filename = filename[idx+1:]
+	}
+	if disposition = Disposition(mt.Type); disposition != DispositionInline {
+		disposition = DispositionAttachment
+	}
+	return
+}
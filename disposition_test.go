@@ -0,0 +1,83 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContentDisposition(t *testing.T) {
+	Convey("DispositionFor", t, func() {
+		r := New()
+		for _, check := range []struct {
+			mime        string
+			disposition Disposition
+		}{
+			{"image/png", DispositionInline},
+			{"image/jpg", DispositionInline},
+			{"video/mp4", DispositionInline},
+			{"audio/mpeg", DispositionInline},
+			{"text/plain; charset=utf-8", DispositionInline},
+			{"application/pdf", DispositionInline},
+			{"image/svg+xml", DispositionAttachment},
+			{"text/html", DispositionAttachment},
+			{"application/zip", DispositionAttachment},
+			{"", DispositionAttachment},
+		} {
+			So(r.DispositionFor(check.mime), ShouldEqual, check.disposition)
+		}
+		So(DispositionFor("image/gif"), ShouldEqual, DispositionInline)
+	})
+
+	Convey("ContentDisposition", t, func() {
+		r := New()
+		So(r.ContentDisposition("photo.png", "image/png"), ShouldEqual, `inline; filename=photo.png`)
+		So(r.ContentDisposition("../../report", "application/pdf"), ShouldEqual, `inline; filename=report.pdf`)
+		So(r.ContentDisposition("", "application/zip"), ShouldEqual, `attachment; filename=download.zip`)
+		So(r.ContentDisposition("naïve.html", "text/html"), ShouldEqual, `attachment; filename=naive.html; filename*=utf-8''na%C3%AFve.html`)
+		So(ContentDisposition("notes.txt", "text/plain"), ShouldEqual, `inline; filename=notes.txt`)
+	})
+
+	Convey("ParseContentDisposition", t, func() {
+		for _, check := range []struct {
+			value       string
+			disposition Disposition
+			filename    string
+		}{
+			{`inline`, DispositionInline, ""},
+			{`Attachment; filename="report.pdf"`, DispositionAttachment, "report.pdf"},
+			{`attachment; filename="fallback.txt"; filename*=UTF-8''na%C3%AFve.txt`, DispositionAttachment, "naïve.txt"},
+			{`form-data; name="upload"; filename="C:\\Users\\me\\photo.jpg"`, DispositionAttachment, "photo.jpg"},
+			{`attachment; filename="../../etc/passwd"`, DispositionAttachment, "passwd"},
+		} {
+			disposition, filename, err := ParseContentDisposition(check.value)
+			So(err, ShouldBeNil)
+			So(disposition, ShouldEqual, check.disposition)
+			So(filename, ShouldEqual, check.filename)
+		}
+
+		disposition, filename, err := ParseContentDisposition(ContentDisposition("naïve résumé.txt", "text/plain"))
+		So(err, ShouldBeNil)
+		So(disposition, ShouldEqual, DispositionInline)
+		So(filename, ShouldEqual, "naïve résumé.txt")
+
+		_, _, err = ParseContentDisposition("text/plain")
+		So(err, ShouldEqual, ErrInvalidDisposition)
+		_, _, err = ParseContentDisposition("")
+		So(err, ShouldNotBeNil)
+	})
+}
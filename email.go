@@ -0,0 +1,137 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"io"
+	goMime "mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	// EmailMimeType is the mime type of RFC 822/2822 message files
+	EmailMimeType = "message/rfc822"
+	// OutlookMimeType is the mime type of Outlook message containers
+	OutlookMimeType = "application/vnd.ms-outlook"
+)
+
+var gEmailHeaders = map[string]struct{}{
+	"from": {}, "to": {}, "cc": {}, "bcc": {}, "subject": {}, "date": {},
+	"received": {}, "return-path": {}, "message-id": {}, "mime-version": {},
+	"delivered-to": {}, "reply-to": {}, "sender": {}, "in-reply-to": {},
+	"references": {}, "content-type": {},
+}
+
+func registerEmailTypes() {
+	SetExtension("eml", EmailMimeType)
+	SetExtension("msg", OutlookMimeType)
+	graftDetector(mimetype.Lookup(TextMimeType), EmailDetector, EmailMimeType, ".eml")
+	gTextDetectors.add(Detector{Mime: EmailMimeType, Detect: EmailDetector})
+}
+
+// EmailDetector returns true if the given `raw` content starts with a block
+// of RFC 822 header fields containing at least two commonly used message
+// headers (From, To, Subject, Date, Received and so on)
+func EmailDetector(raw []byte, limit uint32) bool {
+	if limit > 0 && len(raw) > int(limit) {
+		raw = raw[:limit]
+	}
+	var known int
+	lines := bytes.Split(raw, []byte("\n"))
+	for idx, line := range lines {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) == 0 {
+			// end of the header block
+			break
+		} else if idx == len(lines)-1 {
+			// possibly truncated by the limit
+			break
+		} else if line[0] == ' ' || line[0] == '\t' {
+			// folded continuation of the previous header
+			if idx == 0 {
+				return false
+			}
+			continue
+		}
+		name, _, found := bytes.Cut(line, []byte(":"))
+		if !found || len(name) == 0 || bytes.ContainsAny(name, " \t") {
+			return false
+		}
+		if _, ok := gEmailHeaders[strings.ToLower(string(name))]; ok {
+			known += 1
+		}
+	}
+	return known >= 2
+}
+
+// EmailPart describes a top-level part of an email message
+type EmailPart struct {
+	ContentType string
+	Filename    string
+}
+
+// EmailSummary is a brief description of an email message
+type EmailSummary struct {
+	From        string
+	To          string
+	Subject     string
+	Date        string
+	ContentType string
+	Parts       []EmailPart
+}
+
+// SummarizeEmail parses the RFC 822 message read from `r` and returns the
+// main headers along with the content types of the top-level parts when the
+// message is multipart. Outlook message containers are not supported
+func SummarizeEmail(r io.Reader) (summary *EmailSummary, err error) {
+	var msg *mail.Message
+	if msg, err = mail.ReadMessage(r); err != nil {
+		return
+	}
+	summary = &EmailSummary{
+		From:        msg.Header.Get("From"),
+		To:          msg.Header.Get("To"),
+		Subject:     msg.Header.Get("Subject"),
+		Date:        msg.Header.Get("Date"),
+		ContentType: msg.Header.Get("Content-Type"),
+	}
+	if summary.ContentType == "" {
+		summary.ContentType = TextMimeType
+	}
+	mediatype, params, e := goMime.ParseMediaType(summary.ContentType)
+	if e != nil || !strings.HasPrefix(mediatype, "multipart/") || params["boundary"] == "" {
+		return
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		var part *multipart.Part
+		if part, err = mr.NextPart(); err == io.EOF {
+			err = nil
+			return
+		} else if err != nil {
+			return
+		}
+		ep := EmailPart{ContentType: part.Header.Get("Content-Type"), Filename: part.FileName()}
+		if ep.ContentType == "" {
+			ep.ContentType = TextMimeType
+		}
+		summary.Parts = append(summary.Parts, ep)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testEmail = "From: a@example.com\r\n" +
+	"To: b@example.com\r\n" +
+	"Subject: hello\r\n" +
+	"Date: Fri, 2 Feb 2024 10:00:00 +0000\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=XYZ\r\n" +
+	"\r\n" +
+	"--XYZ\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"body\r\n" +
+	"--XYZ\r\n" +
+	"Content-Type: application/pdf\r\n" +
+	"Content-Disposition: attachment; filename=\"doc.pdf\"\r\n" +
+	"\r\n" +
+	"%PDF-1.4\r\n" +
+	"--XYZ--\r\n"
+
+func TestEmail(t *testing.T) {
+	Convey("EmailDetector", t, func() {
+		So(EmailDetector([]byte(testEmail), 3072), ShouldBeTrue)
+		So(EmailDetector([]byte("Subject: only one\n\nbody"), 3072), ShouldBeFalse)
+		So(EmailDetector([]byte("# markdown\n\nnot: email\n"), 3072), ShouldBeFalse)
+		So(EmailDetector([]byte(" From: folded\nTo: x\n\n"), 3072), ShouldBeFalse)
+		mt := mimetype.Lookup(EmailMimeType)
+		So(mt, ShouldNotBeNil)
+		So(mt.Parent().Is(TextMimeType), ShouldBeTrue)
+	})
+
+	Convey("email extensions", t, func() {
+		So(FromPathOnly("message.eml"), ShouldEqual, EmailMimeType)
+		So(FromPathOnly("message.msg"), ShouldEqual, OutlookMimeType)
+	})
+
+	Convey("SummarizeEmail", t, func() {
+		summary, err := SummarizeEmail(strings.NewReader(testEmail))
+		So(err, ShouldBeNil)
+		So(summary.From, ShouldEqual, "a@example.com")
+		So(summary.Subject, ShouldEqual, "hello")
+		So(summary.Parts, ShouldResemble, []EmailPart{
+			{ContentType: "text/plain"},
+			{ContentType: "application/pdf", Filename: "doc.pdf"},
+		})
+
+		summary, err = SummarizeEmail(strings.NewReader("From: a@example.com\r\n\r\nbody"))
+		So(err, ShouldBeNil)
+		So(summary.ContentType, ShouldEqual, TextMimeType)
+		So(summary.Parts, ShouldBeEmpty)
+
+		_, err = SummarizeEmail(strings.NewReader(""))
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+var (
+	// ErrUnknownExtension is returned by FromPathOnlyE when the path does not
+	// identify any mime type
+	ErrUnknownExtension = errors.New("unknown extension")
+	// ErrNotAFile is returned by MimeE when the path is neither a directory
+	// nor a regular file
+	ErrNotAFile = errors.New("not a file")
+	// ErrDetectionFailed is returned by MimeE when the content of a file is
+	// needed to identify the mime type but could not be read or identified
+	ErrDetectionFailed = errors.New("detection failed")
+)
+
+// FromPathOnlyE is FromPathOnly returning ErrUnknownExtension instead of an
+// empty string when the `path` does not identify any mime type
+func (r *Registry) FromPathOnlyE(path string) (mime string, err error) {
+	if mime = r.FromPathOnly(path); mime == "" {
+		err = ErrUnknownExtension
+	}
+	return
+}
+
+// MimeE is Mime returning an error instead of an empty string, so that
+// callers can distinguish a file which is not of any known type from a path
+// which could not be classified. Permission and I/O errors are surfaced
+// instead of being logged: errors from os.Stat are returned as-is, except
+// for missing paths which are also ErrNotAFile, paths which are neither a
+// directory nor a regular file are ErrNotAFile and errors reading the
+// content are wrapped in ErrDetectionFailed, so that errors.Is works with
+// both the sentinel and the underlying error, such as fs.ErrPermission. In
+// path-only mode, see SetPathOnly, paths which PathMime cannot classify are
+// ErrUnknownExtension
+func (r *Registry) MimeE(path string) (mime string, err error) {
+	if r.GetPathOnly() {
+		if mime = r.PathMime(path); mime == "" {
+			err = ErrUnknownExtension
+		}
+		return
+	} else if inode, ok := r.inodeMime(path); ok {
+		return inode, nil
+	}
+	var info os.FileInfo
+	if info, err = os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %w", ErrNotAFile, err)
+	} else if err != nil {
+		return
+	} else if info.IsDir() {
+		return DirectoryMimeType, nil
+	} else if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: %s", ErrNotAFile, path)
+	} else if overridden, ok := r.fromOverrides(path); ok {
+		return r.output(overridden), nil
+	} else if mime, _ = r.FromPathChecked(path); mime != "" && !r.IsPlainText(mime) {
+		return
+	}
+	byExtension := mime
+	var head []byte
+	if head, err = r.readFileHead(path); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDetectionFailed, err)
+	} else if mime = r.fromHead(nil, path, byExtension, head, nil); mime == "" {
+		err = fmt.Errorf("%w: %s", ErrDetectionFailed, path)
+	}
+	return
+}
+
+// FromPathOnlyE is FromPathOnly using the default Registry, see
+// Registry.FromPathOnlyE
+func FromPathOnlyE(path string) (mime string, err error) {
+	return gRegistry.FromPathOnlyE(path)
+}
+
+// MimeE is Mime using the default Registry, see Registry.MimeE
+func MimeE(path string) (mime string, err error) {
+	return gRegistry.MimeE(path)
+}
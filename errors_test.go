@@ -0,0 +1,68 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErrorLookups(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	dir := t.TempDir()
+
+	Convey("FromPathOnlyE", t, func() {
+		r := New()
+		mime, err := r.FromPathOnlyE("page.html")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "text/html; charset=utf-8")
+		mime, err = r.FromPathOnlyE("file.no-such-extension")
+		So(errors.Is(err, ErrUnknownExtension), ShouldBeTrue)
+		So(mime, ShouldBeEmpty)
+		_, err = FromPathOnlyE("")
+		So(errors.Is(err, ErrUnknownExtension), ShouldBeTrue)
+	})
+
+	Convey("MimeE", t, func() {
+		r := New()
+		mime, err := r.MimeE(dir)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, DirectoryMimeType)
+
+		blob := filepath.Join(dir, "blob")
+		So(os.WriteFile(blob, png, 0644), ShouldBeNil)
+		mime, err = r.MimeE(blob)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+
+		mime, err = MimeE("./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+
+		mime, err = r.MimeE(filepath.Join(dir, "missing"))
+		So(errors.Is(err, ErrNotAFile), ShouldBeTrue)
+		So(errors.Is(err, fs.ErrNotExist), ShouldBeTrue)
+		So(mime, ShouldBeEmpty)
+
+		_, err = r.MimeE(filepath.Join(blob, "beneath-a-file"))
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrNotAFile), ShouldBeFalse)
+	})
+}
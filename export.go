@@ -0,0 +1,103 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// ExportVersion is the schema version of the Export structure
+const ExportVersion = 1
+
+// Export is the stable schema written by ExportJSON and read by ImportJSON
+// and ImportYAML. Map keys are written in sorted order so that exports of the
+// same configuration are identical
+type Export struct {
+	// Version is the ExportVersion of the schema
+	Version int `json:"version" yaml:"version"`
+	// Extensions are the extension to mime type mappings
+	Extensions map[string]string `json:"extensions" yaml:"extensions"`
+	// Charsets are the mime type to charset mappings
+	Charsets map[string]string `json:"charsets" yaml:"charsets"`
+	// Aliases are the alias to canonical mime type mappings
+	Aliases map[string]string `json:"aliases" yaml:"aliases"`
+	// Filenames are the well-known file name to mime type mappings
+	Filenames map[string]string `json:"filenames" yaml:"filenames"`
+	// Globs are the glob pattern to mime type mappings
+	Globs map[string]string `json:"globs" yaml:"globs"`
+	// Types are the sorted custom mime types, without parameters, having
+	// content detectors from RegisterDetector, plugins and, for the default
+	// Registry, RegisterTextType and RegisterBinaryType
+	Types []string `json:"types" yaml:"types"`
+}
+
+// Export returns the effective configuration of the Registry
+func (r *Registry) Export() (e Export) {
+	e = Export{
+		Version:    ExportVersion,
+		Extensions: r.snapshot(ExtensionTable),
+		Charsets:   r.snapshot(CharsetTable),
+		Aliases:    r.snapshot(AliasTable),
+		Filenames:  r.snapshot(FilenameTable),
+		Globs:      r.snapshot(GlobTable),
+		Types:      []string{},
+	}
+	seen := map[string]struct{}{}
+	add := func(mimes []string) {
+		for _, mime := range mimes {
+			mime = PruneCharset(mime)
+			if _, present := seen[mime]; !present {
+				seen[mime] = struct{}{}
+				e.Types = append(e.Types, mime)
+			}
+		}
+	}
+	add(r.detectors.mimes())
+	for _, info := range Plugins() {
+		add(info.Detectors)
+	}
+	if r == gRegistry {
+		add(gTextDetectors.mimes())
+		add(gBinaryDetectors.mimes())
+	}
+	sort.Strings(e.Types)
+	return
+}
+
+// ExportJSON writes the Export of the Registry to `w` as indented JSON, so
+// that deployments can audit and diff the effective configuration of a
+// running service
+func (r *Registry) ExportJSON(w io.Writer) (err error) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Export())
+}
+
+// ExportJSON writes the Export of the default Registry to `w` as indented
+// JSON, see Registry.ExportJSON
+func ExportJSON(w io.Writer) (err error) {
+	return gRegistry.ExportJSON(w)
+}
+
+func (l *textDetectorList) mimes() (mimes []string) {
+	l.RLock()
+	defer l.RUnlock()
+	for _, d := range l.list {
+		mimes = append(mimes, d.Mime)
+	}
+	return
+}
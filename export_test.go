@@ -0,0 +1,57 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportJSON(t *testing.T) {
+	Convey("ExportJSON", t, func() {
+		r := New()
+		r.SetExtension("njk", "text/x-nunjucks")
+		So(r.RegisterDetector(Detector{Mime: "application/x-thing", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("THING"))
+		}}), ShouldBeNil)
+
+		var first, second bytes.Buffer
+		So(r.ExportJSON(&first), ShouldBeNil)
+		So(r.ExportJSON(&second), ShouldBeNil)
+		So(first.String(), ShouldEqual, second.String())
+		So(first.String(), ShouldStartWith, "{\n  \"version\": 1,\n  \"extensions\": {\n")
+		So(strings.Index(first.String(), `"css"`), ShouldBeLessThan, strings.Index(first.String(), `"njk"`))
+
+		var e Export
+		So(json.Unmarshal(first.Bytes(), &e), ShouldBeNil)
+		So(e.Version, ShouldEqual, ExportVersion)
+		So(e.Extensions["njk"], ShouldEqual, "text/x-nunjucks")
+		So(e.Charsets[HtmlMimeType], ShouldEqual, "utf-8")
+		So(e.Aliases["text/x-markdown"], ShouldEqual, MarkdownMimeType)
+		So(e.Filenames["makefile"], ShouldStartWith, MakefileMimeType)
+		So(e.Globs, ShouldBeEmpty)
+		So(e.Types, ShouldContain, "application/x-thing")
+		So(New().Export().Types, ShouldNotContain, "application/x-thing")
+
+		var buf bytes.Buffer
+		So(ExportJSON(&buf), ShouldBeNil)
+		So(json.Unmarshal(buf.Bytes(), &e), ShouldBeNil)
+		So(e.Types, ShouldContain, VCardMimeType)
+	})
+}
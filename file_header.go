@@ -0,0 +1,121 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+)
+
+// ErrTypeMismatch is the error wrapped by MismatchError
+var ErrTypeMismatch = errors.New("declared type does not match content")
+
+// MismatchError reports the discrepancies found by FromFileHeader between
+// the detected content type and the declared Content-Type or filename
+// extension of an upload
+type MismatchError struct {
+	// Filename is the name given by the client
+	Filename string
+	// Detected is the mime type detected from the content
+	Detected string
+	// Declared is the Content-Type given by the client, when it disagrees
+	// with the Detected type
+	Declared string
+	// ByExtension is the mime type of the Filename extension, when it
+	// disagrees with the Detected type
+	ByExtension string
+}
+
+// Error returns a description of the discrepancies
+func (e *MismatchError) Error() string {
+	msg := fmt.Sprintf("%q detected as %s", e.Filename, PruneCharset(e.Detected))
+	if e.Declared != "" {
+		msg += ", declared as " + PruneCharset(e.Declared)
+	}
+	if e.ByExtension != "" {
+		msg += ", named as " + PruneCharset(e.ByExtension)
+	}
+	return msg
+}
+
+// Unwrap returns ErrTypeMismatch
+func (e *MismatchError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// FromFileHeader opens the given multipart upload and detects the mime type
+// of its content. When the content is only identified as generic text or
+// binary data, the type of the filename extension is used instead, if known.
+// The declared Content-Type of the part (ignoring the BinaryMimeType that
+// clients send for unknown files) and the filename extension are both
+// cross-checked with the detected type and any discrepancies are returned as
+// a *MismatchError along with the detected `mime`, so that upload handlers
+// can choose whether to reject or log them. Filenames that CheckPath
+// considers suspicious do not contribute an extension type
+func (r *Registry) FromFileHeader(fh *multipart.FileHeader) (mime string, err error) {
+	if fh == nil {
+		err = errors.New("nil file header")
+		return
+	}
+	var file multipart.File
+	if file, err = fh.Open(); err != nil {
+		return
+	}
+	defer file.Close()
+	var head []byte
+	if head, err = readHeadN(file, r.GetReadLimit()); err != nil {
+		return
+	}
+
+	detected := r.withDetectedCharset(r.detect(head), head)
+	byExtension, _ := r.FromPathChecked(fh.Filename)
+	declared := fh.Header.Get("Content-Type")
+	if PruneCharset(declared) == BinaryMimeType {
+		declared = ""
+	}
+
+	mime = r.output(detected)
+	switch PruneCharset(detected) {
+	case TextMimeType, BinaryMimeType:
+		if byExtension != "" && !r.typeMismatch(declared, byExtension) {
+			mime = byExtension
+		}
+	}
+
+	mismatch := &MismatchError{Filename: fh.Filename, Detected: mime}
+	if r.typeMismatch(declared, mime) {
+		mismatch.Declared = declared
+	}
+	if r.typeMismatch(byExtension, mime) {
+		mismatch.ByExtension = byExtension
+	}
+	if mismatch.Declared != "" || mismatch.ByExtension != "" {
+		r.logWarn("mime: upload content mismatch", "filename", fh.Filename, "declared", mismatch.Declared, "extension", mismatch.ByExtension, "detected", mime)
+		err = mismatch
+	}
+	return
+}
+
+// typeMismatch is mismatched with registered aliases resolved
+func (r *Registry) typeMismatch(claimed, detected string) bool {
+	return r.mismatched(r.Canonical(claimed), r.Canonical(detected))
+}
+
+// FromFileHeader detects the mime type of the given multipart upload using
+// the default Registry, see Registry.FromFileHeader
+func FromFileHeader(fh *multipart.FileHeader) (mime string, err error) {
+	return gRegistry.FromFileHeader(fh)
+}
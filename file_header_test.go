@@ -0,0 +1,80 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFromFileHeader(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	upload := func(filename, contentType string, data []byte) (fh *multipart.FileHeader) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		part, _ := mw.CreatePart(header)
+		_, _ = part.Write(data)
+		_ = mw.Close()
+		form, err := multipart.NewReader(&body, mw.Boundary()).ReadForm(1 << 20)
+		So(err, ShouldBeNil)
+		return form.File["file"][0]
+	}
+
+	Convey("FromFileHeader", t, func() {
+		mime, err := FromFileHeader(upload("logo.png", "image/png", png))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+
+		mime, err = FromFileHeader(upload("logo.png", BinaryMimeType, png))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "image/png")
+
+		mime, err = FromFileHeader(upload("report.pdf", "application/pdf", png))
+		So(mime, ShouldEqual, "image/png")
+		So(errors.Is(err, ErrTypeMismatch), ShouldBeTrue)
+		var mismatch *MismatchError
+		So(errors.As(err, &mismatch), ShouldBeTrue)
+		So(mismatch.Filename, ShouldEqual, "report.pdf")
+		So(mismatch.Declared, ShouldEqual, "application/pdf")
+		So(mismatch.ByExtension, ShouldEqual, "application/pdf")
+		So(err.Error(), ShouldEqual, `"report.pdf" detected as image/png, declared as application/pdf, named as application/pdf`)
+
+		mime, err = FromFileHeader(upload("logo.png", "application/pdf", png))
+		So(mime, ShouldEqual, "image/png")
+		So(errors.As(err, &mismatch), ShouldBeTrue)
+		So(mismatch.ByExtension, ShouldEqual, "")
+
+		r := New()
+		r.SetExtension("data", "application/x-data")
+		mime, err = r.FromFileHeader(upload("values.data", "", []byte{0x00, 0x01, 0x02, 0x03}))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/x-data")
+
+		_, err = FromFileHeader(nil)
+		So(err, ShouldNotBeNil)
+	})
+}
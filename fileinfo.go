@@ -0,0 +1,153 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"io/fs"
+	"os"
+)
+
+// ModeMime returns the inode/* mime type for the file type bits of the given
+// `mode`, or an empty string for regular files
+func ModeMime(mode fs.FileMode) (mime string) {
+	switch {
+	case mode&fs.ModeDir != 0:
+		return DirectoryMimeType
+	case mode&fs.ModeSymlink != 0:
+		return SymlinkMimeType
+	case mode&fs.ModeNamedPipe != 0:
+		return FifoMimeType
+	case mode&fs.ModeSocket != 0:
+		return SocketMimeType
+	case mode&fs.ModeDevice != 0 && mode&fs.ModeCharDevice != 0:
+		return CharDeviceMimeType
+	case mode&fs.ModeDevice != 0:
+		return BlockDeviceMimeType
+	}
+	return
+}
+
+// MimeFromInfo classifies the given fs.FileInfo without any filesystem
+// access: directories, symlinks and special files are reported with their
+// inode/* mime types from the mode bits and regular files are classified
+// with FromPathOnly using the name
+func (r *Registry) MimeFromInfo(fi fs.FileInfo) (mime string) {
+	if fi == nil {
+		return
+	} else if mime = ModeMime(fi.Mode()); mime != "" {
+		return
+	}
+	return r.FromPathOnly(fi.Name())
+}
+
+// MimeFromDirEntry is the fs.DirEntry version of MimeFromInfo, which does
+// not call fs.DirEntry.Info
+func (r *Registry) MimeFromDirEntry(de fs.DirEntry) (mime string) {
+	if de == nil {
+		return
+	} else if mime = ModeMime(de.Type()); mime != "" {
+		return
+	}
+	return r.FromPathOnly(de.Name())
+}
+
+// SetFollowSymlinks configures whether Mime follows symbolic links, which is
+// the default, or reports them as SymlinkMimeType without following them
+func (r *Registry) SetFollowSymlinks(follow bool) {
+	r.noFollowSymlinks.Store(!follow)
+}
+
+// GetFollowSymlinks returns true if Mime follows symbolic links
+func (r *Registry) GetFollowSymlinks() (follow bool) {
+	return !r.noFollowSymlinks.Load()
+}
+
+// SetSpecialFiles configures whether Mime reports named pipes, sockets and
+// device nodes with their inode/* mime types, such as FifoMimeType, as
+// defined by the freedesktop.org shared-mime-info database. Special files
+// are not reported by default and Mime returns an empty string for them,
+// without opening them as reading a named pipe may block indefinitely
+func (r *Registry) SetSpecialFiles(enabled bool) {
+	r.specialFiles.Store(enabled)
+}
+
+// GetSpecialFiles returns true if Mime reports special files
+func (r *Registry) GetSpecialFiles() (enabled bool) {
+	return r.specialFiles.Load()
+}
+
+// SetFollowSymlinks configures symbolic link handling of the default
+// Registry, see Registry.SetFollowSymlinks
+func SetFollowSymlinks(follow bool) {
+	gRegistry.SetFollowSymlinks(follow)
+}
+
+// GetFollowSymlinks returns true if the default Registry follows symbolic
+// links
+func GetFollowSymlinks() (follow bool) {
+	return gRegistry.GetFollowSymlinks()
+}
+
+// SetSpecialFiles configures special file handling of the default Registry,
+// see Registry.SetSpecialFiles
+func SetSpecialFiles(enabled bool) {
+	gRegistry.SetSpecialFiles(enabled)
+}
+
+// GetSpecialFiles returns true if the default Registry reports special files
+func GetSpecialFiles() (enabled bool) {
+	return gRegistry.GetSpecialFiles()
+}
+
+// inodeMime returns the SymlinkMimeType of unfollowed symbolic links and,
+// when enabled, the inode/* mime types of special files, according to the
+// SetFollowSymlinks and SetSpecialFiles settings
+func (r *Registry) inodeMime(path string) (mime string, ok bool) {
+	follow, special := r.GetFollowSymlinks(), r.GetSpecialFiles()
+	if follow && !special {
+		return
+	}
+	var info os.FileInfo
+	var err error
+	if follow {
+		info, err = os.Stat(path)
+	} else {
+		info, err = os.Lstat(path)
+	}
+	if err != nil {
+		return
+	}
+	switch mime = ModeMime(info.Mode()); {
+	case mime == SymlinkMimeType:
+		ok = true
+	case mime != "" && mime != DirectoryMimeType && special:
+		ok = true
+	default:
+		mime = ""
+	}
+	return
+}
+
+// MimeFromInfo classifies the given fs.FileInfo using the default Registry,
+// see Registry.MimeFromInfo
+func MimeFromInfo(fi fs.FileInfo) (mime string) {
+	return gRegistry.MimeFromInfo(fi)
+}
+
+// MimeFromDirEntry classifies the given fs.DirEntry using the default
+// Registry, see Registry.MimeFromDirEntry
+func MimeFromDirEntry(de fs.DirEntry) (mime string) {
+	return gRegistry.MimeFromDirEntry(de)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMimeFromInfo(t *testing.T) {
+	Convey("ModeMime", t, func() {
+		So(ModeMime(0644), ShouldBeEmpty)
+		So(ModeMime(fs.ModeDir|0755), ShouldEqual, DirectoryMimeType)
+		So(ModeMime(fs.ModeSymlink), ShouldEqual, SymlinkMimeType)
+		So(ModeMime(fs.ModeNamedPipe), ShouldEqual, FifoMimeType)
+		So(ModeMime(fs.ModeSocket), ShouldEqual, SocketMimeType)
+		So(ModeMime(fs.ModeDevice), ShouldEqual, BlockDeviceMimeType)
+		So(ModeMime(fs.ModeDevice|fs.ModeCharDevice), ShouldEqual, CharDeviceMimeType)
+	})
+
+	Convey("MimeFromInfo", t, func() {
+		So(MimeFromInfo(nil), ShouldBeEmpty)
+		info, err := os.Stat("./testdata")
+		So(err, ShouldBeNil)
+		So(MimeFromInfo(info), ShouldEqual, DirectoryMimeType)
+		info, err = os.Stat("./testdata/README.md")
+		So(err, ShouldBeNil)
+		So(MimeFromInfo(info), ShouldEqual, "text/markdown; charset=utf-8")
+		info, err = os.Stat("./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(MimeFromInfo(info), ShouldBeEmpty)
+	})
+
+	Convey("MimeFromDirEntry", t, func() {
+		So(MimeFromDirEntry(nil), ShouldBeEmpty)
+		fsys := fstest.MapFS{
+			"dir/page.html": {Data: []byte("<html></html>")},
+			"link":          {Mode: fs.ModeSymlink},
+		}
+		entries, err := fs.ReadDir(fsys, ".")
+		So(err, ShouldBeNil)
+		So(entries, ShouldHaveLength, 2)
+		So(MimeFromDirEntry(entries[0]), ShouldEqual, DirectoryMimeType)
+		So(MimeFromDirEntry(entries[1]), ShouldEqual, SymlinkMimeType)
+		entries, err = fs.ReadDir(fsys, "dir")
+		So(err, ShouldBeNil)
+		So(MimeFromDirEntry(entries[0]), ShouldEqual, "text/html; charset=utf-8")
+	})
+}
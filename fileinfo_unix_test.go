@@ -0,0 +1,85 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecialFiles(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	dir := t.TempDir()
+	target := filepath.Join(dir, "logo.png")
+	link := filepath.Join(dir, "link.png")
+	dangling := filepath.Join(dir, "dangling")
+	fifo := filepath.Join(dir, "fifo")
+	if err := os.WriteFile(target, png, 0644); err != nil {
+		t.Fatal(err)
+	} else if err = os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	} else if err = os.Symlink(filepath.Join(dir, "missing"), dangling); err != nil {
+		t.Fatal(err)
+	} else if err = syscall.Mkfifo(fifo, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("symlinks", t, func() {
+		r := New()
+		So(r.GetFollowSymlinks(), ShouldBeTrue)
+		So(r.Mime(link), ShouldEqual, "image/png")
+		So(r.Mime(dangling), ShouldBeEmpty)
+
+		r.SetFollowSymlinks(false)
+		So(r.GetFollowSymlinks(), ShouldBeFalse)
+		So(r.Mime(link), ShouldEqual, SymlinkMimeType)
+		So(r.Mime(dangling), ShouldEqual, SymlinkMimeType)
+		So(r.Mime(target), ShouldEqual, "image/png")
+		mime, err := r.MimeE(link)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, SymlinkMimeType)
+		result, err := r.Detect(link)
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: SymlinkMimeType, Confidence: ConfidenceCertain, Source: SourceInode})
+		d, err := r.Describe(dangling)
+		So(err, ShouldBeNil)
+		So(d.Source, ShouldEqual, SourceInode)
+		So(r.MimeWith(link, ContentFirst()), ShouldEqual, SymlinkMimeType)
+	})
+
+	Convey("special files", t, func() {
+		r := New()
+		So(r.GetSpecialFiles(), ShouldBeFalse)
+		So(r.Mime(fifo), ShouldBeEmpty)
+
+		r.SetSpecialFiles(true)
+		So(r.Mime(fifo), ShouldEqual, FifoMimeType)
+		So(r.Mime(dir), ShouldEqual, DirectoryMimeType)
+		So(r.Mime(link), ShouldEqual, "image/png")
+		if _, err := os.Stat("/dev/null"); err == nil {
+			So(r.Mime("/dev/null"), ShouldEqual, CharDeviceMimeType)
+		}
+
+		r.Reset()
+		So(r.GetSpecialFiles(), ShouldBeFalse)
+		So(r.GetFollowSymlinks(), ShouldBeTrue)
+	})
+}
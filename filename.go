@@ -0,0 +1,119 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	// DefaultFilename is the name SuggestFilename uses when the given base
+	// name is empty after sanitizing
+	DefaultFilename = "download"
+	// MaxFilenameLength is the maximum length, in bytes, of the names
+	// returned by SuggestFilename
+	MaxFilenameLength = 255
+)
+
+// SuggestFilename returns a download filename for content of the given
+// `mime` type, based on the given `base` name. Any directory portion of
+// `base` is removed, unsafe characters are replaced with underscores, names
+// reserved by Windows are prefixed with an underscore and the preferred
+// extension for the `mime` type is appended, unless `base` already has an
+// extension for the same type (avoiding names like "report.pdf.pdf")
+func (r *Registry) SuggestFilename(base, mime string) (filename string) {
+	filename = sanitizeFilename(base)
+	extension, ok := r.preferredExtension(mime)
+	if !ok {
+		return truncateFilename(filename, "")
+	}
+	if current := r.FromPathOnly(filename); current != "" && r.Key(PruneCharset(current)) == r.Key(PruneCharset(mime)) {
+		return truncateFilename(filename, "")
+	}
+	return truncateFilename(filename, "."+extension)
+}
+
+// SuggestFilename returns a download filename using the default Registry,
+// see Registry.SuggestFilename
+func SuggestFilename(base, mime string) (filename string) {
+	return gRegistry.SuggestFilename(base, mime)
+}
+
+// preferredExtension returns the extension to use for the given `mime`
+// type, checking the SetPreferredExtension values first, then the registered
+// extensions (shortest and then alphabetically first), then
+// github.com/gabriel-vasile/mimetype and then mime.ExtensionsByType
+func (r *Registry) preferredExtension(mime string) (extension string, ok bool) {
+	mediatype := r.Key(PruneCharset(mime))
+	if mediatype == "" {
+		return
+	} else if extension, ok = r.preferred.get(mediatype); ok {
+		return
+	}
+	if found := r.registeredExtensions(mediatype); len(found) > 0 {
+		return found[0], true
+	}
+	if mt := mimetype.Lookup(mediatype); mt != nil && mt.Extension() != "" {
+		return strings.TrimPrefix(mt.Extension(), "."), true
+	}
+	if list, err := goMime.ExtensionsByType(mediatype); err == nil && len(list) > 0 {
+		return strings.TrimPrefix(list[0], "."), true
+	}
+	return
+}
+
+// sanitizeFilename removes any directory portion of `name` and replaces
+// characters that are unsafe in filenames
+func sanitizeFilename(name string) (clean string) {
+	if idx := strings.LastIndexAny(name, `/\`); idx >= 0 {
+		name = name[idx+1:]
+	}
+	clean = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError || strings.ContainsRune(`<>:"|?*`, r) {
+			return '_'
+		} else if _, flagged := gPathFlagRunes[r]; flagged {
+			// see CheckPath
+			return '_'
+		}
+		return r
+	}, name)
+	if clean = strings.Trim(clean, ". "); clean == "" {
+		return DefaultFilename
+	}
+	stem, _, _ := strings.Cut(clean, ".")
+	switch upper := strings.ToUpper(strings.TrimSpace(stem)); {
+	case upper == "CON", upper == "PRN", upper == "AUX", upper == "NUL":
+		clean = "_" + clean
+	case len(upper) == 4 && (strings.HasPrefix(upper, "COM") || strings.HasPrefix(upper, "LPT")) && upper[3] >= '1' && upper[3] <= '9':
+		clean = "_" + clean
+	}
+	return
+}
+
+// truncateFilename appends the `extension` to the `name`, shortening the
+// name at a rune boundary to keep the total within MaxFilenameLength
+func truncateFilename(name, extension string) (filename string) {
+	limit := MaxFilenameLength - len(extension)
+	for len(name) > limit {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name + extension
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSuggestFilename(t *testing.T) {
+	Convey("SuggestFilename", t, func() {
+		So(SuggestFilename("report", "application/pdf"), ShouldEqual, "report.pdf")
+		So(SuggestFilename("report.pdf", "application/pdf"), ShouldEqual, "report.pdf")
+		So(SuggestFilename("report.PDF", "application/pdf"), ShouldEqual, "report.PDF")
+		So(SuggestFilename("photo.jpeg", "image/jpeg"), ShouldEqual, "photo.jpeg")
+		So(SuggestFilename("photo", "image/jpeg"), ShouldEqual, "photo.jpg")
+		So(SuggestFilename("notes.txt", "text/html; charset=utf-8"), ShouldEqual, "notes.txt.html")
+		So(SuggestFilename("page", HtmlMimeType), ShouldEqual, "page.html")
+		So(SuggestFilename("data", "nope/nope"), ShouldEqual, "data")
+
+		Convey("sanitizing", func() {
+			So(SuggestFilename("../../etc/passwd", TextMimeType), ShouldEqual, "passwd.txt")
+			So(SuggestFilename(`C:\Users\someone\a<b>:c?.txt`, TextMimeType), ShouldEqual, "a_b__c_.txt")
+			So(SuggestFilename("invoice\u202etxt.exe", "application/pdf"), ShouldEqual, "invoice_txt.exe.pdf")
+			So(SuggestFilename("line\nbreak", TextMimeType), ShouldEqual, "line_break.txt")
+			So(SuggestFilename(" ... ", "application/pdf"), ShouldEqual, "download.pdf")
+			So(SuggestFilename("", ""), ShouldEqual, "download")
+			So(SuggestFilename("CON", TextMimeType), ShouldEqual, "_CON.txt")
+			So(SuggestFilename("lpt1.log", TextMimeType), ShouldEqual, "_lpt1.log.txt")
+			So(SuggestFilename("COM10", TextMimeType), ShouldEqual, "COM10.txt")
+		})
+
+		Convey("length limit", func() {
+			name := SuggestFilename(strings.Repeat("é", 200), "application/pdf")
+			So(len(name), ShouldBeLessThanOrEqualTo, MaxFilenameLength)
+			So(name, ShouldEndWith, "é.pdf")
+		})
+	})
+}
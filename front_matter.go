@@ -0,0 +1,130 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OrgModeDetector returns true if the first line of the given `raw` content
+// that is not blank, after any front-matter block, is an org-mode
+// "#+KEYWORD:" line, such as "#+TITLE:".
+// Headlines are not considered as they are indistinguishable from markdown
+// lists. OrgModeDetector is the content detector of OrgModeMimeType
+func OrgModeDetector(raw []byte, limit uint32) bool {
+	if limit > 0 && len(raw) > int(limit) {
+		raw = raw[:limit]
+	}
+	if body, found := splitFrontMatter(raw); found {
+		raw = body
+	}
+	_, size := DetectBOM(raw)
+	raw = bytes.TrimLeft(raw[size:], " \t\r\n")
+	line, _, _ := bytes.Cut(raw, []byte("\n"))
+	if keyword, found := bytes.CutPrefix(line, []byte("#+")); found {
+		name, _, colon := bytes.Cut(keyword, []byte(":"))
+		return colon && len(name) > 0 && isConfigKey(name, false)
+	}
+	return false
+}
+
+// MarkdownDetector returns true if any line of the given `raw` content is a
+// markdown ATX heading, such as "## Heading", or opens a fenced code block.
+// MarkdownDetector is the content detector of MarkdownMimeType
+func MarkdownDetector(raw []byte, limit uint32) bool {
+	if limit > 0 && len(raw) > int(limit) {
+		raw = raw[:limit]
+	}
+	_, size := DetectBOM(raw)
+	for _, line := range bytes.Split(raw[size:], []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if bytes.HasPrefix(line, []byte("```")) || bytes.HasPrefix(line, []byte("~~~")) || isMarkdownHeading(string(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFrontMatter returns the `body` following a YAML ("---"), TOML ("+++")
+// or JSON front-matter block at the start of the `head` content, as used by
+// Go-Enjin pages. The `head` is not considered to have front-matter when the
+// block is not terminated, when nothing follows it or when what follows is
+// more of the same format, such as a multi-document YAML stream or NDJSON
+func splitFrontMatter(head []byte) (body []byte, ok bool) {
+	_, size := DetectBOM(head)
+	head = head[size:]
+	switch {
+	case hasFenceLine(head, "---"):
+		if body, ok = fencedBody(head, "---", "..."); ok {
+			// a multi-document yaml stream is not front-matter, a body of
+			// only "#" lines is taken as markdown headings and not comments
+			lines := significantLines(body, 0)
+			if len(lines) == 0 {
+				break
+			}
+			for _, line := range lines {
+				if !isYamlLine(line) {
+					return body, true
+				}
+			}
+			return nil, false
+		}
+	case hasFenceLine(head, "+++"):
+		body, ok = fencedBody(head, "+++")
+	case len(head) > 0 && head[0] == '{':
+		decoder := json.NewDecoder(bytes.NewReader(head))
+		var matter json.RawMessage
+		if err := decoder.Decode(&matter); err == nil {
+			rest := head[decoder.InputOffset():]
+			if trimmed := bytes.TrimLeft(rest, " \t\r"); len(trimmed) > 0 && trimmed[0] == '\n' {
+				body = bytes.TrimLeft(trimmed, " \t\r\n")
+				ok = len(body) > 0 && body[0] != '{' && body[0] != '['
+			}
+		}
+	}
+	if ok && len(bytes.TrimSpace(body)) == 0 {
+		return nil, false
+	}
+	return
+}
+
+// hasFenceLine returns true if `data` starts with a line consisting of the
+// `fence` alone
+func hasFenceLine(data []byte, fence string) bool {
+	line, _, found := bytes.Cut(data, []byte("\n"))
+	return found && string(bytes.TrimRight(line, " \t\r")) == fence
+}
+
+// fencedBody returns the content following the first line after the opening
+// fence line of `data` that consists of one of the `closing` fences alone
+func fencedBody(data []byte, closing ...string) (body []byte, ok bool) {
+	_, rest, _ := bytes.Cut(data, []byte("\n"))
+	for len(rest) > 0 {
+		line, next, found := bytes.Cut(rest, []byte("\n"))
+		if !found {
+			// the closing fence may have been truncated
+			return
+		}
+		trimmed := string(bytes.TrimRight(line, " \t\r"))
+		for _, fence := range closing {
+			if trimmed == fence {
+				return next, true
+			}
+		}
+		rest = next
+	}
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFrontMatter(t *testing.T) {
+	Convey("splitFrontMatter", t, func() {
+		for _, check := range []struct {
+			data string
+			body string
+		}{
+			{"---\ntitle: Home\n---\n# Welcome\n", "# Welcome\n"},
+			{"\xef\xbb\xbf---\r\ntitle: Home\r\n...\r\nbody text\r\n", "body text\r\n"},
+			{"+++\ntitle = \"Home\"\n+++\n#+TITLE: Home\n", "#+TITLE: Home\n"},
+			{"{\n  \"title\": \"Home\"\n}\n\n<p>body</p>\n", "<p>body</p>\n"},
+		} {
+			body, ok := splitFrontMatter([]byte(check.data))
+			So(ok, ShouldBeTrue)
+			So(string(body), ShouldEqual, check.body)
+		}
+		for _, data := range []string{
+			"",
+			"---\ntitle: Home\n",
+			"---\ntitle: Home\n---\n",
+			"---\na: 1\n---\nb: 2\n",
+			"+++\ntitle = \"x\"\n",
+			"{\"a\": 1}\n{\"a\": 2}\n",
+			"{\"a\": 1} trailing",
+			"# just markdown\n",
+		} {
+			_, ok := splitFrontMatter([]byte(data))
+			So(ok, ShouldBeFalse)
+		}
+	})
+
+	Convey("OrgModeDetector", t, func() {
+		So(OrgModeDetector([]byte("\n#+TITLE: Home\n* Heading\n"), 0), ShouldBeTrue)
+		So(OrgModeDetector([]byte("* item\n* item\n"), 0), ShouldBeFalse)
+		So(OrgModeDetector([]byte("#+ not a keyword\n"), 0), ShouldBeFalse)
+		So(OrgModeDetector([]byte("---\ntitle: Home\n---\n#+TITLE: Home\n"), 0), ShouldBeTrue)
+	})
+
+	Convey("MarkdownDetector", t, func() {
+		So(MarkdownDetector([]byte("Intro\n\n## Heading\n"), 0), ShouldBeTrue)
+		So(MarkdownDetector([]byte("```go\npackage main\n```\n"), 0), ShouldBeTrue)
+		So(MarkdownDetector([]byte("plain text\n#hashtag\n"), 0), ShouldBeFalse)
+		So(MarkdownDetector([]byte("plain text\n# Heading\n"), 11), ShouldBeFalse)
+	})
+
+	Convey("detection", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		yamlPage := []byte("---\ntitle: Home\nlayout: page\n---\n#+TITLE: Home\n\n* Heading\n")
+		So(r.DetectBytes(yamlPage), ShouldEqual, OrgModeMimeType)
+		tomlPage := []byte("+++\ntitle = \"Home\"\nlayout = \"page\"\n+++\n#+TITLE: Home\n")
+		So(r.DetectBytes(tomlPage), ShouldEqual, OrgModeMimeType)
+		markdown := []byte("---\ntitle: Home\nlayout: page\n---\n# Welcome\n\nSome text.\n")
+		So(r.DetectBytes(markdown), ShouldNotEqual, YamlMimeType)
+		So(r.DetectBytes([]byte("---\na: 1\n---\nb: 2\nc: 3\n")), ShouldEqual, YamlMimeType)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+)
+
+// GenerateGo writes a Go source file for the package named `pkg` to `w`,
+// containing a NewRegistry function which re-creates the current extension,
+// charset, alias, filename and glob registrations of the Registry. The generated code starts
+// from New and applies only the differences between New and the Registry, so
+// that runtime tuning built from OS data and config files can be captured and
+// embedded for hermetic builds
+func (r *Registry) GenerateGo(w io.Writer, pkg string) (err error) {
+	if !token.IsIdentifier(pkg) {
+		err = fmt.Errorf("invalid package name: %q", pkg)
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by github.com/go-corelibs/mime; DO NOT EDIT.\n\n")
+	buf.WriteString("package " + pkg + "\n\n")
+	buf.WriteString("import \"github.com/go-corelibs/mime\"\n\n")
+	buf.WriteString("// NewRegistry returns a new mime.Registry with the generated registrations\n")
+	buf.WriteString("func NewRegistry() (r *mime.Registry) {\n")
+	buf.WriteString("r = mime.New()\n")
+	for _, e := range Diff(New(), r) {
+		var method string
+		switch e.Table {
+		case ExtensionTable:
+			method = "SetExtension"
+		case CharsetTable:
+			method = "SetCharset"
+		case AliasTable:
+			method = "SetAlias"
+		case FilenameTable:
+			method = "SetFilename"
+		case GlobTable:
+			method = "SetGlob"
+		}
+		// a removed mapping has an empty After value, which clears it
+		if e.Table == GlobTable {
+			// patterns were validated when registered
+			buf.WriteString("_ = ")
+		}
+		buf.WriteString(fmt.Sprintf("r.%s(%q, %q)\n", method, e.Key, e.After))
+	}
+	buf.WriteString("return\n}\n")
+
+	var src []byte
+	if src, err = format.Source(buf.Bytes()); err != nil {
+		return
+	}
+	_, err = w.Write(src)
+	return
+}
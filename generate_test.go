@@ -0,0 +1,49 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateGo(t *testing.T) {
+	Convey("GenerateGo", t, func() {
+		r := New()
+		r.SetExtension("txt", "")
+		r.SetExtension("webmanifest", "application/manifest+json")
+		r.SetCharset("text/x-custom", "iso-8859-1")
+		r.SetAlias("text/x-md", MarkdownMimeType)
+
+		var buf strings.Builder
+		So(r.GenerateGo(&buf, "mimedata"), ShouldBeNil)
+		src := buf.String()
+		So(src, ShouldStartWith, "// Code generated by github.com/go-corelibs/mime; DO NOT EDIT.\n")
+		So(src, ShouldContainSubstring, "package mimedata\n")
+		So(src, ShouldContainSubstring, "\tr = mime.New()\n")
+		So(src, ShouldContainSubstring, `r.SetExtension("txt", "")`)
+		So(src, ShouldContainSubstring, `r.SetExtension("webmanifest", "application/manifest+json")`)
+		So(src, ShouldContainSubstring, `r.SetCharset("text/x-custom", "iso-8859-1")`)
+		So(src, ShouldContainSubstring, `r.SetAlias("text/x-md", "text/markdown")`)
+
+		buf.Reset()
+		So(New().GenerateGo(&buf, "mimedata"), ShouldBeNil)
+		So(buf.String(), ShouldNotContainSubstring, "r.Set")
+
+		So(r.GenerateGo(&buf, "not a package"), ShouldNotBeNil)
+	})
+}
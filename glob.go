@@ -0,0 +1,86 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"path"
+	"strings"
+)
+
+// SetGlob registers the given path.Match `pattern` with the given mime type
+// string, for naming conventions that a single extension cannot express,
+// such as "*rc", "Jenkinsfile*" or "CMakeLists.txt". Patterns without a
+// slash are matched against the base name of a path and patterns with one
+// are matched against the whole path. When more than one pattern matches,
+// the longest pattern wins. If `mime` is empty, the pattern is removed.
+// SetGlob returns path.ErrBadPattern if the `pattern` is malformed
+func (r *Registry) SetGlob(pattern, mime string) (err error) {
+	defer r.logRegistration(mime, &err)
+	if _, err = path.Match(pattern, ""); err != nil {
+		return
+	} else if pattern == "" {
+		return path.ErrBadPattern
+	}
+	pattern = r.normalizePath(pattern)
+	if mime == "" {
+		r.globs.unset(pattern)
+		return
+	}
+	r.globs.set(pattern, mime)
+	return
+}
+
+// GetGlob returns the mime type registered for the exact `pattern` using
+// SetGlob, use FromPathOnly to match a path against all patterns
+func (r *Registry) GetGlob(pattern string) (mime string, ok bool) {
+	if mime, ok = r.globs.get(r.normalizePath(pattern)); ok {
+		mime = r.output(mime)
+	}
+	return
+}
+
+// SetGlob registers the given `pattern` with the given mime type string in
+// the default Registry, see Registry.SetGlob for details
+func SetGlob(pattern, mime string) (err error) {
+	return gRegistry.SetGlob(pattern, mime)
+}
+
+// GetGlob returns the mime type registered for the exact `pattern` in the
+// default Registry
+func GetGlob(pattern string) (mime string, ok bool) {
+	return gRegistry.GetGlob(pattern)
+}
+
+// fromGlobs returns the mime type of the longest glob pattern matching the
+// already normalized `full` path, breaking ties by lexical order
+func (r *Registry) fromGlobs(full string) (mime string, ok bool) {
+	base := r.baseName(full)
+	var best string
+	for pattern, value := range r.globs.load() {
+		subject := base
+		if strings.Contains(pattern, "/") {
+			subject = full
+		}
+		if len(pattern) < len(best) || (ok && len(pattern) == len(best) && pattern > best) {
+			continue
+		} else if matched, _ := path.Match(pattern, subject); matched {
+			best, mime, ok = pattern, value, true
+		}
+	}
+	if ok {
+		mime = r.output(mime)
+	}
+	return
+}
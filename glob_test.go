@@ -0,0 +1,74 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGlobs(t *testing.T) {
+	Convey("SetGlob", t, func() {
+		r := New()
+		So(r.SetGlob("[", "text/plain"), ShouldEqual, path.ErrBadPattern)
+		So(r.SetGlob("", "text/plain"), ShouldEqual, path.ErrBadPattern)
+
+		So(r.SetGlob("*.conf", "text/x-config"), ShouldBeNil)
+		So(r.SetGlob("*rc", "text/x-rc"), ShouldBeNil)
+		So(r.SetGlob("CMakeLists.txt", "text/x-cmake"), ShouldBeNil)
+		So(r.SetGlob("Jenkinsfile*", "text/x-groovy"), ShouldBeNil)
+		So(r.SetGlob("etc/*.d/*", "text/x-dropin"), ShouldBeNil)
+
+		So(r.FromPathOnly("/etc/nginx.conf"), ShouldEqual, "text/x-config")
+		So(r.FromPathOnly("home/.bashrc"), ShouldEqual, "text/x-rc")
+		So(r.FromPathOnly("src/CMakeLists.txt"), ShouldEqual, "text/x-cmake")
+		So(r.FromPathOnly("notes.txt"), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.FromPathOnly("Jenkinsfile.release"), ShouldEqual, "text/x-groovy")
+		So(r.FromPathOnly("etc/sudoers.d/custom"), ShouldEqual, "text/x-dropin")
+		So(r.FromPathOnly("other/sudoers.d/custom"), ShouldEqual, "")
+
+		// the longest pattern wins
+		So(r.SetGlob("*.vimrc", "text/x-vim"), ShouldBeNil)
+		So(r.FromPathOnly(".vimrc"), ShouldEqual, "text/x-vim")
+		// well-known filenames are checked first
+		So(r.SetGlob("Make*", "text/x-other"), ShouldBeNil)
+		So(r.FromPathOnly("Makefile"), ShouldEqual, "text/x-makefile; charset=utf-8")
+
+		mime, ok := r.GetGlob("*rc")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "text/x-rc")
+		So(r.SetGlob("*rc", ""), ShouldBeNil)
+		_, ok = r.GetGlob("*rc")
+		So(ok, ShouldBeFalse)
+		So(r.FromPathOnly(".bashrc"), ShouldEqual, "")
+
+		var buf bytes.Buffer
+		So(r.GenerateGo(&buf, "mimes"), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `_ = r.SetGlob("*.conf", "text/x-config")`)
+
+		So(r.Validate(), ShouldBeNil)
+		r.globs.set("*.bad", "not a type")
+		So(r.Validate(), ShouldNotBeNil)
+
+		So(SetGlob("*.globtest", "text/x-globtest"), ShouldBeNil)
+		So(FromPathOnly("a.globtest"), ShouldEqual, "text/x-globtest")
+		So(SetGlob("*.globtest", ""), ShouldBeNil)
+		_, ok = GetGlob("*.globtest")
+		So(ok, ShouldBeFalse)
+	})
+}
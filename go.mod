@@ -6,6 +6,8 @@ require (
 	github.com/gabriel-vasile/mimetype v1.4.3
 	github.com/go-corelibs/path v1.2.0
 	github.com/smartystreets/goconvey v1.8.1
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,398 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// gKnownTypes are the mime types supported by github.com/gabriel-vasile/mimetype
+// v1.4.3, as listed in its supported_mimes.md file. The mimetype package does
+// not expose the children of a type, so Descendants searches these along with
+// all the types registered with this package
+var gKnownTypes = []string{
+	"application/atom+xml",
+	"application/dicom",
+	"application/epub+zip",
+	"application/fits",
+	"application/geo+json",
+	"application/gml+xml",
+	"application/gpx+xml",
+	"application/gzip",
+	"application/jar",
+	"application/javascript",
+	"application/json",
+	"application/lzip",
+	"application/marc",
+	"application/msword",
+	"application/octet-stream",
+	"application/ogg",
+	"application/owl+xml",
+	"application/pdf",
+	"application/pkcs7-signature",
+	"application/postscript",
+	"application/rss+xml",
+	"application/tzif",
+	"application/vnd.adobe.xfdf",
+	"application/vnd.apple.mpegurl",
+	"application/vnd.debian.binary-package",
+	"application/vnd.fdf",
+	"application/vnd.garmin.tcx+xml",
+	"application/vnd.google-earth.kml+xml",
+	"application/vnd.microsoft.portable-executable",
+	"application/vnd.ms-cab-compressed",
+	"application/vnd.ms-excel",
+	"application/vnd.ms-fontobject",
+	"application/vnd.ms-outlook",
+	"application/vnd.ms-package.3dmanufacturing-3dmodel+xml",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.ms-publisher",
+	"application/vnd.nintendo.snes.rom",
+	"application/vnd.oasis.opendocument.chart",
+	"application/vnd.oasis.opendocument.formula",
+	"application/vnd.oasis.opendocument.graphics",
+	"application/vnd.oasis.opendocument.graphics-template",
+	"application/vnd.oasis.opendocument.presentation",
+	"application/vnd.oasis.opendocument.presentation-template",
+	"application/vnd.oasis.opendocument.spreadsheet",
+	"application/vnd.oasis.opendocument.spreadsheet-template",
+	"application/vnd.oasis.opendocument.text",
+	"application/vnd.oasis.opendocument.text-template",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.rn-realmedia-vbr",
+	"application/vnd.shp",
+	"application/vnd.shx",
+	"application/vnd.sqlite3",
+	"application/vnd.sun.xml.calc",
+	"application/warc",
+	"application/wasm",
+	"application/x-7z-compressed",
+	"application/x-amf",
+	"application/x-archive",
+	"application/x-bittorrent",
+	"application/x-bzip2",
+	"application/x-chrome-extension",
+	"application/x-coredump",
+	"application/x-cpio",
+	"application/x-dbf",
+	"application/x-elf",
+	"application/x-executable",
+	"application/x-installshield",
+	"application/x-java-applet",
+	"application/x-mach-binary",
+	"application/x-mobipocket-ebook",
+	"application/x-ms-installer",
+	"application/x-ms-reader",
+	"application/x-ms-shortcut",
+	"application/x-msaccess",
+	"application/x-ndjson",
+	"application/x-object",
+	"application/x-ole-storage",
+	"application/x-rar-compressed",
+	"application/x-rpm",
+	"application/x-sharedlib",
+	"application/x-shockwave-flash",
+	"application/x-subrip",
+	"application/x-tar",
+	"application/x-xar",
+	"application/x-xliff+xml",
+	"application/x-xz",
+	"application/zip",
+	"application/zstd",
+	"audio/aac",
+	"audio/aiff",
+	"audio/amr",
+	"audio/ape",
+	"audio/basic",
+	"audio/flac",
+	"audio/midi",
+	"audio/mp4",
+	"audio/mpeg",
+	"audio/musepack",
+	"audio/ogg",
+	"audio/qcelp",
+	"audio/wav",
+	"audio/x-m4a",
+	"audio/x-unknown",
+	"font/collection",
+	"font/otf",
+	"font/ttf",
+	"font/woff",
+	"font/woff2",
+	"image/avif",
+	"image/bmp",
+	"image/bpg",
+	"image/gif",
+	"image/heic",
+	"image/heic-sequence",
+	"image/heif",
+	"image/heif-sequence",
+	"image/jp2",
+	"image/jpeg",
+	"image/jpm",
+	"image/jpx",
+	"image/jxl",
+	"image/jxr",
+	"image/jxs",
+	"image/png",
+	"image/svg+xml",
+	"image/tiff",
+	"image/vnd.adobe.photoshop",
+	"image/vnd.djvu",
+	"image/vnd.dwg",
+	"image/vnd.mozilla.apng",
+	"image/vnd.radiance",
+	"image/webp",
+	"image/x-gimp-gbr",
+	"image/x-gimp-pat",
+	"image/x-icns",
+	"image/x-icon",
+	"image/x-xcf",
+	"image/x-xpixmap",
+	"model/gltf-binary",
+	"model/vnd.collada+xml",
+	"model/x3d+xml",
+	"text/calendar",
+	"text/csv",
+	"text/html",
+	"text/plain",
+	"text/rtf",
+	"text/tab-separated-values",
+	"text/vcard",
+	"text/vtt",
+	"text/x-lua",
+	"text/x-perl",
+	"text/x-php",
+	"text/x-python",
+	"text/x-tcl",
+	"text/xml",
+	"video/3gpp",
+	"video/3gpp2",
+	"video/mp4",
+	"video/mpeg",
+	"video/ogg",
+	"video/quicktime",
+	"video/webm",
+	"video/x-flv",
+	"video/x-m4v",
+	"video/x-matroska",
+	"video/x-ms-asf",
+	"video/x-msvideo",
+}
+
+// gSuffixParents are the parents of types with a structured syntax suffix
+// which are unknown to the detection hierarchy
+var gSuffixParents = map[string]string{
+	"json": JsonMimeType,
+	"xml":  "application/xml",
+	"yaml": YamlMimeType,
+	"zip":  ZipMimeType,
+	"gzip": GzipMimeType,
+}
+
+// Ancestors returns an iterator over the parents of `mime` within the
+// default Registry, see Registry.Ancestors
+//
+// The iterator has the same shape as the Go 1.23 iter.Seq[string] type and
+// can be used with range-over-func
+func Ancestors(mime string) func(yield func(mime string) bool) {
+	return gRegistry.Ancestors(mime)
+}
+
+// IsKindOf returns true if `mime` is the same type as `ancestor` or one of
+// its descendants within the default Registry, see Registry.IsKindOf
+func IsKindOf(mime, ancestor string) (yes bool) {
+	return gRegistry.IsKindOf(mime, ancestor)
+}
+
+// Ancestors returns an iterator over the parents of `mime`, nearest first
+// and not including `mime` itself. The parent of each type, looked up by
+// the type itself and by its Canonical form, is the one registered with
+// SetParent, or the parent within the github.com/gabriel-vasile/mimetype
+// detection hierarchy or, for types unknown to the hierarchy, the type
+// implied by the structured syntax suffix, such as JsonMimeType for
+// "application/vnd.api+json". Types without any of these have no ancestors
+//
+// The iterator has the same shape as the Go 1.23 iter.Seq[string] type and
+// can be used with range-over-func
+func (r *Registry) Ancestors(mime string) func(yield func(mime string) bool) {
+	return func(yield func(mime string) bool) {
+		current := PruneCharset(mime)
+		seen := map[string]struct{}{current: {}}
+		for {
+			parent := r.parentOf(current)
+			if parent == "" {
+				return
+			} else if _, present := seen[parent]; present {
+				return
+			} else if !yield(parent) {
+				return
+			}
+			seen[parent] = struct{}{}
+			seen[PruneCharset(r.Canonical(parent))] = struct{}{}
+			current = parent
+		}
+	}
+}
+
+// IsKindOf returns true if `mime` is the same type as `ancestor` or one of
+// the Ancestors of `mime` is, with registered aliases resolved and any
+// parameters ignored. For example "application/geo+json" is a kind of
+// JsonMimeType and of TextMimeType
+func (r *Registry) IsKindOf(mime, ancestor string) (yes bool) {
+	target := PruneCharset(r.Canonical(ancestor))
+	if current := PruneCharset(r.Canonical(mime)); current == "" || target == "" {
+		return
+	} else if yes = current == target; yes {
+		return
+	}
+	r.Ancestors(mime)(func(parent string) bool {
+		yes = PruneCharset(r.Canonical(parent)) == target
+		return !yes
+	})
+	return
+}
+
+// SetParent registers the `parent` of the given `mime` type, used by
+// Ancestors and IsKindOf instead of the detection hierarchy. Types loaded
+// with FormatXDG register their sub-class-of type and types registered
+// with Registry.RegisterTextType and Registry.RegisterBinaryTypeFunc
+// register TextMimeType and BinaryMimeType. If `parent` is empty, any
+// registered parent is cleared
+func (r *Registry) SetParent(mime, parent string) {
+	mediatype := PruneCharset(mime)
+	if parent == "" {
+		r.parents.unset(mediatype)
+		return
+	}
+	r.parents.set(mediatype, PruneCharset(parent))
+}
+
+// SetParent registers the parent of the given `mime` type within the
+// default Registry, see Registry.SetParent
+func SetParent(mime, parent string) {
+	gRegistry.SetParent(mime, parent)
+}
+
+// parentOf returns the nearest parent of the given `mediatype`, checking
+// both the type itself and its canonical form because the detection
+// hierarchy only knows some of the types by their alias
+func (r *Registry) parentOf(mediatype string) (parent string) {
+	names := []string{mediatype}
+	if canonical := PruneCharset(r.Canonical(mediatype)); canonical != mediatype {
+		names = append(names, canonical)
+	}
+	var ok bool
+	for _, name := range names {
+		if parent, ok = r.parents.get(name); ok {
+			return
+		}
+	}
+	for _, name := range names {
+		if mt := mimetype.Lookup(name); mt != nil {
+			if mt.Parent() != nil {
+				parent = mt.Parent().String()
+			}
+			return
+		}
+	}
+	if parent = gSuffixParents[Suffix(mediatype)]; parent == mediatype {
+		parent = ""
+	}
+	return
+}
+
+// Descendants returns an iterator over all the known types beneath `mime`,
+// depth-first with siblings in sorted order and not including `mime`
+// itself. The known types are the types of the
+// github.com/gabriel-vasile/mimetype detection hierarchy and the types
+// registered with the default Registry by extension or by content
+// detector, each beneath its parent as reported by Ancestors
+//
+// The iterator has the same shape as the Go 1.23 iter.Seq[string] type and
+// can be used with range-over-func
+func Descendants(mime string) func(yield func(mime string) bool) {
+	return func(yield func(mime string) bool) {
+		start := hierarchyName(PruneCharset(mime))
+		if start == "" {
+			return
+		}
+		children := hierarchyChildren()
+		seen := map[string]struct{}{start: {}}
+		var walk func(parent string) bool
+		walk = func(parent string) bool {
+			for _, child := range children[parent] {
+				if _, present := seen[child]; present {
+					continue
+				}
+				seen[child] = struct{}{}
+				if !yield(child) || !walk(child) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(start)
+	}
+}
+
+// hierarchyName returns the name of the `mediatype` as known to the
+// github.com/gabriel-vasile/mimetype hierarchy, or the `mediatype` itself
+// for types unknown to it
+func hierarchyName(mediatype string) (name string) {
+	if mt := mimetype.Lookup(mediatype); mt != nil {
+		return mt.String()
+	}
+	return mediatype
+}
+
+// hierarchyChildren returns the sorted children of each known parent type,
+// see Descendants
+func hierarchyChildren() (children map[string][]string) {
+	candidates := append([]string{}, gKnownTypes...)
+	for _, mime := range gRegistry.snapshot(ExtensionTable) {
+		candidates = append(candidates, mime)
+	}
+	for _, list := range []*textDetectorList{gRegistry.detectors, gTextDetectors, gCatchAllDetectors, gBinaryDetectors} {
+		for _, d := range list.snapshot() {
+			candidates = append(candidates, d.Mime)
+		}
+	}
+	for _, info := range Plugins() {
+		candidates = append(candidates, info.Detectors...)
+	}
+
+	seen := map[string]struct{}{}
+	children = map[string][]string{}
+	for _, candidate := range candidates {
+		name := hierarchyName(PruneCharset(candidate))
+		if _, present := seen[name]; present || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		if parent := gRegistry.parentOf(name); parent != "" {
+			parent = hierarchyName(parent)
+			children[parent] = append(children[parent], name)
+		}
+	}
+	for _, list := range children {
+		sort.Strings(list)
+	}
+	return
+}
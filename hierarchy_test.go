@@ -0,0 +1,124 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func collect(seq func(yield func(mime string) bool)) (list []string) {
+	seq(func(mime string) bool {
+		list = append(list, mime)
+		return true
+	})
+	return
+}
+
+func TestHierarchy(t *testing.T) {
+	Convey("Ancestors", t, func() {
+		So(collect(Ancestors("application/geo+json")), ShouldResemble, []string{JsonMimeType, TextMimeType, BinaryMimeType})
+		So(collect(Ancestors(BinaryMimeType)), ShouldBeEmpty)
+		So(collect(Ancestors("nope/nope")), ShouldBeEmpty)
+		var first []string
+		Ancestors("application/geo+json")(func(mime string) bool {
+			first = append(first, mime)
+			return false
+		})
+		So(first, ShouldResemble, []string{JsonMimeType})
+
+		r := New()
+		So(collect(r.Ancestors("application/vnd.api+json")), ShouldResemble, []string{JsonMimeType, TextMimeType, BinaryMimeType})
+		So(collect(r.Ancestors("application/x-unknown")), ShouldBeEmpty)
+		So(r.RegisterTextType("text/x-thing", "thing", nil), ShouldBeNil)
+		So(collect(r.Ancestors("text/x-thing; charset=utf-8")), ShouldResemble, []string{TextMimeType, BinaryMimeType})
+		r.SetParent("application/x-custom", "text/x-thing")
+		So(collect(r.Ancestors("application/x-custom")), ShouldResemble, []string{"text/x-thing", TextMimeType, BinaryMimeType})
+		r.SetParent("text/x-thing", "application/x-custom")
+		So(collect(r.Ancestors("application/x-custom")), ShouldResemble, []string{"text/x-thing"})
+		r.SetParent("text/x-thing", "")
+		So(collect(r.Ancestors("text/x-thing")), ShouldBeEmpty)
+		So(r.UnregisterType("text/x-thing"), ShouldBeTrue)
+		So(collect(r.Ancestors("application/x-custom")), ShouldBeEmpty)
+	})
+
+	Convey("IsKindOf", t, func() {
+		So(IsKindOf("application/geo+json", JsonMimeType), ShouldBeTrue)
+		So(IsKindOf("application/geo+json", TextMimeType+"; charset=utf-8"), ShouldBeTrue)
+		So(IsKindOf(JsonMimeType, JsonMimeType), ShouldBeTrue)
+		So(IsKindOf("text/xml", "application/xml"), ShouldBeTrue)
+		So(IsKindOf("application/rss+xml", "application/xml"), ShouldBeTrue)
+		So(IsKindOf("image/png", TextMimeType), ShouldBeFalse)
+		So(IsKindOf("image/png", BinaryMimeType), ShouldBeTrue)
+		So(IsKindOf(JsonMimeType, "application/geo+json"), ShouldBeFalse)
+		So(IsKindOf("", BinaryMimeType), ShouldBeFalse)
+		So(IsKindOf(BinaryMimeType, ""), ShouldBeFalse)
+
+		r := New()
+		So(r.IsKindOf("application/x-custom", TextMimeType), ShouldBeFalse)
+		So(r.IsPlainText("application/x-custom"), ShouldBeFalse)
+		r.SetParent("application/x-custom", TextMimeType)
+		So(r.IsKindOf("application/x-custom", TextMimeType), ShouldBeTrue)
+		So(r.IsPlainText("application/x-custom"), ShouldBeTrue)
+		So(IsKindOf("application/x-custom", TextMimeType), ShouldBeFalse)
+	})
+
+	Convey("known types", t, func() {
+		// catches gKnownTypes drifting from the mimetype module version
+		for _, mime := range gKnownTypes {
+			So(mimetype.Lookup(mime), ShouldNotBeNil)
+		}
+	})
+
+	Convey("Descendants", t, func() {
+		json := collect(Descendants(JsonMimeType + "; charset=utf-8"))
+		So(json, ShouldContain, "application/geo+json")
+		So(collect(Descendants("text/xml")), ShouldContain, "application/rss+xml")
+
+		text := collect(Descendants(TextMimeType))
+		So(text, ShouldContain, HtmlMimeType)
+		So(text, ShouldContain, "application/geo+json")
+		So(text, ShouldContain, EnjinMimeType)
+		So(text, ShouldContain, VCardMimeType)
+		for _, mime := range text {
+			So(collect(Ancestors(mime)), ShouldContain, TextMimeType)
+		}
+		So(text, ShouldNotContain, "image/png")
+
+		all := collect(Descendants(BinaryMimeType))
+		So(len(all), ShouldBeGreaterThan, 150)
+		So(all, ShouldContain, "image/png")
+
+		for _, mime := range all {
+			So(mime, ShouldContainSubstring, "/")
+		}
+		So(all, ShouldNotContain, "utf-8")
+		So(all, ShouldNotContain, "md")
+
+		SetExtension("xvndtest", "application/vnd.test+json")
+		defer SetExtension("xvndtest", "")
+		So(collect(Descendants(JsonMimeType)), ShouldContain, "application/vnd.test+json")
+
+		So(collect(Descendants("nope/nope")), ShouldBeEmpty)
+		var first []string
+		Descendants(BinaryMimeType)(func(mime string) bool {
+			first = append(first, mime)
+			return false
+		})
+		So(first, ShouldHaveLength, 1)
+	})
+}
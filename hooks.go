@@ -0,0 +1,161 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+	"sync"
+)
+
+// Event describes a change to one of the Registry mapping tables, as given
+// to the hooks added with OnRegister and OnUnset
+type Event struct {
+	// Table is the mapping table that changed
+	Table Table
+	// Key is the extension, mime type, alias, file name or glob pattern
+	// that changed, as stored in the Table
+	Key string
+	// Value is the value registered for the Key or, for OnUnset hooks, the
+	// value that was removed
+	Value string
+	// Previous is the value replaced by an OnRegister change, or empty when
+	// the Key is new
+	Previous string
+}
+
+// Hook is a callback notified of Registry changes. Hooks are called after
+// the change is published and outside of any Registry locks, so they may
+// use the Registry freely
+type Hook func(e Event)
+
+type hookEntry struct {
+	hook  Hook
+	unset bool
+}
+
+// change is an Event with whether the Key was removed
+type change struct {
+	Event
+	removed bool
+}
+
+type hookList struct {
+	list []*hookEntry
+	sync.RWMutex
+}
+
+// add appends the `hook` and returns the func removing it again
+func (l *hookList) add(hook Hook, unset bool) (remove func()) {
+	entry := &hookEntry{hook: hook, unset: unset}
+	l.Lock()
+	l.list = append(l.list, entry)
+	l.Unlock()
+	return func() {
+		l.Lock()
+		defer l.Unlock()
+		for idx, e := range l.list {
+			if e == entry {
+				l.list = append(l.list[:idx:idx], l.list[idx+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// active returns true if any hooks are present
+func (l *hookList) active() bool {
+	if l == nil {
+		return false
+	}
+	l.RLock()
+	defer l.RUnlock()
+	return len(l.list) > 0
+}
+
+// notify calls the hooks interested in each of the `changes`, in order
+func (l *hookList) notify(changes []change) {
+	if l == nil || len(changes) == 0 {
+		return
+	}
+	l.RLock()
+	list := l.list
+	l.RUnlock()
+	for _, c := range changes {
+		for _, entry := range list {
+			if entry.unset == c.removed {
+				entry.hook(c.Event)
+			}
+		}
+	}
+}
+
+// diffChanges returns the changes turning the `before` contents of the
+// `table` into the `after` contents, sorted by key
+func diffChanges(table Table, before, after map[string]string) (changes []change) {
+	for key, value := range after {
+		if previous, present := before[key]; !present || previous != value {
+			changes = append(changes, change{Event: Event{Table: table, Key: key, Value: value, Previous: previous}})
+		}
+	}
+	for key, value := range before {
+		if _, present := after[key]; !present {
+			changes = append(changes, change{Event: Event{Table: table, Key: key, Value: value}, removed: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return
+}
+
+// OnRegister adds a Hook called whenever an extension, charset, alias, file
+// name or glob mapping is added to the Registry or changed, including those
+// made by RegisterTextType, LoadFrom, Import and Reset. OnRegister returns
+// the func which removes the `hook` again, a nil `hook` is ignored
+func (r *Registry) OnRegister(hook Hook) (remove func()) {
+	if hook == nil {
+		return func() {}
+	}
+	return r.hooks.add(hook, false)
+}
+
+// OnUnset adds a Hook called whenever an extension, charset, alias, file
+// name or glob mapping is removed from the Registry, including those removed
+// by UnregisterType and Reset. OnUnset returns the func which removes the
+// `hook` again, a nil `hook` is ignored
+func (r *Registry) OnUnset(hook Hook) (remove func()) {
+	if hook == nil {
+		return func() {}
+	}
+	return r.hooks.add(hook, true)
+}
+
+// watchTables connects the mapping tables to the Registry hooks
+func (r *Registry) watchTables() {
+	for _, t := range []Table{ExtensionTable, CharsetTable, AliasTable, FilenameTable, GlobTable} {
+		l := r.table(t)
+		l.table, l.hooks = t, r.hooks
+	}
+}
+
+// OnRegister adds a Hook to the default Registry, see Registry.OnRegister
+func OnRegister(hook Hook) (remove func()) {
+	return gRegistry.OnRegister(hook)
+}
+
+// OnUnset adds a Hook to the default Registry, see Registry.OnUnset
+func OnUnset(hook Hook) (remove func()) {
+	return gRegistry.OnUnset(hook)
+}
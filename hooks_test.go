@@ -0,0 +1,85 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHooks(t *testing.T) {
+	Convey("OnRegister and OnUnset", t, func() {
+		r := New()
+		var registered, unset []Event
+		removeRegister := r.OnRegister(func(e Event) {
+			registered = append(registered, e)
+		})
+		removeUnset := r.OnUnset(func(e Event) {
+			unset = append(unset, e)
+		})
+
+		r.SetExtension("hook", "text/x-hook")
+		r.SetExtension("hook", "text/x-hooked")
+		So(registered, ShouldResemble, []Event{
+			{Table: ExtensionTable, Key: "hook", Value: "text/x-hook"},
+			{Table: ExtensionTable, Key: "hook", Value: "text/x-hooked", Previous: "text/x-hook"},
+		})
+		So(unset, ShouldBeEmpty)
+
+		registered = nil
+		r.SetExtension("hook", "text/x-hooked")
+		So(registered, ShouldBeEmpty)
+
+		So(r.RegisterTextType("text/x-hooked", "hook", nil), ShouldBeNil)
+		So(registered, ShouldContain, Event{Table: CharsetTable, Key: "text/x-hooked", Value: "utf-8"})
+
+		So(r.UnregisterType("text/x-hooked"), ShouldBeTrue)
+		So(unset, ShouldContain, Event{Table: ExtensionTable, Key: "hook", Value: "text/x-hooked; charset=utf-8"})
+		So(unset, ShouldContain, Event{Table: CharsetTable, Key: "text/x-hooked", Value: "utf-8"})
+
+		removeRegister()
+		removeUnset()
+		registered, unset = nil, nil
+		r.SetExtension("hook", "text/x-hook")
+		r.SetExtension("hook", "")
+		So(registered, ShouldBeEmpty)
+		So(unset, ShouldBeEmpty)
+	})
+
+	Convey("hooks may use the Registry", t, func() {
+		r := New()
+		var seen string
+		r.OnRegister(func(e Event) {
+			seen, _ = r.GetExtensionRaw(e.Key)
+		})
+		r.SetGlob("*.hook", "text/x-hook")
+		So(seen, ShouldEqual, "")
+		r.SetExtension("hook", "text/x-hook")
+		So(seen, ShouldEqual, "text/x-hook")
+		So(r.OnRegister(nil), ShouldNotBeNil)
+	})
+
+	Convey("Reset", t, func() {
+		r := New()
+		r.SetExtension("hook", "text/x-hook")
+		var unset []Event
+		r.OnUnset(func(e Event) {
+			unset = append(unset, e)
+		})
+		r.Reset()
+		So(unset, ShouldResemble, []Event{{Table: ExtensionTable, Key: "hook", Value: "text/x-hook"}})
+	})
+}
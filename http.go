@@ -0,0 +1,75 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/json"
+	goMime "mime"
+	"net/http"
+)
+
+// ProblemJsonMimeType is the RFC 7807 problem details mime type
+const ProblemJsonMimeType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response body
+type Problem struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Status    int      `json:"status"`
+	Detail    string   `json:"detail,omitempty"`
+	Supported []string `json:"supported,omitempty"`
+}
+
+// WriteProblem writes the given Problem to `w` as an application/problem+json
+// response, using the Problem.Status as the response status code
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	w.Header().Set("Content-Type", ProblemJsonMimeType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// RequireContentType returns an http.Handler which only calls `next` when
+// the request Content-Type satisfies at least one of the given `patterns`
+// (see Match). Requests with a body and a missing, malformed or mismatched
+// Content-Type are rejected with a 415 Unsupported Media Type problem
+// response. Requests without a body are always passed through
+func RequireContentType(next http.Handler, patterns ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 && (r.Body == nil || r.Body == http.NoBody) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		contentType := r.Header.Get("Content-Type")
+		problem := Problem{Status: http.StatusUnsupportedMediaType, Supported: patterns}
+		if contentType == "" {
+			problem.Detail = "missing Content-Type header"
+		} else if mediatype, _, err := goMime.ParseMediaType(contentType); err != nil {
+			problem.Detail = "malformed Content-Type header: " + err.Error()
+		} else if !MatchAny(contentType, patterns...) {
+			problem.Detail = "unsupported Content-Type: " + mediatype
+		} else {
+			next.ServeHTTP(w, r)
+			return
+		}
+		WriteProblem(w, problem)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testOkHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+})
+
+func TestRequireContentType(t *testing.T) {
+	handler := RequireContentType(testOkHandler, "application/json", "application/*+json")
+
+	serve := func(method, contentType, body string) *httptest.ResponseRecorder {
+		var req *http.Request
+		if body == "" {
+			req = httptest.NewRequest(method, "/", nil)
+		} else {
+			req = httptest.NewRequest(method, "/", strings.NewReader(body))
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	Convey("RequireContentType", t, func() {
+		So(serve(http.MethodGet, "", "").Code, ShouldEqual, http.StatusNoContent)
+		So(serve(http.MethodPost, "application/json; charset=utf-8", "{}").Code, ShouldEqual, http.StatusNoContent)
+		So(serve(http.MethodPost, "application/vnd.api+json", "{}").Code, ShouldEqual, http.StatusNoContent)
+
+		for _, tc := range []struct{ contentType, detail string }{
+			{"", "missing Content-Type header"},
+			{"text/plain", "unsupported Content-Type: text/plain"},
+			{"not a type", "malformed Content-Type header: mime: expected slash after first token"},
+		} {
+			rec := serve(http.MethodPost, tc.contentType, "data")
+			So(rec.Code, ShouldEqual, http.StatusUnsupportedMediaType)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, ProblemJsonMimeType)
+			var problem Problem
+			So(json.Unmarshal(rec.Body.Bytes(), &problem), ShouldBeNil)
+			So(problem, ShouldResemble, Problem{
+				Type:      "about:blank",
+				Title:     "Unsupported Media Type",
+				Status:    http.StatusUnsupportedMediaType,
+				Detail:    tc.detail,
+				Supported: []string{"application/json", "application/*+json"},
+			})
+		}
+	})
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Tree identifies the RFC 6838 registration tree of a mime type
+type Tree uint8
+
+const (
+	// StandardsTree types have no facet prefix, such as "text/html"
+	StandardsTree Tree = iota
+	// VendorTree types have the "vnd." facet prefix
+	VendorTree
+	// PersonalTree types have the "prs." facet prefix
+	PersonalTree
+	// UnregisteredTree types have the "x." or legacy "x-" prefix
+	UnregisteredTree
+)
+
+// String returns the name of the Tree
+func (t Tree) String() string {
+	switch t {
+	case StandardsTree:
+		return "standards"
+	case VendorTree:
+		return "vendor"
+	case PersonalTree:
+		return "personal"
+	case UnregisteredTree:
+		return "unregistered"
+	}
+	return "unknown"
+}
+
+// GetTree returns the registration Tree of the given `mime` type, based on
+// the facet prefix of the subtype
+func GetTree(mime string) (tree Tree) {
+	_, subtype, _ := strings.Cut(strings.ToLower(PruneCharset(mime)), "/")
+	switch {
+	case strings.HasPrefix(subtype, "vnd."):
+		return VendorTree
+	case strings.HasPrefix(subtype, "prs."):
+		return PersonalTree
+	case strings.HasPrefix(subtype, "x.") || strings.HasPrefix(subtype, "x-"):
+		return UnregisteredTree
+	}
+	return StandardsTree
+}
+
+// IANARegistration is a single entry of the IANA media types registry
+type IANARegistration struct {
+	// Name is the subtype name as listed by IANA
+	Name string
+	// Template is the full mime type, such as "application/json"
+	Template string
+	// Reference is the specification reference, such as "[RFC8259]"
+	Reference string
+	// Obsolete is true when the entry is marked deprecated or obsoleted
+	Obsolete bool
+}
+
+type ianaTable struct {
+	m map[string]IANARegistration
+	sync.RWMutex
+}
+
+// ParseIANA parses one of the per top-level type CSV exports of the IANA
+// media types registry, such as application.csv, which have a header row of
+// Name, Template and Reference columns. The `topLevel` type is used to
+// complete entries without a Template
+func ParseIANA(reader io.Reader, topLevel string) (registrations []IANARegistration, err error) {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	var header []string
+	if header, err = cr.Read(); err != nil {
+		return nil, fmt.Errorf("reading IANA header: %w", err)
+	}
+	columns := map[string]int{}
+	for idx, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = idx
+	}
+	nameIdx, hasName := columns["name"]
+	templateIdx, hasTemplate := columns["template"]
+	referenceIdx, hasReference := columns["reference"]
+	if !hasName || !hasTemplate {
+		return nil, errors.New("IANA CSV must have Name and Template columns")
+	}
+
+	column := func(record []string, idx int) string {
+		if idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+	for {
+		var record []string
+		if record, err = cr.Read(); err == io.EOF {
+			return registrations, nil
+		} else if err != nil {
+			return nil, err
+		}
+		reg := IANARegistration{Name: column(record, nameIdx), Template: column(record, templateIdx)}
+		if hasReference {
+			reg.Reference = column(record, referenceIdx)
+		}
+		lower := strings.ToLower(reg.Name)
+		reg.Obsolete = strings.Contains(lower, "deprecated") || strings.Contains(lower, "obsolete")
+		if reg.Template == "" && topLevel != "" {
+			if fields := strings.Fields(reg.Name); len(fields) > 0 {
+				reg.Template = topLevel + "/" + fields[0]
+			}
+		}
+		if reg.Template = strings.ToLower(reg.Template); reg.Template != "" {
+			registrations = append(registrations, reg)
+		}
+	}
+}
+
+// MergeIANA adds the given IANA `registrations` to the Registry, replacing
+// any existing entries for the same Template
+func (r *Registry) MergeIANA(registrations ...IANARegistration) {
+	r.iana.Lock()
+	defer r.iana.Unlock()
+	for _, reg := range registrations {
+		if reg.Template != "" {
+			r.iana.m[strings.ToLower(reg.Template)] = reg
+		}
+	}
+}
+
+// GetIANA returns the IANA registration merged with MergeIANA for the given
+// `mime` type, with parameters ignored
+func (r *Registry) GetIANA(mime string) (reg IANARegistration, ok bool) {
+	r.iana.RLock()
+	defer r.iana.RUnlock()
+	reg, ok = r.iana.m[strings.ToLower(PruneCharset(mime))]
+	return
+}
+
+// Unregistered returns the mime types within the extension table of the
+// Registry which are not present in the IANA registrations
+// merged with MergeIANA, along with their registration Tree, so that vendor
+// and unregistered types can be flagged
+func (r *Registry) Unregistered() (unregistered map[string]Tree) {
+	unregistered = map[string]Tree{}
+	for _, mime := range r.snapshot(ExtensionTable) {
+		mime = strings.ToLower(PruneCharset(mime))
+		if _, ok := r.GetIANA(mime); !ok && mime != "" {
+			unregistered[mime] = GetTree(mime)
+		}
+	}
+	return
+}
+
+// MergeIANA adds IANA registrations to the default Registry
+func MergeIANA(registrations ...IANARegistration) {
+	gRegistry.MergeIANA(registrations...)
+}
+
+// GetIANA returns the IANA registration of the given `mime` type within the
+// default Registry
+func GetIANA(mime string) (reg IANARegistration, ok bool) {
+	return gRegistry.GetIANA(mime)
+}
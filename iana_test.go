@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testIANAText = `Name,Template,Reference
+css,text/css,[RFC2318]
+html,text/html,[W3C][Robin_Berjon]
+javascript - OBSOLETED in favor of text/javascript,,[RFC9239]
+plain,,[RFC2046][RFC3676][RFC5147]
+"vnd.example,quoted",text/vnd.example-quoted,"[Someone, Else]"
+`
+
+func TestIANA(t *testing.T) {
+	Convey("GetTree", t, func() {
+		So(GetTree("text/html"), ShouldEqual, StandardsTree)
+		So(GetTree("application/vnd.ms-excel"), ShouldEqual, VendorTree)
+		So(GetTree("application/prs.thing; v=1"), ShouldEqual, PersonalTree)
+		So(GetTree("text/x-scss"), ShouldEqual, UnregisteredTree)
+		So(GetTree("application/x.thing"), ShouldEqual, UnregisteredTree)
+		So(VendorTree.String(), ShouldEqual, "vendor")
+		So(Tree(99).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("ParseIANA", t, func() {
+		regs, err := ParseIANA(strings.NewReader(testIANAText), "text")
+		So(err, ShouldBeNil)
+		So(regs, ShouldHaveLength, 5)
+		So(regs[1], ShouldResemble, IANARegistration{Name: "html", Template: "text/html", Reference: "[W3C][Robin_Berjon]"})
+		So(regs[2].Template, ShouldEqual, "text/javascript")
+		So(regs[2].Obsolete, ShouldBeTrue)
+		So(regs[3].Template, ShouldEqual, "text/plain")
+		So(regs[4].Reference, ShouldEqual, "[Someone, Else]")
+
+		_, err = ParseIANA(strings.NewReader(""), "text")
+		So(err, ShouldNotBeNil)
+		_, err = ParseIANA(strings.NewReader("Type,Reference\n"), "text")
+		So(err, ShouldNotBeNil)
+		_, err = ParseIANA(strings.NewReader("Name,Template\n\"broken,\n"), "text")
+		So(err, ShouldNotBeNil)
+
+		Convey("MergeIANA", func() {
+			r := New()
+			r.MergeIANA(regs...)
+			reg, ok := r.GetIANA("TEXT/HTML; charset=utf-8")
+			So(ok, ShouldBeTrue)
+			So(reg.Reference, ShouldEqual, "[W3C][Robin_Berjon]")
+			_, ok = GetIANA("text/html")
+			So(ok, ShouldBeFalse)
+
+			unregistered := r.Unregistered()
+			So(unregistered, ShouldContainKey, ScssMimeType)
+			So(unregistered[ScssMimeType], ShouldEqual, UnregisteredTree)
+			So(unregistered, ShouldContainKey, JsonMimeType)
+			So(unregistered, ShouldNotContainKey, HtmlMimeType)
+			So(unregistered, ShouldNotContainKey, TextMimeType)
+		})
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+// gGenericIcons are the freedesktop.org icon naming specification generic
+// icons of the types which are not covered by their top-level type alone
+var gGenericIcons = map[string]string{
+	DirectoryMimeType:       "folder",
+	HtmlMimeType:            "text-html",
+	"application/xhtml+xml": "text-html",
+	"text/calendar":         "x-office-calendar",
+	"text/vcard":            "x-office-address-book",
+	"text/x-vcard":          "x-office-address-book",
+	"application/pdf":       "x-office-document",
+	"application/vnd.oasis.opendocument.graphics":          "x-office-drawing",
+	"application/vnd.oasis.opendocument.graphics-template": "x-office-drawing",
+	"text/csv":                  "x-office-spreadsheet",
+	"text/tab-separated-values": "x-office-spreadsheet",
+
+	JavaScriptMimeType:          "text-x-script",
+	"application/javascript":    "text-x-script",
+	PhpMimeType:                 "text-x-script",
+	"text/x-python":             "text-x-script",
+	"text/x-perl":               "text-x-script",
+	"text/x-lua":                "text-x-script",
+	"text/x-tcl":                "text-x-script",
+	"text/x-shellscript":        "text-x-script",
+	"application/x-shellscript": "text-x-script",
+
+	"application/x-executable":                      "application-x-executable",
+	"application/x-elf":                             "application-x-executable",
+	"application/x-sharedlib":                       "application-x-executable",
+	"application/x-mach-binary":                     "application-x-executable",
+	"application/vnd.microsoft.portable-executable": "application-x-executable",
+	"application/x-msdownload":                      "application-x-executable",
+
+	ZipMimeType:                             "package-x-generic",
+	TarMimeType:                             "package-x-generic",
+	GzipMimeType:                            "package-x-generic",
+	"application/x-7z-compressed":           "package-x-generic",
+	"application/x-rar-compressed":          "package-x-generic",
+	"application/x-bzip2":                   "package-x-generic",
+	"application/x-xz":                      "package-x-generic",
+	"application/zstd":                      "package-x-generic",
+	"application/x-archive":                 "package-x-generic",
+	"application/x-cpio":                    "package-x-generic",
+	"application/x-rpm":                     "package-x-generic",
+	"application/vnd.debian.binary-package": "package-x-generic",
+	"application/java-archive":              "package-x-generic",
+	"application/jar":                       "package-x-generic",
+}
+
+// gOfficeIcons are the generic icons of each OfficeFamily
+var gOfficeIcons = map[OfficeFamily]string{
+	WordProcessing: "x-office-document",
+	Spreadsheet:    "x-office-spreadsheet",
+	Presentation:   "x-office-presentation",
+}
+
+// IconName returns the freedesktop.org icon naming specification generic
+// icon name of the given `mime`, such as "image-x-generic" or
+// "text-x-script", for use by file browsers and other user interfaces. The
+// name registered with SetIconName is returned first, then the built-in
+// names of specific types, such as "package-x-generic" for archives, and
+// then the generic icon of the top-level type. Types without a more specific
+// icon are "text-x-generic" when textual and "application-x-generic"
+// otherwise. An empty string is returned when `mime` does not parse
+func (r *Registry) IconName(mime string) (icon string) {
+	mediatype := PruneCharset(mime)
+	if mediatype == "" {
+		return
+	}
+	canonical := PruneCharset(r.Canonical(mediatype))
+	for _, key := range []string{mediatype, canonical} {
+		if icon, ok := r.icons.get(key); ok {
+			return icon
+		} else if icon, ok = gGenericIcons[key]; ok {
+			return icon
+		} else if icon, ok = gOfficeIcons[GetOfficeFamily(key)]; ok {
+			return icon
+		}
+	}
+	switch TopLevel(canonical) {
+	case "image":
+		return "image-x-generic"
+	case "audio":
+		return "audio-x-generic"
+	case "video":
+		return "video-x-generic"
+	case "font":
+		return "font-x-generic"
+	}
+	if r.IsPlainText(canonical) {
+		return "text-x-generic"
+	}
+	return "application-x-generic"
+}
+
+// SetIconName registers the icon name that IconName returns for the given
+// `mime` type, overriding the built-in names. If `icon` is empty, any
+// registered icon name is cleared
+func (r *Registry) SetIconName(mime, icon string) {
+	mediatype := PruneCharset(mime)
+	if icon == "" {
+		r.icons.unset(mediatype)
+		return
+	}
+	r.icons.set(mediatype, icon)
+}
+
+// IconName returns the generic icon name of the given `mime` using the
+// default Registry, see Registry.IconName
+func IconName(mime string) (icon string) {
+	return gRegistry.IconName(mime)
+}
+
+// SetIconName registers the icon name of the given `mime` type within the
+// default Registry, see Registry.SetIconName
+func SetIconName(mime, icon string) {
+	gRegistry.SetIconName(mime, icon)
+}
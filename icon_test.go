@@ -0,0 +1,76 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIconName(t *testing.T) {
+	Convey("IconName", t, func() {
+		r := New()
+		for _, check := range [][2]string{
+			{"", ""},
+			{"image/png", "image-x-generic"},
+			{"audio/mpeg", "audio-x-generic"},
+			{"video/mp4", "video-x-generic"},
+			{"font/woff2", "font-x-generic"},
+			{"text/plain; charset=utf-8", "text-x-generic"},
+			{"text/markdown", "text-x-generic"},
+			{"text/x-markdown", "text-x-generic"},
+			{"application/json", "text-x-generic"},
+			{"application/ld+json", "text-x-generic"},
+			{"text/html", "text-html"},
+			{"text/x-python", "text-x-script"},
+			{"application/zip", "package-x-generic"},
+			{"application/x-elf", "application-x-executable"},
+			{DocxMimeType, "x-office-document"},
+			{"application/vnd.ms-excel", "x-office-spreadsheet"},
+			{OdpMimeType, "x-office-presentation"},
+			{"text/calendar", "x-office-calendar"},
+			{DirectoryMimeType, "folder"},
+			{"application/octet-stream", "application-x-generic"},
+		} {
+			So(r.IconName(check[0]), ShouldEqual, check[1])
+		}
+		So(IconName("image/gif"), ShouldEqual, "image-x-generic")
+	})
+
+	Convey("SetIconName", t, func() {
+		r := New()
+		r.SetIconName("image/png; charset=binary", "image-png")
+		So(r.IconName("image/png"), ShouldEqual, "image-png")
+		r.SetIconName("text/markdown", "text-x-markdown")
+		So(r.IconName("text/x-markdown"), ShouldEqual, "text-x-markdown")
+		r.SetIconName("image/png", "")
+		So(r.IconName("image/png"), ShouldEqual, "image-x-generic")
+		r.Reset()
+		So(r.IconName("text/markdown"), ShouldEqual, "text-x-generic")
+	})
+
+	Convey("FormatXDG generic icons", t, func() {
+		r := New()
+		So(r.LoadFrom(strings.NewReader(`<mime-info>
+  <mime-type type="application/x-icon-thing">
+    <generic-icon name="x-office-document"/>
+    <glob pattern="*.iconthing"/>
+  </mime-type>
+</mime-info>`), FormatXDG), ShouldBeNil)
+		So(r.IconName("application/x-icon-thing"), ShouldEqual, "x-office-document")
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
+	"gopkg.in/yaml.v3"
+)
+
+// Import applies the mappings of the given Export to the Registry. Entries
+// with empty values clear the existing mapping, the same as the Set methods.
+// All entries are checked before any are applied and nothing is changed
+// when an error is returned. The Types of the Export are informational only
+// and are ignored, content detectors cannot be configured declaratively
+func (r *Registry) Import(e Export) (err error) {
+	if e.Version > ExportVersion {
+		return fmt.Errorf("unsupported schema version: %d", e.Version)
+	}
+
+	var problems []error
+	check := func(kind, key, mime string) {
+		if mime != "" {
+			if ee := checkMimeType(mime); ee != nil {
+				problems = append(problems, fmt.Errorf("%s %q: %w", kind, key, ee))
+			}
+		}
+	}
+	for extension, mime := range e.Extensions {
+		if extension == "" || strings.ContainsAny(extension, "/\\") {
+			problems = append(problems, fmt.Errorf("invalid extension %q", extension))
+		}
+		check("extension", extension, mime)
+	}
+	for mime, charset := range e.Charsets {
+		check("charset", mime, mime)
+		if _, ee := ianaindex.IANA.Encoding(charset); charset != "" && ee != nil {
+			problems = append(problems, fmt.Errorf("mime type %q has an unknown charset %q", mime, charset))
+		}
+	}
+	for alias, canonical := range e.Aliases {
+		check("alias", alias, alias)
+		check("alias", alias, canonical)
+	}
+	for name, mime := range e.Filenames {
+		if name == "" || strings.ContainsAny(name, "/\\") {
+			problems = append(problems, fmt.Errorf("invalid filename %q", name))
+		}
+		check("filename", name, mime)
+	}
+	for pattern, mime := range e.Globs {
+		if _, ee := path.Match(pattern, ""); ee != nil || pattern == "" {
+			problems = append(problems, fmt.Errorf("invalid glob %q", pattern))
+		}
+		check("glob", pattern, mime)
+	}
+	if err = errors.Join(problems...); err != nil {
+		return
+	}
+
+	for extension, mime := range e.Extensions {
+		r.SetExtension(extension, mime)
+	}
+	for mime, charset := range e.Charsets {
+		r.SetCharset(mime, charset)
+	}
+	for alias, canonical := range e.Aliases {
+		r.SetAlias(alias, canonical)
+	}
+	for name, mime := range e.Filenames {
+		r.SetFilename(name, mime)
+	}
+	for pattern, mime := range e.Globs {
+		_ = r.SetGlob(pattern, mime)
+	}
+	return
+}
+
+// ImportJSON reads an Export from the JSON configuration in `reader` and
+// applies it with Import. Unknown fields are reported as errors so that
+// typos in configuration files are not silently ignored
+func (r *Registry) ImportJSON(reader io.Reader) (err error) {
+	var e Export
+	dec := json.NewDecoder(reader)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&e); err != nil {
+		return
+	}
+	return r.Import(e)
+}
+
+// ImportYAML reads an Export from the YAML configuration in `reader` and
+// applies it with Import. Unknown fields are reported as errors so that
+// typos in configuration files are not silently ignored
+func (r *Registry) ImportYAML(reader io.Reader) (err error) {
+	var e Export
+	dec := yaml.NewDecoder(reader)
+	dec.KnownFields(true)
+	if err = dec.Decode(&e); err != nil && err != io.EOF {
+		return
+	}
+	return r.Import(e)
+}
+
+// ImportJSON applies the JSON configuration in `reader` to the default
+// Registry, see Registry.ImportJSON
+func ImportJSON(reader io.Reader) (err error) {
+	return gRegistry.ImportJSON(reader)
+}
+
+// ImportYAML applies the YAML configuration in `reader` to the default
+// Registry, see Registry.ImportYAML
+func ImportYAML(reader io.Reader) (err error) {
+	return gRegistry.ImportYAML(reader)
+}
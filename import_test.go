@@ -0,0 +1,92 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestImport(t *testing.T) {
+	Convey("ImportJSON", t, func() {
+		r := New()
+		So(r.ImportJSON(strings.NewReader(`{
+			"extensions": {"njk": "text/x-nunjucks", "txt": ""},
+			"charsets": {"text/x-nunjucks": "utf-8"},
+			"aliases": {"text/x-njk": "text/x-nunjucks"},
+			"filenames": {"Brewfile": "text/x-ruby"},
+			"globs": {"*.nunjucks.*": "text/x-nunjucks"}
+		}`)), ShouldBeNil)
+		So(r.FromPathOnly("page.njk"), ShouldEqual, "text/x-nunjucks")
+		So(r.FromPathOnly("page.nunjucks.html"), ShouldEqual, "text/x-nunjucks")
+		So(r.FromPathOnly("Brewfile"), ShouldEqual, "text/x-ruby")
+		So(r.Canonical("text/x-njk"), ShouldEqual, "text/x-nunjucks")
+		charset, _ := r.GetCharset("text/x-nunjucks")
+		So(charset, ShouldEqual, "utf-8")
+		_, ok := r.extensions.get("txt")
+		So(ok, ShouldBeFalse)
+
+		So(r.ImportJSON(strings.NewReader(`{"extenstions": {}}`)), ShouldNotBeNil)
+		So(r.ImportJSON(strings.NewReader(`{"version": 99}`)), ShouldNotBeNil)
+		So(r.ImportJSON(strings.NewReader(`not json`)), ShouldNotBeNil)
+
+		// nothing is applied when any entry is invalid
+		So(r.ImportJSON(strings.NewReader(`{
+			"extensions": {"imported": "text/x-good", "bad": "not-a-type"},
+			"charsets": {"text/x-good": "no-such-charset"},
+			"globs": {"[": "text/x-good"}
+		}`)), ShouldNotBeNil)
+		So(r.FromPathOnly("file.imported"), ShouldEqual, "")
+	})
+
+	Convey("ImportYAML", t, func() {
+		r := New()
+		So(r.ImportYAML(strings.NewReader(`
+extensions:
+  njk: text/x-nunjucks
+charsets:
+  text/x-nunjucks: utf-8
+aliases:
+  text/x-njk: text/x-nunjucks
+globs:
+  "*.njk.html": text/x-nunjucks
+`)), ShouldBeNil)
+		So(r.FromPathOnly("page.njk"), ShouldEqual, "text/x-nunjucks")
+		So(r.FromPathOnly("page.njk.html"), ShouldEqual, "text/x-nunjucks")
+		So(r.ImportYAML(strings.NewReader("")), ShouldBeNil)
+		So(r.ImportYAML(strings.NewReader("unknown: true\n")), ShouldNotBeNil)
+		So(r.ImportYAML(strings.NewReader("extensions:\n  bad: not-a-type\n")), ShouldNotBeNil)
+	})
+
+	Convey("Export round trip", t, func() {
+		a := New()
+		a.SetExtension("njk", "text/x-nunjucks")
+		a.SetAlias("text/x-njk", "text/x-nunjucks")
+		So(a.SetGlob("*.njk.*", "text/x-nunjucks"), ShouldBeNil)
+		var buf bytes.Buffer
+		So(a.ExportJSON(&buf), ShouldBeNil)
+		b := New()
+		So(b.ImportJSON(&buf), ShouldBeNil)
+		So(Diff(a, b).Empty(), ShouldBeTrue)
+
+		So(ImportJSON(strings.NewReader(`{"extensions": {"importtest": "text/x-import-test"}}`)), ShouldBeNil)
+		So(FromPathOnly("a.importtest"), ShouldEqual, "text/x-import-test")
+		So(ImportYAML(strings.NewReader("extensions:\n  importtest: \"\"\n")), ShouldBeNil)
+		So(FromPathOnly("a.importtest"), ShouldEqual, "")
+	})
+}
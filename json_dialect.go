@@ -0,0 +1,125 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// gGeoJSONTypes are the top-level "type" values of GeoJSON documents
+var gGeoJSONTypes = map[string]struct{}{
+	"FeatureCollection":  {},
+	"Feature":            {},
+	"Point":              {},
+	"MultiPoint":         {},
+	"LineString":         {},
+	"MultiLineString":    {},
+	"Polygon":            {},
+	"MultiPolygon":       {},
+	"GeometryCollection": {},
+}
+
+// isJSONFamily returns true if the `mediatype` is one of the JSON dialects
+// told apart by detectJSON
+func isJSONFamily(mediatype string) bool {
+	switch mediatype {
+	case JsonMimeType, NdjsonMimeType, GeoJsonMimeType:
+		return true
+	}
+	return false
+}
+
+// detectJSON returns the JSON dialect of the `head` content: NdjsonMimeType
+// for two or more lines each holding a JSON value, GeoJsonMimeType for an
+// object with a GeoJSON top-level "type" member and JsonMimeType for any
+// other JSON value. When `truncated` is true, the `head` is only the leading
+// portion of the content and a value cut short at the end is accepted
+func detectJSON(head []byte, truncated bool) (mime string, ok bool) {
+	_, size := DetectBOM(head)
+	head = bytes.TrimSpace(head[size:])
+	if len(head) == 0 || (head[0] != '{' && head[0] != '[') {
+		return
+	}
+
+	if lines := bytes.Split(head, []byte("\n")); len(lines) > 1 {
+		values := 0
+		for idx, line := range lines {
+			if line = bytes.TrimSpace(line); len(line) == 0 {
+				continue
+			} else if line[0] != '{' && line[0] != '[' {
+				values = 0
+				break
+			} else if json.Valid(line) {
+				values += 1
+			} else if !truncated || idx < len(lines)-1 || !validJSONPrefix(line) {
+				values = 0
+				break
+			}
+		}
+		if values > 1 {
+			return NdjsonMimeType, true
+		}
+	}
+
+	if json.Valid(head) || (truncated && validJSONPrefix(head)) {
+		if head[0] == '{' && isGeoJSON(head) {
+			return GeoJsonMimeType, true
+		}
+		return JsonMimeType, true
+	}
+	return
+}
+
+// validJSONPrefix returns true if `data` is the start of a JSON value, that
+// is, it is valid up until the point where it was cut short
+func validJSONPrefix(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// isGeoJSON returns true if the top-level "type" member of the JSON object
+// within `data` is one of the GeoJSON types
+func isGeoJSON(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return false
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return false
+		} else if key != "type" {
+			continue
+		}
+		var name string
+		if json.Unmarshal(value, &name) == nil {
+			_, found := gGeoJSONTypes[name]
+			return found
+		}
+		return false
+	}
+	return false
+}
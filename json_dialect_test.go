@@ -0,0 +1,82 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSONDialect(t *testing.T) {
+	Convey("detectJSON", t, func() {
+		for _, check := range []struct {
+			data      string
+			truncated bool
+			mime      string
+		}{
+			{`{"a": 1}`, false, JsonMimeType},
+			{"[1, 2, 3]\n", false, JsonMimeType},
+			{"{\n  \"a\": 1,\n  \"b\": [1, 2]\n}\n", false, JsonMimeType},
+			{"{\"a\": 1}\n{\"a\": 2}\n", false, NdjsonMimeType},
+			{"{\"a\": 1}\r\n\r\n[2]\r\n", false, NdjsonMimeType},
+			{"{\"a\": 1}\n{\"a\": 2}\n{\"a\": \"cut sh", true, NdjsonMimeType},
+			{`{"type": "FeatureCollection", "features": []}`, false, GeoJsonMimeType},
+			{`{"properties": {"type": "Point"}, "type": "Feature", "geometry": null}`, false, GeoJsonMimeType},
+			{`{"type": "Invoice"}`, false, JsonMimeType},
+			{`{"type": "FeatureCollection", "features": [{"type": "Feat`, true, GeoJsonMimeType},
+			{`{"a": [1, 2`, true, JsonMimeType},
+		} {
+			mime, ok := detectJSON([]byte(check.data), check.truncated)
+			So(ok, ShouldBeTrue)
+			So(mime, ShouldEqual, check.mime)
+		}
+
+		for _, check := range []struct {
+			data      string
+			truncated bool
+		}{
+			{`{"a": 1`, false},
+			{"{\"a\": 1}\nnot json\n", false},
+			{`{"a": 1}}`, true},
+			{`plain text`, false},
+			{``, false},
+		} {
+			_, ok := detectJSON([]byte(check.data), check.truncated)
+			So(ok, ShouldBeFalse)
+		}
+	})
+
+	Convey("detection", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		So(r.DetectBytes([]byte("{\"id\": 1}\n{\"id\": 2}\n")), ShouldEqual, NdjsonMimeType)
+		So(r.DetectBytes([]byte(`{"type": "FeatureCollection", "features": []}`)), ShouldEqual, GeoJsonMimeType)
+		So(r.DetectBytes([]byte(`{"name": "value"}`)), ShouldEqual, JsonMimeType)
+
+		large := append([]byte(`{"type": "FeatureCollection", "features": [`), bytes.Repeat([]byte(`{"type": "Feature"}, `), HeadWindow)...)
+		So(r.DetectBytes(large), ShouldEqual, GeoJsonMimeType)
+	})
+
+	Convey("extensions", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		So(r.FromPathOnly("events.ndjson"), ShouldEqual, NdjsonMimeType)
+		So(r.FromPathOnly("events.jsonl"), ShouldEqual, NdjsonMimeType)
+		So(r.FromPathOnly("map.geojson"), ShouldEqual, GeoJsonMimeType)
+		So(r.IsPlainText(NdjsonMimeType), ShouldBeTrue)
+	})
+}
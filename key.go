@@ -0,0 +1,48 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"hash/fnv"
+)
+
+// Key returns a stable, comparable form of the given `mime` suitable for use
+// as a map or cache key. Equivalent spellings of the same media type produce
+// the same Key: the value is the Canonical form, which is normalized (see
+// Normalize) with sorted parameters and registered aliases replaced with
+// their canonical type
+func (r *Registry) Key(mime string) (key string) {
+	return r.Canonical(mime)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of the given `mime`, which
+// is stable across processes and platforms
+func (r *Registry) Hash(mime string) (sum uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(r.Key(mime)))
+	return h.Sum64()
+}
+
+// Key returns the stable, comparable form of the given `mime` using the
+// default Registry, see Registry.Key for details
+func Key(mime string) (key string) {
+	return gRegistry.Key(mime)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of the given `mime` using
+// the default Registry
+func Hash(mime string) (sum uint64) {
+	return gRegistry.Hash(mime)
+}
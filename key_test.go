@@ -0,0 +1,40 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKey(t *testing.T) {
+	Convey("Key", t, func() {
+		So(Key("text/html; charset=utf-8"), ShouldEqual, "text/html; charset=utf-8")
+		So(Key(`Text/HTML;Charset="UTF-8"`), ShouldEqual, "text/html; charset=utf-8")
+		So(Key("text/x-template; b=2; A=1"), ShouldEqual, "text/x-template; a=1; b=2")
+
+		r := New()
+		r.SetAlias("text/x-md", "text/markdown")
+		So(r.Key("Text/X-Markdown; charset=UTF-8"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(Key("text/x-md"), ShouldEqual, "text/x-md")
+	})
+
+	Convey("Hash", t, func() {
+		So(Hash("text/html;charset=UTF-8"), ShouldEqual, Hash("text/html; charset=utf-8"))
+		So(Hash("text/html"), ShouldNotEqual, Hash("text/plain"))
+		So(Hash(""), ShouldEqual, uint64(0xcbf29ce484222325))
+	})
+}
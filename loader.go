@@ -0,0 +1,186 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	goMime "mime"
+	"os"
+	"strings"
+)
+
+// Format identifies the syntax of a mime types file given to LoadFrom
+type Format uint8
+
+const (
+	// FormatApache is the Apache httpd (and /etc/mime.types) format: one
+	// mime type per line followed by any number of extensions
+	//
+	//	text/html  html htm
+	FormatApache Format = iota
+	// FormatNginx is the nginx types block format: a "types" block of mime
+	// types followed by extensions, each terminated by a semicolon
+	//
+	//	types {
+	//	    text/html  html htm shtml;
+	//	}
+	FormatNginx
+	// FormatXDG is the freedesktop.org shared-mime-info XML package format.
+	// Simple "*.ext" globs are registered as extensions, other globs with
+	// SetGlob, aliases with SetAlias, generic icons with SetIconName,
+	// sub-class-of types with SetParent and magic rules as content detectors.
+	// For the default Registry, the detectors are registered within the
+	// github.com/gabriel-vasile/mimetype hierarchy beneath the sub-class-of
+	// type, other Registry instances use RegisterDetector
+	FormatXDG
+)
+
+// ErrUnknownFormat is returned by LoadFrom for unsupported Format values
+var ErrUnknownFormat = errors.New("unknown mime types file format")
+
+// String returns the name of the Format
+func (f Format) String() string {
+	switch f {
+	case FormatApache:
+		return "apache"
+	case FormatNginx:
+		return "nginx"
+	case FormatXDG:
+		return "xdg"
+	}
+	return "unknown"
+}
+
+// LoadFrom reads a mime types file in the given `format` from `reader` and
+// registers every extension found with SetExtension, later entries replacing
+// earlier ones. Nothing is registered when an error is returned
+func (r *Registry) LoadFrom(reader io.Reader, format Format) (err error) {
+	var entries [][2]string
+	switch format {
+	case FormatApache:
+		entries, err = parseApacheTypes(reader)
+	case FormatNginx:
+		entries, err = parseNginxTypes(reader)
+	case FormatXDG:
+		return r.loadXDG(reader)
+	default:
+		err = ErrUnknownFormat
+	}
+	if err != nil {
+		return
+	}
+	r.setExtensions(entries)
+	return
+}
+
+// LoadFile opens the file at `path` and loads it with LoadFrom
+func (r *Registry) LoadFile(path string, format Format) (err error) {
+	var fh *os.File
+	if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	return r.LoadFrom(fh, format)
+}
+
+// LoadFrom loads a mime types file into the default Registry, see
+// Registry.LoadFrom
+func LoadFrom(reader io.Reader, format Format) (err error) {
+	return gRegistry.LoadFrom(reader, format)
+}
+
+// LoadFile loads a mime types file into the default Registry, see
+// Registry.LoadFile
+func LoadFile(path string, format Format) (err error) {
+	return gRegistry.LoadFile(path, format)
+}
+
+// parseApacheTypes returns the extension and mime type pairs of an Apache
+// format mime types file
+func parseApacheTypes(reader io.Reader) (entries [][2]string, err error) {
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		} else if ee := checkMimeType(fields[0]); ee != nil {
+			return nil, fmt.Errorf("line %d: %w", line, ee)
+		}
+		for _, extension := range fields[1:] {
+			entries = append(entries, [2]string{extension, fields[0]})
+		}
+	}
+	err = scanner.Err()
+	return
+}
+
+// parseNginxTypes returns the extension and mime type pairs of the types
+// block within an nginx format mime types file
+func parseNginxTypes(reader io.Reader) (entries [][2]string, err error) {
+	var data []byte
+	if data, err = io.ReadAll(reader); err != nil {
+		return
+	}
+	var tokens []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.NewReplacer("{", " { ", "}", " } ", ";", " ; ").Replace(line)
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	if len(tokens) < 2 || tokens[0] != "types" || tokens[1] != "{" {
+		return nil, errors.New(`expected a "types {" block`)
+	}
+	var statement []string
+	for _, token := range tokens[2:] {
+		switch token {
+		case "{":
+			return nil, errors.New(`unexpected "{" within the types block`)
+		case "}":
+			if len(statement) > 0 {
+				return nil, fmt.Errorf("missing semicolon after %q", strings.Join(statement, " "))
+			}
+			return
+		case ";":
+			if len(statement) < 2 {
+				return nil, fmt.Errorf("incomplete statement %q", strings.Join(statement, " "))
+			} else if ee := checkMimeType(statement[0]); ee != nil {
+				return nil, ee
+			}
+			for _, extension := range statement[1:] {
+				entries = append(entries, [2]string{extension, statement[0]})
+			}
+			statement = nil
+		default:
+			statement = append(statement, token)
+		}
+	}
+	return nil, errors.New("unterminated types block")
+}
+
+// checkMimeType returns an error if `mime` is not a valid "type/subtype"
+func checkMimeType(mime string) (err error) {
+	var mediatype string
+	if mediatype, _, err = goMime.ParseMediaType(mime); err != nil {
+		return fmt.Errorf("invalid mime type %q: %w", mime, err)
+	} else if !strings.Contains(mediatype, "/") {
+		return fmt.Errorf("invalid mime type %q: missing subtype", mime)
+	}
+	return
+}
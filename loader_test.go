@@ -0,0 +1,98 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testApacheTypes = `# comment line
+application/x-thing		thing thg
+text/x-other	other # trailing comment
+
+application/x-none
+`
+
+const testNginxTypes = `
+types {
+    text/html                                        html htm shtml;
+    application/vnd.openxmlformats-officedocument.wordprocessingml.document
+                                                     docx;
+    # a comment
+    application/x-thing thing;application/x-thang thang;
+}
+`
+
+func TestLoadFrom(t *testing.T) {
+	Convey("LoadFrom", t, func() {
+		Convey("apache", func() {
+			r := New()
+			So(r.LoadFrom(strings.NewReader(testApacheTypes), FormatApache), ShouldBeNil)
+			mime, ok := r.GetExtension("thg")
+			So(ok, ShouldBeTrue)
+			So(mime, ShouldEqual, "application/x-thing")
+			mime, _ = r.GetExtension("other")
+			So(mime, ShouldEqual, "text/x-other")
+
+			So(New().LoadFrom(strings.NewReader("not-a-type ext\n"), FormatApache), ShouldNotBeNil)
+		})
+
+		Convey("nginx", func() {
+			r := New()
+			So(r.LoadFrom(strings.NewReader(testNginxTypes), FormatNginx), ShouldBeNil)
+			mime, _ := r.GetExtension("shtml")
+			So(mime, ShouldEqual, HtmlMimeType)
+			mime, _ = r.GetExtension("docx")
+			So(mime, ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+			mime, _ = r.GetExtension("thang")
+			So(mime, ShouldEqual, "application/x-thang")
+
+			for _, broken := range []string{
+				"text/html html;",
+				"types { text/html html }",
+				"types { text/html; }",
+				"types { text/html html;",
+				"types { nested { } }",
+				"types { bad html; }",
+			} {
+				r = New()
+				So(r.LoadFrom(strings.NewReader(broken), FormatNginx), ShouldNotBeNil)
+				So(Diff(New(), r).Empty(), ShouldBeTrue)
+			}
+		})
+
+		Convey("formats", func() {
+			So(FormatApache.String(), ShouldEqual, "apache")
+			So(FormatNginx.String(), ShouldEqual, "nginx")
+			So(Format(99).String(), ShouldEqual, "unknown")
+			So(New().LoadFrom(strings.NewReader(""), Format(99)), ShouldEqual, ErrUnknownFormat)
+		})
+
+		Convey("LoadFile", func() {
+			path := filepath.Join(t.TempDir(), "mime.types")
+			So(os.WriteFile(path, []byte(testApacheTypes), 0o644), ShouldBeNil)
+			r := New()
+			So(r.LoadFile(path, FormatApache), ShouldBeNil)
+			_, ok := r.extensions.get("thing")
+			So(ok, ShouldBeTrue)
+			So(r.LoadFile(path+".missing", FormatApache), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"log/slog"
+	"sync"
+)
+
+var gInitErrors struct {
+	list []error
+	sync.Mutex
+}
+
+// initError records any non-nil `err` which occurred during package
+// initialization, these are reported to the logger of the default Registry
+func initError(err error) {
+	if err != nil {
+		gInitErrors.Lock()
+		defer gInitErrors.Unlock()
+		gInitErrors.list = append(gInitErrors.list, err)
+	}
+}
+
+// SetLogger configures the *slog.Logger which receives diagnostics for
+// errors that are otherwise discarded, such as files that Mime cannot read
+// and media types that PruneCharset cannot parse. Failed registrations and
+// content contradicting an extension or a declared Content-Type are reported
+// as warnings, detections falling back to BinaryMimeType at the debug level.
+// Setting a logger on the default Registry also reports any errors which
+// occurred during package initialization. A nil `logger` disables
+// diagnostics, which is the default
+func (r *Registry) SetLogger(logger *slog.Logger) {
+	r.logger.Store(logger)
+	if logger != nil && r == gRegistry {
+		gInitErrors.Lock()
+		defer gInitErrors.Unlock()
+		for _, err := range gInitErrors.list {
+			logger.Error("mime: package initialization failed", "err", err)
+		}
+	}
+}
+
+// GetLogger returns the *slog.Logger receiving diagnostics, if any
+func (r *Registry) GetLogger() (logger *slog.Logger) {
+	return r.logger.Load()
+}
+
+// logWarn reports a diagnostic to the logger, if one is set
+func (r *Registry) logWarn(msg string, args ...any) {
+	if r != nil {
+		if logger := r.logger.Load(); logger != nil {
+			logger.Warn(msg, args...)
+		}
+	}
+}
+
+// logDebug reports a diagnostic of routine interest, such as a detection
+// falling back to a generic type, to the logger, if one is set
+func (r *Registry) logDebug(msg string, args ...any) {
+	if r != nil {
+		if logger := r.logger.Load(); logger != nil {
+			logger.Debug(msg, args...)
+		}
+	}
+}
+
+// logRegistration reports the registration of `mime` failing with the error
+// `err` points to, if any, for use with defer:
+//
+//	defer r.logRegistration(mime, &err)
+func (r *Registry) logRegistration(mime string, err *error) {
+	if *err != nil {
+		r.logWarn("mime: registration failed", "mime", mime, "err", *err)
+	}
+}
+
+// SetLogger configures the *slog.Logger of the default Registry, see
+// Registry.SetLogger
+func SetLogger(logger *slog.Logger) {
+	gRegistry.SetLogger(logger)
+}
+
+// GetLogger returns the *slog.Logger of the default Registry
+func GetLogger() (logger *slog.Logger) {
+	return gRegistry.GetLogger()
+}
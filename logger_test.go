@@ -0,0 +1,95 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLogger(t *testing.T) {
+	Convey("SetLogger", t, func() {
+		var buf strings.Builder
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		Convey("swallowed errors", func() {
+			So(GetLogger(), ShouldBeNil)
+			SetLogger(logger)
+			defer SetLogger(nil)
+			So(GetLogger(), ShouldEqual, logger)
+			So(PruneCharset("text/html; =bad"), ShouldEqual, "text/html")
+			So(PruneCharset(""), ShouldEqual, "")
+			So(buf.String(), ShouldContainSubstring, `msg="mime: unable to parse media type" mime="text/html; =bad"`)
+			So(strings.Count(buf.String(), "\n"), ShouldEqual, 1)
+		})
+
+		Convey("package initialization errors", func() {
+			gInitErrors.Lock()
+			saved := gInitErrors.list
+			gInitErrors.Unlock()
+			defer func() {
+				gInitErrors.Lock()
+				gInitErrors.list = saved
+				gInitErrors.Unlock()
+			}()
+			initError(nil)
+			initError(errors.New("broken registration"))
+
+			r := New()
+			r.SetLogger(logger)
+			So(buf.String(), ShouldBeEmpty)
+
+			SetLogger(logger)
+			defer SetLogger(nil)
+			So(buf.String(), ShouldContainSubstring, `level=ERROR msg="mime: package initialization failed" err="broken registration"`)
+		})
+
+		Convey("registration failures", func() {
+			r := New()
+			r.SetLogger(logger)
+			So(r.RegisterTextType("text/x-logged", "", nil), ShouldNotBeNil)
+			So(r.RegisterBinaryType("application/x-logged", "lgd", nil, 0), ShouldNotBeNil)
+			So(r.SetGlob("[", "text/x-logged"), ShouldNotBeNil)
+			So(r.RegisterTextType("text/x-logged", "lgd", nil), ShouldBeNil)
+			So(strings.Count(buf.String(), `msg="mime: registration failed"`), ShouldEqual, 3)
+			So(buf.String(), ShouldContainSubstring, `mime=application/x-logged err="magic must not be empty`)
+		})
+
+		Convey("detection diagnostics", func() {
+			debug := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			r := New()
+			r.SetLogger(debug)
+			tmp := t.TempDir()
+			unknown := filepath.Join(tmp, "unknown")
+			So(os.WriteFile(unknown, []byte{0, 0, 1, 2, 0xfe, 0xff}, 0o600), ShouldBeNil)
+			So(PruneCharset(r.Mime(unknown)), ShouldEqual, BinaryMimeType)
+			So(buf.String(), ShouldContainSubstring, `level=DEBUG msg="mime: content not recognized" path=`+unknown)
+
+			buf.Reset()
+			fake := filepath.Join(tmp, "fake.png")
+			So(os.WriteFile(fake, []byte("%PDF-1.4\n"), 0o600), ShouldBeNil)
+			result, err := r.Detect(fake)
+			So(err, ShouldBeNil)
+			So(result.Confidence, ShouldEqual, ConfidenceMismatch)
+			So(buf.String(), ShouldContainSubstring, `level=WARN msg="mime: extension contradicted by content"`)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"strings"
+)
+
+// Match returns true if the given `mime` satisfies the `pattern`. Patterns
+// are media types where the type and/or subtype can be a wildcard: "*/*"
+// (or just "*"), "text/*" or "application/*+json" (matching any subtype with
+// the +json structured syntax suffix). Parameters on the `mime` are ignored
+// unless the `pattern` has them, in which case each pattern parameter must
+// be present with an equal value: "text/plain; charset=utf-8" matches
+// "text/plain; charset=UTF8" but not "text/plain". Comparisons of types,
+// parameter names and parameter values are case-insensitive, with charset
+// values compared using CharsetEqual. Registered aliases also match through
+// their Canonical type, so "text/xml" satisfies "application/xml"
+func Match(pattern, mime string) bool {
+	if match(pattern, mime) {
+		return true
+	}
+	// compare the canonical forms only when an alias is involved
+	cPattern, cMime := Canonical(pattern), Canonical(mime)
+	if PruneCharset(cPattern) == PruneCharset(Normalize(pattern)) && PruneCharset(cMime) == PruneCharset(Normalize(mime)) {
+		return false
+	}
+	return match(cPattern, mime) || match(pattern, cMime) || match(cPattern, cMime)
+}
+
+// match is the alias unaware implementation of Match
+func match(pattern, mime string) bool {
+	if strings.TrimSpace(pattern) == "*" {
+		pattern = "*/*"
+	}
+	pattern, pParams, pErr := goMime.ParseMediaType(pattern)
+	mime, mParams, mErr := goMime.ParseMediaType(mime)
+	if pErr != nil || mErr != nil {
+		return false
+	}
+	pType, pSub, _ := strings.Cut(pattern, "/")
+	mType, mSub, _ := strings.Cut(mime, "/")
+	if pType != "*" && pType != mType {
+		return false
+	}
+	switch {
+	case pSub == "*":
+	case strings.HasPrefix(pSub, "*+"):
+		if !strings.HasSuffix(mSub, pSub[1:]) {
+			return false
+		}
+	case pSub != mSub:
+		return false
+	}
+	for name, want := range pParams {
+		if have, present := mParams[name]; !present {
+			return false
+		} else if name == "charset" && !CharsetEqual(want, have) {
+			return false
+		} else if name != "charset" && !strings.EqualFold(want, have) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAny returns true if the given `mime` satisfies any of the `patterns`
+func MatchAny(mime string, patterns ...string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, mime) {
+			return true
+		}
+	}
+	return false
+}
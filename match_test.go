@@ -0,0 +1,49 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMatch(t *testing.T) {
+	Convey("Match", t, func() {
+		So(Match("*/*", "image/png"), ShouldBeTrue)
+		So(Match("image/*", "image/png"), ShouldBeTrue)
+		So(Match("image/*", "text/png"), ShouldBeFalse)
+		So(Match("text/html", "Text/HTML; charset=utf-8"), ShouldBeTrue)
+		So(Match("text/html", "text/plain"), ShouldBeFalse)
+		So(Match("application/*+json", "application/vnd.api+json"), ShouldBeTrue)
+		So(Match("application/*+json", "application/json"), ShouldBeFalse)
+		So(Match("*/*+xml", "image/svg+xml"), ShouldBeTrue)
+		So(Match("", "text/plain"), ShouldBeFalse)
+		So(Match("text/plain", ""), ShouldBeFalse)
+		So(Match("*", "video/mp4"), ShouldBeTrue)
+		So(Match("text/plain; charset=utf-8", "text/plain; charset=UTF8"), ShouldBeTrue)
+		So(Match("text/plain; charset=utf-8", "text/plain"), ShouldBeFalse)
+		So(Match("text/plain; charset=utf-8", "text/plain; charset=latin1"), ShouldBeFalse)
+		So(Match("text/*; format=Flowed", "text/plain; format=flowed; charset=utf-8"), ShouldBeTrue)
+		So(Match("text/*; format=flowed", "text/plain; format=fixed"), ShouldBeFalse)
+		So(Match("text/plain", "not a type"), ShouldBeFalse)
+	})
+
+	Convey("MatchAny", t, func() {
+		So(MatchAny("image/png", "text/*", "image/*"), ShouldBeTrue)
+		So(MatchAny("video/mp4", "text/*", "image/*"), ShouldBeFalse)
+		So(MatchAny("video/mp4"), ShouldBeFalse)
+	})
+}
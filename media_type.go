@@ -0,0 +1,216 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"fmt"
+	"io"
+	goMime "mime"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/unicode/norm"
+)
+
+// gWordDecoder decodes RFC 2047 encoded-words in any charset known to the
+// WHATWG encoding index
+var gWordDecoder = &goMime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		enc, err := htmlindex.Get(charset)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported charset: %q", charset)
+		}
+		return enc.NewDecoder().Reader(input), nil
+	},
+}
+
+// MediaType is a parsed media type value, such as "text/enjin; charset=utf-8"
+type MediaType struct {
+	// Type is the lowercased top-level type, such as "text"
+	Type string
+	// Subtype is the lowercased subtype, including any structured syntax
+	// suffix, such as "ld+json"
+	Subtype string
+	// Suffix is the structured syntax suffix of the Subtype without the
+	// plus sign, such as "json", or empty when there is none
+	Suffix string
+	// Params are the parameters, with lowercased names
+	Params map[string]string
+}
+
+// ParseMediaType parses the given `value` into a MediaType using
+// mime.ParseMediaType, which decodes RFC 2231 extended parameters such as
+// the percent-encoded "filename*" form. Parameter values consisting of RFC
+// 2047 encoded-words, as sent by some clients for non-ASCII filenames, are
+// decoded as well. Values without a subtype, such as Content-Disposition
+// values, are also accepted
+func ParseMediaType(value string) (mt MediaType, err error) {
+	var mediatype string
+	if mediatype, mt.Params, err = goMime.ParseMediaType(value); err != nil {
+		mt.Params = nil
+		return
+	}
+	for name, param := range mt.Params {
+		if strings.Contains(param, "=?") {
+			if decoded, ee := gWordDecoder.DecodeHeader(param); ee == nil {
+				mt.Params[name] = decoded
+			}
+		}
+	}
+	mt.Type, mt.Subtype, _ = strings.Cut(mediatype, "/")
+	if idx := strings.LastIndex(mt.Subtype, "+"); idx >= 0 {
+		mt.Suffix = mt.Subtype[idx+1:]
+	}
+	if len(mt.Params) == 0 {
+		mt.Params = nil
+	}
+	return
+}
+
+// IsZero returns true if the MediaType has no Type
+func (m MediaType) IsZero() bool {
+	return m.Type == ""
+}
+
+// Essence returns the "type/subtype" portion of the MediaType, without any
+// parameters. Values without a subtype return only the Type
+func (m MediaType) Essence() string {
+	if m.IsZero() {
+		return ""
+	} else if m.Subtype == "" {
+		return m.Type
+	}
+	return m.Type + "/" + m.Subtype
+}
+
+// Param returns the value of the named parameter, the `name` is
+// case-insensitive
+func (m MediaType) Param(name string) (value string) {
+	return m.Params[strings.ToLower(name)]
+}
+
+// Charset returns the value of the charset parameter
+func (m MediaType) Charset() (charset string) {
+	return m.Param("charset")
+}
+
+// WithParam returns a copy of the MediaType with the named parameter set to
+// the given `value`, an empty `value` removes the parameter
+func (m MediaType) WithParam(name, value string) (modified MediaType) {
+	modified = m
+	modified.Params = make(map[string]string, len(m.Params)+1)
+	for k, v := range m.Params {
+		modified.Params[k] = v
+	}
+	if name = strings.ToLower(name); value == "" {
+		delete(modified.Params, name)
+	} else {
+		modified.Params[name] = value
+	}
+	if len(modified.Params) == 0 {
+		modified.Params = nil
+	}
+	return
+}
+
+// String returns the MediaType formatted with mime.FormatMediaType, with the
+// parameters in sorted order
+func (m MediaType) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	return goMime.FormatMediaType(m.Essence(), m.Params)
+}
+
+// Header returns the MediaType formatted for use as a header value. Unlike
+// String, each parameter with a non-ASCII value is written both as an ASCII
+// approximation and in the RFC 2231 extended form, for example:
+//
+//	attachment; filename="naive.txt"; filename*=utf-8''na%C3%AFve.txt
+//
+// as recommended by RFC 6266 so that clients lacking RFC 2231 support still
+// receive a usable filename
+func (m MediaType) Header() string {
+	if m.IsZero() {
+		return ""
+	}
+	params := make(map[string]string, len(m.Params))
+	names := make([]string, 0, len(m.Params))
+	for name, value := range m.Params {
+		names = append(names, name)
+		params[name] = value
+	}
+	sort.Strings(names)
+	var extended []string
+	for _, name := range names {
+		if value := params[name]; !isASCII(value) {
+			params[name] = asciiFallback(value)
+			extended = append(extended, name+"*=utf-8''"+percentEncode2231(value))
+		}
+	}
+	header := goMime.FormatMediaType(m.Essence(), params)
+	if header == "" {
+		return ""
+	}
+	for _, ext := range extended {
+		header += "; " + ext
+	}
+	return header
+}
+
+func isASCII(value string) bool {
+	for idx := 0; idx < len(value); idx++ {
+		if value[idx] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiFallback approximates `value` in ASCII, removing diacritics and
+// replacing any other non-ASCII characters with underscores
+func asciiFallback(value string) (fallback string) {
+	var buf strings.Builder
+	for _, r := range norm.NFD.String(value) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case r < utf8.RuneSelf:
+			buf.WriteRune(r)
+		default:
+			buf.WriteByte('_')
+		}
+	}
+	return buf.String()
+}
+
+// percentEncode2231 encodes `value` as an RFC 2231 extended value, without
+// the charset and language prefix
+func percentEncode2231(value string) (encoded string) {
+	var buf strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+			buf.WriteByte(b)
+		case strings.IndexByte("!#$&+-.^_`|~", b) >= 0:
+			buf.WriteByte(b)
+		default:
+			buf.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return buf.String()
+}
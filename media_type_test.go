@@ -0,0 +1,88 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMediaType(t *testing.T) {
+	Convey("ParseMediaType", t, func() {
+		mt, err := ParseMediaType("Text/Enjin; Charset=UTF-8")
+		So(err, ShouldBeNil)
+		So(mt.Type, ShouldEqual, "text")
+		So(mt.Subtype, ShouldEqual, "enjin")
+		So(mt.Suffix, ShouldBeEmpty)
+		So(mt.Essence(), ShouldEqual, EnjinMimeType)
+		So(mt.Charset(), ShouldEqual, "UTF-8")
+		So(mt.Param("CHARSET"), ShouldEqual, "UTF-8")
+		So(mt.String(), ShouldEqual, "text/enjin; charset=UTF-8")
+
+		mt, err = ParseMediaType(`application/ld+json; profile="http://www.w3.org/ns/json-ld#compacted"`)
+		So(err, ShouldBeNil)
+		So(mt.Subtype, ShouldEqual, "ld+json")
+		So(mt.Suffix, ShouldEqual, "json")
+		So(mt.String(), ShouldEqual, `application/ld+json; profile="http://www.w3.org/ns/json-ld#compacted"`)
+
+		mt, err = ParseMediaType("image/png")
+		So(err, ShouldBeNil)
+		So(mt.Params, ShouldBeNil)
+
+		mt, err = ParseMediaType("not a media type")
+		So(err, ShouldNotBeNil)
+		So(mt.IsZero(), ShouldBeTrue)
+		So(mt.String(), ShouldBeEmpty)
+		So(mt.Essence(), ShouldBeEmpty)
+	})
+
+	Convey("WithParam", t, func() {
+		mt, _ := ParseMediaType("text/html")
+		withCharset := mt.WithParam("Charset", "utf-8")
+		So(withCharset.String(), ShouldEqual, "text/html; charset=utf-8")
+		So(mt.String(), ShouldEqual, "text/html")
+		So(withCharset.WithParam("charset", "").String(), ShouldEqual, "text/html")
+	})
+
+	Convey("Extended parameters", t, func() {
+		mt, err := ParseMediaType(`attachment; filename*=UTF-8''na%C3%AFve%20r%C3%A9sum%C3%A9.txt`)
+		So(err, ShouldBeNil)
+		So(mt.Essence(), ShouldEqual, "attachment")
+		So(mt.Param("filename"), ShouldEqual, "naïve résumé.txt")
+
+		mt, err = ParseMediaType(`attachment; filename="=?UTF-8?B?0L/RgNC40LLQtdGCLnR4dA==?="`)
+		So(err, ShouldBeNil)
+		So(mt.Param("filename"), ShouldEqual, "привет.txt")
+		mt, err = ParseMediaType(`attachment; filename="=?ISO-8859-1?Q?caf=E9.txt?="`)
+		So(err, ShouldBeNil)
+		So(mt.Param("filename"), ShouldEqual, "café.txt")
+		mt, err = ParseMediaType(`attachment; filename="=?bogus?Q?x?="`)
+		So(err, ShouldBeNil)
+		So(mt.Param("filename"), ShouldEqual, "=?bogus?Q?x?=")
+
+		mt = MediaType{Type: "attachment"}.WithParam("filename", "naïve résumé.txt")
+		So(mt.Header(), ShouldEqual, `attachment; filename="naive resume.txt"; filename*=utf-8''na%C3%AFve%20r%C3%A9sum%C3%A9.txt`)
+		mt = mt.WithParam("filename", "привет.txt")
+		So(mt.Header(), ShouldEqual, `attachment; filename=______.txt; filename*=utf-8''%D0%BF%D1%80%D0%B8%D0%B2%D0%B5%D1%82.txt`)
+		mt = MediaType{Type: "text", Subtype: "plain"}.WithParam("charset", "utf-8")
+		So(mt.Header(), ShouldEqual, mt.String())
+		So(MediaType{}.Header(), ShouldEqual, "")
+
+		parsed, err := ParseMediaType(MediaType{Type: "attachment"}.WithParam("filename", "naïve résumé.txt").Header())
+		So(err, ShouldBeNil)
+		So(parsed.Param("filename"), ShouldEqual, "naïve résumé.txt")
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"net/http"
+)
+
+// Middleware returns an http.Handler which fills in a missing Content-Type
+// response header before `next` writes the response status. The type is
+// taken from FromPathOnly of the request URL path and, failing that, from
+// DetectBytes of the response body. Like net/http, the body is buffered up
+// to the GetReadLimit before it is detected, unless the handler calls Flush
+// or returns first. Responses with a status of 204 or 304, or with a
+// Content-Encoding set, are never sniffed. Handlers that set the
+// Content-Type header themselves are not affected
+func (r *Registry) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tw := &typedResponseWriter{ResponseWriter: w, registry: r, path: req.URL.Path, limit: r.GetReadLimit()}
+		next.ServeHTTP(tw, req)
+		_ = tw.finish()
+	})
+}
+
+// Middleware returns an http.Handler which fills in missing Content-Type
+// response headers using the Registry attached to the request context (see
+// WithContext), or the default Registry. See Registry.Middleware for details
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		FromContext(req.Context()).Middleware(next).ServeHTTP(w, req)
+	})
+}
+
+// typedResponseWriter delays the response status until the Content-Type can
+// be determined, buffering the leading `limit` bytes of the body when the
+// type is detected from the content
+type typedResponseWriter struct {
+	http.ResponseWriter
+	registry *Registry
+	path     string
+	limit    int
+	buffer   []byte
+	status   int
+	written  bool
+}
+
+func (w *typedResponseWriter) WriteHeader(status int) {
+	if status >= 100 && status < 200 {
+		// informational responses are not the final status
+		w.ResponseWriter.WriteHeader(status)
+		return
+	} else if w.written || w.status != 0 {
+		return
+	}
+	w.status = status
+	if w.ResponseWriter.Header().Get("Content-Type") != "" || !w.sniffable() {
+		w.flush()
+		return
+	} else if mime := w.registry.FromPathOnly(w.path); mime != "" {
+		w.ResponseWriter.Header().Set("Content-Type", mime)
+		w.flush()
+	}
+	// otherwise the status is written along with the buffered body bytes
+}
+
+func (w *typedResponseWriter) Write(data []byte) (n int, err error) {
+	if !w.written && w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.written {
+		return w.ResponseWriter.Write(data)
+	}
+	w.buffer = append(w.buffer, data...)
+	if len(w.buffer) >= w.limit {
+		if err = w.sniff(); err != nil {
+			return
+		}
+	}
+	return len(data), nil
+}
+
+// Flush implements http.Flusher, writing any delayed status and buffered
+// body bytes first
+func (w *typedResponseWriter) Flush() {
+	if w.status != 0 {
+		_ = w.sniff()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, for http.ResponseController
+func (w *typedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// sniffable returns true if the response may carry a body of detectable
+// content
+func (w *typedResponseWriter) sniffable() bool {
+	switch {
+	case w.status == http.StatusNoContent, w.status == http.StatusNotModified:
+		return false
+	case w.ResponseWriter.Header().Get("Content-Encoding") != "":
+		return false
+	}
+	return true
+}
+
+// sniff detects the Content-Type of the buffered body bytes, unless already
+// set, and writes the delayed status followed by the buffered bytes, once
+func (w *typedResponseWriter) sniff() (err error) {
+	if w.written {
+		return
+	}
+	if len(w.buffer) > 0 && w.ResponseWriter.Header().Get("Content-Type") == "" {
+		w.ResponseWriter.Header().Set("Content-Type", w.registry.DetectBytes(w.buffer))
+	}
+	w.flush()
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) > 0 {
+		_, err = w.ResponseWriter.Write(buffer)
+	}
+	return
+}
+
+// flush writes the delayed status, once
+func (w *typedResponseWriter) flush() {
+	if !w.written {
+		w.written = true
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+// finish writes any delayed status and buffered body bytes for handlers
+// which returned before the buffer was filled
+func (w *typedResponseWriter) finish() (err error) {
+	if w.status != 0 {
+		err = w.sniff()
+	}
+	return
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	serve := func(h http.Handler, path string) (rec *httptest.ResponseRecorder) {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return
+	}
+
+	Convey("Middleware", t, func() {
+		r := New()
+		body := func(data []byte) http.HandlerFunc {
+			return func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(data)
+			}
+		}
+
+		Convey("from the request path", func() {
+			rec := serve(r.Middleware(body([]byte("* heading"))), "/pages/about.org")
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/org-mode; charset=utf-8")
+			rec = serve(r.Middleware(body([]byte("+++"))), "/pages/index.njn")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/enjin; charset=utf-8")
+		})
+
+		Convey("from the response content", func() {
+			rec := serve(r.Middleware(body(png)), "/images/logo")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "image/png")
+			So(rec.Body.Len(), ShouldEqual, len(png))
+		})
+
+		Convey("plain text and chunked writes", func() {
+			rec := serve(r.Middleware(body([]byte("OK\n"))), "/health")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/plain; charset=utf-8")
+			So(rec.Body.String(), ShouldEqual, "OK\n")
+
+			chunked := func(chunks ...[]byte) http.HandlerFunc {
+				return func(w http.ResponseWriter, _ *http.Request) {
+					for _, chunk := range chunks {
+						_, _ = w.Write(chunk)
+					}
+				}
+			}
+			rec = serve(r.Middleware(chunked(png[:1], png[1:])), "/images/logo")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "image/png")
+			So(rec.Body.Bytes(), ShouldResemble, png)
+
+			large := bytes.Repeat([]byte("plain words\n"), r.GetReadLimit())
+			rec = serve(r.Middleware(chunked([]byte("p"), large)), "/large")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/plain; charset=utf-8")
+			So(rec.Body.Len(), ShouldEqual, len(large)+1)
+
+			flushed := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("partial"))
+				w.(http.Flusher).Flush()
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/plain; charset=utf-8")
+				_, _ = w.Write(png)
+			}))
+			rec = serve(flushed, "/stream")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/plain; charset=utf-8")
+			So(rec.Body.Len(), ShouldEqual, len("partial")+len(png))
+		})
+
+		Convey("explicit headers and statuses", func() {
+			h := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/x-custom")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write(png)
+			}))
+			rec := serve(h, "/file.org")
+			So(rec.Code, ShouldEqual, http.StatusCreated)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "application/x-custom")
+
+			h = r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write(png)
+			}))
+			rec = serve(h, "/upload")
+			So(rec.Code, ShouldEqual, http.StatusAccepted)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "image/png")
+
+			h = r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			rec = serve(h, "/nothing")
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "")
+
+			h = r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			rec = serve(h, "/missing")
+			So(rec.Code, ShouldEqual, http.StatusNotFound)
+
+			h = r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Encoding", "gzip")
+				_, _ = w.Write(png)
+			}))
+			rec = serve(h, "/compressed")
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "")
+		})
+
+		Convey("registry from the request context", func() {
+			custom := New()
+			custom.SetExtension("thing", "application/x-thing")
+			h := Middleware(body([]byte("data")))
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/a.thing", nil)
+			h.ServeHTTP(rec, req.WithContext(WithContext(req.Context(), custom)))
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "application/x-thing")
+		})
+	})
+}
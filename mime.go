@@ -21,8 +21,6 @@ import (
 	"strings"
 
 	"github.com/gabriel-vasile/mimetype"
-
-	clPath "github.com/go-corelibs/path"
 )
 
 const (
@@ -31,11 +29,30 @@ const (
 	CssMimeType        = "text/css"
 	ScssMimeType       = "text/x-scss"
 	JsonMimeType       = "application/json"
+	XmlMimeType        = "text/xml"
+	NdjsonMimeType     = "application/x-ndjson"
+	GeoJsonMimeType    = "application/geo+json"
 	JavaScriptMimeType = "text/javascript"
 	BinaryMimeType     = "application/octet-stream"
+	ZipMimeType        = "application/zip"
+	Mp3MimeType        = "audio/mpeg"
+	IsoMimeType        = "application/x-iso9660-image"
+	TarMimeType        = "application/x-tar"
+	GzipMimeType       = "application/gzip"
+	PhpMimeType        = "text/x-php"
 
 	// DirectoryMimeType defines the mime type used for filesystem directories
 	DirectoryMimeType = "inode/directory"
+	// SymlinkMimeType defines the mime type used for symbolic links
+	SymlinkMimeType = "inode/symlink"
+	// FifoMimeType defines the mime type used for named pipes
+	FifoMimeType = "inode/fifo"
+	// SocketMimeType defines the mime type used for unix domain sockets
+	SocketMimeType = "inode/socket"
+	// BlockDeviceMimeType defines the mime type used for block devices
+	BlockDeviceMimeType = "inode/blockdevice"
+	// CharDeviceMimeType defines the mime type used for character devices
+	CharDeviceMimeType = "inode/chardevice"
 	// EnjinMimeType defines the mime type for the Go-Enjin project's `njn`
 	// page format
 	EnjinMimeType = "text/enjin"
@@ -59,44 +76,32 @@ const (
 	MarkdownExtension = "md"
 )
 
-var (
-	gExtension = &lookup{m: map[string]string{
-		"txt":  TextMimeType + "; charset=utf-8",
-		"html": HtmlMimeType + "; charset=utf-8",
-		"css":  CssMimeType + "; charset=utf-8",
-		"scss": ScssMimeType + "; charset=utf-8",
-		"json": JsonMimeType + "; charset=utf-8",
-		"js":   JavaScriptMimeType + "; charset=utf-8",
-	}}
-	gCharset = &lookup{m: map[string]string{
-		TextMimeType:       "utf-8",
-		HtmlMimeType:       "utf-8",
-		CssMimeType:        "utf-8",
-		ScssMimeType:       "utf-8",
-		JsonMimeType:       "utf-8",
-		JavaScriptMimeType: "utf-8",
-		EnjinMimeType:      "utf-8",
-		OrgModeMimeType:    "utf-8",
-		MarkdownMimeType:   "utf-8",
-	}}
-)
-
 func init() {
-	_ = RegisterTextType(EnjinMimeType, EnjinExtension, nil)
-	_ = RegisterTextType(OrgModeMimeType, OrgModeExtension, nil)
-	_ = RegisterTextType(MarkdownMimeType, MarkdownExtension, nil)
+	// the page formats are registered with content detectors of their own,
+	// or by extension only, so that other text is not claimed by any of them
+	initError(registerTextExtension(EnjinMimeType, EnjinExtension))
+	initError(RegisterTextTypePriority(MarkdownMimeType, MarkdownExtension, MarkdownDetector, -1))
+	initError(RegisterTextTypePriority(OrgModeMimeType, OrgModeExtension, OrgModeDetector, -1))
+	// content detectors registered after the page formats above so that they
+	// are checked first
+	registerEmailTypes()
+	registerCardTypes()
+	registerConfigTypes()
+	snapshotPristine()
 }
 
 // GetExtension returns the mime type internally associated with this package
 // using SetExtension, or if not present uses mime.TypeByExtension to lookup
 // further
 func GetExtension(extension string) (mime string, ok bool) {
-	extension = strings.TrimPrefix(extension, ".")
-	if mime, ok = gExtension.get(extension); !ok {
-		mime = goMime.TypeByExtension("." + extension)
-		ok = mime != ""
-	}
-	return
+	return gRegistry.GetExtension(extension)
+}
+
+// GetExtensionRaw is the same as GetExtension except that the mime type is
+// returned exactly as registered instead of in the canonical form produced
+// by Normalize
+func GetExtensionRaw(extension string) (mime string, ok bool) {
+	return gRegistry.GetExtensionRaw(extension)
 }
 
 // SetExtension registers the given extension with the given mime type string.
@@ -104,129 +109,228 @@ func GetExtension(extension string) (mime string, ok bool) {
 // will overwrite any existing value. If `mime` is empty, any internal
 // association with the extension is cleared
 func SetExtension(extension, mime string) {
-	extension = strings.TrimPrefix(extension, ".")
-	if mime == "" {
-		gExtension.unset(extension)
-		return
-	}
-	gExtension.set(extension, mime)
+	gRegistry.SetExtension(extension, mime)
 }
 
-// GetCharset returns the `charset` internally associated with this package
+// GetCharset returns the `charset` internally associated with this package,
+// see Registry.GetCharset for how charset parameters are handled
 func GetCharset(mime string) (charset string, ok bool) {
-	charset, ok = gCharset.get(PruneCharset(mime))
-	return
+	return gRegistry.GetCharset(mime)
 }
 
 // SetCharset registers the given extension with the given charset string.
 // There can only be one charset associated per extension and SetCharset
 // will overwrite any existing value
 func SetCharset(mime, charset string) {
-	mime = PruneCharset(mime)
-	if charset == "" {
-		gCharset.unset(mime)
-		return
-	}
-	gCharset.set(mime, charset)
+	gRegistry.SetCharset(mime, charset)
+}
+
+// GetAlias returns the canonical mime type internally associated with the
+// given alias
+func GetAlias(alias string) (canonical string, ok bool) {
+	return gRegistry.GetAlias(alias)
+}
+
+// SetAlias registers the given alias as another name for the canonical mime
+// type. If `canonical` is empty, any internal association with the alias is
+// cleared
+func SetAlias(alias, canonical string) {
+	gRegistry.SetAlias(alias, canonical)
 }
 
 // PruneCharset uses mime.ParseMediaType to parse the given mime string and
-// returns only the media type value
+// returns only the media type value. Parse failures of non-empty values are
+// reported to the logger of the default Registry
 func PruneCharset(mime string) (pruned string) {
-	pruned, _, _ = goMime.ParseMediaType(mime)
+	var err error
+	if pruned, _, err = goMime.ParseMediaType(mime); err != nil && mime != "" {
+		gRegistry.logWarn("mime: unable to parse media type", "mime", mime, "err", err)
+	}
 	return
 }
 
 // RegisterTextType associates the given `mime` with the given `extension` and
 // if the `detector` is not nil, registers the given `mime` with TextMimeType
-// as it's parent within the github.com/gabriel-vasile/mimetype system
+// as it's parent within the github.com/gabriel-vasile/mimetype system. The
+// `mime` is associated with the Options.DefaultCharset of the default
+// Registry
 func RegisterTextType(mime, extension string, detector func(raw []byte, limit uint32) bool) (err error) {
-	extension = strings.TrimPrefix(extension, ".")
+	return RegisterTextTypePriority(mime, extension, detector, 0)
+}
+
+// RegisterTextTypePriority is RegisterTextType with an explicit Detector
+// Priority. Whenever content matches more than one of the registered text
+// types, the one reported is chosen by a deterministic chain instead of the
+// github.com/gabriel-vasile/mimetype registration order: the types having a
+// `detector` are consulted first, highest priority first, followed by the
+// catch-all types registered without a `detector`, again highest priority
+// first. Equal priorities are consulted most recently registered first
+func RegisterTextTypePriority(mime, extension string, detector func(raw []byte, limit uint32) bool, priority int) (err error) {
+	defer gRegistry.logRegistration(mime, &err)
 	var mediatype string
-	if mime == "" || extension == "" {
-		err = errors.New("mime and extension arguments must not be empty")
-		return
-	} else if m, p, e := goMime.ParseMediaType(mime); e != nil {
-		err = e
+	charset := gRegistry.defaultCharset()
+	if mime, mediatype, extension, err = parseTextType(mime, extension, charset); err != nil {
 		return
-	} else {
-		p["charset"] = "utf-8"
-		mime = goMime.FormatMediaType(m, p)
-		mediatype = m
 	}
 	SetExtension(extension, mime)
-	SetCharset(mediatype, "utf-8")
+	SetCharset(mediatype, charset)
+	if detector != nil {
+		gTextDetectors.add(Detector{Mime: mime, Detect: detector, Priority: priority})
+	} else {
+		gCatchAllDetectors.add(Detector{Mime: mime, Detect: PlainTextDetector, Priority: priority})
+	}
 	for _, m := range []string{mediatype, mime} {
 		if detector != nil {
-			mimetype.Lookup(TextMimeType).Extend(detector, m, "."+extension)
+			graftDetector(mimetype.Lookup(TextMimeType), detector, m, "."+extension)
 		} else {
-			mimetype.Lookup(TextMimeType).Extend(PlainTextDetector, m, "."+extension)
+			graftDetector(mimetype.Lookup(TextMimeType), PlainTextDetector, m, "."+extension)
 		}
 	}
-	err = goMime.AddExtensionType("."+extension, mediatype)
+	err = addExtensionType("."+extension, mediatype)
 	return
 }
 
-// IsPlainText returns true if the given `mime` is of `text/plain` type.
-// IsPlainText checks if it has an internally registered charset first
-// and if so, returns true early. If the `mime` is not internally registered
-// with a charset (via SetCharset), IsPlainText uses
-// github.com/gabriel-vasile/mimetype.Lookup to check if the given `mime`
-// is exactly TextMimeType or if any of the found mime type's parents are
-// TextMimeType
-func IsPlainText(mime string) (yes bool) {
-	mime = PruneCharset(mime)
-	if _, yes = GetCharset(mime); yes {
+// registerTextExtension registers the text `mime` type by its `extension`
+// alone, without any content detection, as a child of TextMimeType within
+// the default Registry
+func registerTextExtension(mime, extension string) (err error) {
+	var mediatype string
+	charset := gRegistry.defaultCharset()
+	if mime, mediatype, extension, err = parseTextType(mime, extension, charset); err != nil {
 		return
 	}
-	if mt := mimetype.Lookup(mime); mt != nil {
-		if yes = mt.Is(TextMimeType); yes {
+	SetExtension(extension, mime)
+	SetCharset(mediatype, charset)
+	SetParent(mediatype, TextMimeType)
+	err = addExtensionType("."+extension, mediatype)
+	return
+}
+
+// RegisterTextTypeExt is RegisterTextType for a `mime` type having more than
+// one extension, such as "markdown" and "md". Every one of the `extensions`
+// is associated with the `mime` type, including with the standard library
+// mime.ExtensionsByType, and is returned by ExtensionsByType. Nothing is
+// registered when any of the `extensions` are empty
+func RegisterTextTypeExt(mime string, detector func(raw []byte, limit uint32) bool, extensions ...string) (err error) {
+	defer gRegistry.logRegistration(mime, &err)
+	var mediatype string
+	if mime, mediatype, extensions, err = parseTextTypeExt(mime, gRegistry.defaultCharset(), extensions); err != nil {
+		return
+	} else if err = RegisterTextType(mime, extensions[0], detector); err != nil {
+		return
+	}
+	for _, extension := range extensions[1:] {
+		SetExtension(extension, mime)
+		if err = addExtensionType("."+extension, mediatype); err != nil {
 			return
 		}
-		for check := mt; check != nil; check = check.Parent() {
-			if yes = check.Is(TextMimeType); yes {
-				return
-			}
+	}
+	return
+}
+
+// parseTextTypeExt is parseTextType for one or more `extensions`
+func parseTextTypeExt(mime, charset string, extensions []string) (withCharset, mediatype string, exts []string, err error) {
+	if len(extensions) == 0 {
+		err = errors.New("at least one extension argument is required")
+		return
+	}
+	exts = make([]string, len(extensions))
+	for idx, extension := range extensions {
+		if withCharset, mediatype, exts[idx], err = parseTextType(mime, extension, charset); err != nil {
+			return
 		}
 	}
 	return
 }
 
+// parseTextType validates the RegisterTextType arguments, returning the
+// `mime` with the given `charset`, the bare `mediatype` and the `extension`
+// without any leading period
+func parseTextType(mime, extension, charset string) (withCharset, mediatype, ext string, err error) {
+	ext = strings.TrimPrefix(extension, ".")
+	if mime == "" || ext == "" {
+		err = errors.New("mime and extension arguments must not be empty")
+		return
+	}
+	var params map[string]string
+	if mediatype, params, err = goMime.ParseMediaType(mime); err != nil {
+		return
+	}
+	params["charset"] = charset
+	withCharset = goMime.FormatMediaType(mediatype, params)
+	return
+}
+
+// IsPlainText returns true if the given `mime` is of `text/plain` type.
+// IsPlainText checks if it has an internally registered charset first
+// and if so, returns true early. Types with a textual structured syntax
+// suffix, such as +json or +xml, are also plain text. Otherwise, IsPlainText
+// uses IsKindOf to check if the given `mime` is exactly TextMimeType or if
+// any of its Ancestors are TextMimeType
+func IsPlainText(mime string) (yes bool) {
+	return gRegistry.IsPlainText(mime)
+}
+
+// IsTextual returns true if the given `mime` can reasonably be rendered as
+// text within the default Registry, see Registry.IsTextual
+func IsTextual(mime string) (yes bool) {
+	return gRegistry.IsTextual(mime)
+}
+
 // FromPathOnly checks the given `path` for any extensions using
 // github.com/go-corelibs/path.ExtExt and uses GetExtension with any
 // extensions found
 func FromPathOnly(path string) (mime string) {
-	if path != "" {
-		if a, b := clPath.ExtExt(path); b != "" && a == "tmpl" {
-			mime, _ = GetExtension(b)
-		} else if a != "" {
-			mime, _ = GetExtension(a)
-		}
-	}
-	return
+	return gRegistry.FromPathOnly(path)
 }
 
 // Mime returns the MIME type string of a local filesystem directory or file.
 // The specific type returned for directories is defined by the
 // DirectoryMimeType constant
 func Mime(path string) (mime string) {
-	if clPath.IsDir(path) {
-		mime = DirectoryMimeType
-		return
-	} else if clPath.IsFile(path) {
-		if mime = FromPathOnly(path); mime != "" {
-			return
-		} else if mt, err := mimetype.DetectFile(path); err == nil {
-			mime = mt.String()
-		}
-	}
-	return
+	return gRegistry.Mime(path)
 }
 
 // PlainTextDetector is the default detector used when RegisterTextType is
-// given a `nil` value for it's `detector` argument. PlainTextDetector always
-// returns true
+// given a `nil` value for it's `detector` argument. PlainTextDetector returns
+// true when the leading `limit` bytes of `raw` are empty or are text: content
+// with a DetectCharset result (which rules out null bytes outside of UTF-16)
+// where no more than one in ten characters is a control character other than
+// the common whitespace and escape characters. A `limit` of zero inspects all
+// of `raw`
 func PlainTextDetector(raw []byte, limit uint32) bool {
-	// TODO: figure out a better way of detecting plain text things, for example: Mime("./LICENSE") returns "text/markdown" when it should be "text/plain"
-	return true
+	if limit > 0 && uint32(len(raw)) > limit {
+		raw = raw[:limit]
+	}
+	if len(raw) == 0 {
+		return true
+	}
+
+	var runes []rune
+	switch charset := DetectCharset(raw); charset {
+	case "":
+		return false
+	case "utf-16be", "utf-16le":
+		_, size := DetectBOM(raw)
+		runes = decodeUTF16(raw[size:], charset == "utf-16be")
+	case "utf-32be", "utf-32le":
+		_, size := DetectBOM(raw)
+		runes = decodeUTF32(raw[size:], charset == "utf-32be")
+	default:
+		// control characters are all single bytes in every other charset
+		runes = make([]rune, len(raw))
+		for idx, b := range raw {
+			runes[idx] = rune(b)
+		}
+	}
+
+	var controls int
+	for _, r := range runes {
+		switch {
+		case r == '\t', r == '\n', r == '\r', r == '\f', r == '\v', r == 0x1b:
+		case r < 0x20, r == 0x7f:
+			controls += 1
+		}
+	}
+	return controls*10 <= len(runes)
 }
@@ -16,28 +16,84 @@ package mime
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
+// lookup is a copy-on-write string map: readers load the current immutable
+// map without locking while writers, serialized by the mutex, publish a
+// modified copy. Writes are therefore proportional to the size of the map,
+// use update to make many changes at once
 type lookup struct {
-	m map[string]string
-	sync.RWMutex
+	m atomic.Pointer[map[string]string]
+	// table and hooks are set by Registry.watchTables when the lookup is
+	// one of the Registry mapping tables
+	table Table
+	hooks *hookList
+	sync.Mutex
 }
 
-func (l *lookup) unset(k string) {
+func newLookup(m map[string]string) (l *lookup) {
+	l = &lookup{}
+	l.m.Store(&m)
+	return
+}
+
+// load returns the current map, which must not be modified
+func (l *lookup) load() (m map[string]string) {
+	return *l.m.Load()
+}
+
+// update publishes a copy of the current map modified by `fn`, notifying
+// any hooks once the lock is released
+func (l *lookup) update(fn func(m map[string]string)) {
+	var changes []change
+	defer func() { l.hooks.notify(changes) }()
 	l.Lock()
 	defer l.Unlock()
-	delete(l.m, k)
+	current := l.load()
+	m := make(map[string]string, len(current)+1)
+	for k, v := range current {
+		m[k] = v
+	}
+	fn(m)
+	if l.hooks.active() {
+		changes = diffChanges(l.table, current, m)
+	}
+	l.m.Store(&m)
+}
+
+// replace publishes a copy of the given map
+func (l *lookup) replace(m map[string]string) {
+	l.update(func(current map[string]string) {
+		clear(current)
+		for k, v := range m {
+			current[k] = v
+		}
+	})
+}
+
+func (l *lookup) unset(k string) {
+	l.update(func(m map[string]string) {
+		delete(m, k)
+	})
 }
 
 func (l *lookup) set(k, v string) {
-	l.Lock()
-	defer l.Unlock()
-	l.m[k] = v
+	l.update(func(m map[string]string) {
+		m[k] = v
+	})
 }
 
 func (l *lookup) get(k string) (v string, ok bool) {
-	l.RLock()
-	defer l.RUnlock()
-	v, ok = l.m[k]
+	v, ok = l.load()[k]
+	return
+}
+
+func (l *lookup) snapshot() (m map[string]string) {
+	current := l.load()
+	m = make(map[string]string, len(current))
+	for k, v := range current {
+		m[k] = v
+	}
 	return
 }
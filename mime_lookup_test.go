@@ -0,0 +1,73 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLookup(t *testing.T) {
+	Convey("lookup", t, func() {
+		l := newLookup(map[string]string{"a": "1"})
+		v, ok := l.get("a")
+		So(ok, ShouldBeTrue)
+		So(v, ShouldEqual, "1")
+
+		before := l.load()
+		l.set("b", "2")
+		So(before, ShouldHaveLength, 1)
+		So(l.load(), ShouldHaveLength, 2)
+
+		snapshot := l.snapshot()
+		l.unset("a")
+		_, ok = l.get("a")
+		So(ok, ShouldBeFalse)
+		So(snapshot, ShouldContainKey, "a")
+
+		l.update(func(m map[string]string) {
+			m["c"], m["d"] = "3", "4"
+		})
+		So(l.snapshot(), ShouldResemble, map[string]string{"b": "2", "c": "3", "d": "4"})
+
+		Convey("concurrent readers and writers", func() {
+			var wg sync.WaitGroup
+			for idx := 0; idx < 8; idx++ {
+				wg.Add(2)
+				go func(idx int) {
+					defer wg.Done()
+					for n := 0; n < 100; n++ {
+						l.set(strconv.Itoa(idx), strconv.Itoa(n))
+					}
+				}(idx)
+				go func() {
+					defer wg.Done()
+					for n := 0; n < 100; n++ {
+						_, _ = l.get("b")
+					}
+				}()
+			}
+			wg.Wait()
+			for idx := 0; idx < 8; idx++ {
+				v, ok = l.get(strconv.Itoa(idx))
+				So(ok, ShouldBeTrue)
+				So(v, ShouldEqual, "99")
+			}
+		})
+	})
+}
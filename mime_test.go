@@ -15,6 +15,8 @@
 package mime
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gabriel-vasile/mimetype"
@@ -125,6 +127,20 @@ func Test(t *testing.T) {
 		So(charset, ShouldBeEmpty)
 	})
 
+	Convey("SetAlias", t, func() {
+		canonical, ok := GetAlias("text/x-nope")
+		So(ok, ShouldBeFalse)
+		So(canonical, ShouldBeEmpty)
+		SetAlias("text/x-nope", "text/nope")
+		canonical, ok = GetAlias("text/x-nope")
+		So(ok, ShouldBeTrue)
+		So(canonical, ShouldEqual, "text/nope")
+		SetAlias("text/x-nope", "")
+		canonical, ok = GetAlias("text/x-nope")
+		So(ok, ShouldBeFalse)
+		So(canonical, ShouldBeEmpty)
+	})
+
 	Convey("PruneCharset", t, func() {
 		So(PruneCharset(""), ShouldBeEmpty)
 		So(PruneCharset("nope/plain"), ShouldEqual, "nope/plain")
@@ -134,6 +150,7 @@ func Test(t *testing.T) {
 	Convey("RegisterTextType", t, func() {
 		So(RegisterTextType("", "", nil), ShouldNotBeNil)
 		So(RegisterTextType("not/a-thing", "nope", nil), ShouldBeNil)
+		defer UnregisterType("not/a-thing")
 		var ok bool
 		var charset, mime string
 		charset, ok = GetCharset("not/a-thing")
@@ -178,6 +195,28 @@ func Test(t *testing.T) {
 		})
 	})
 
+	Convey("IsTextual", t, func() {
+		So(IsTextual("text/x-unheard-of"), ShouldBeTrue)
+		So(IsTextual("TEXT/CSV; charset=utf-8"), ShouldBeTrue)
+		So(IsTextual(JsonMimeType), ShouldBeTrue)
+		So(IsTextual("application/javascript"), ShouldBeTrue)
+		So(IsTextual("application/vnd.api+json"), ShouldBeTrue)
+		So(IsTextual("application/atom+xml"), ShouldBeTrue)
+		So(IsTextual(EnjinMimeType), ShouldBeTrue)
+		So(IsTextual("image/png"), ShouldBeFalse)
+		So(IsTextual(ZipMimeType), ShouldBeFalse)
+		So(IsTextual(""), ShouldBeFalse)
+
+		r := New()
+		So(r.IsPlainText("text/x-unheard-of"), ShouldBeFalse)
+		So(r.IsTextual("text/x-unheard-of"), ShouldBeTrue)
+		So(r.IsTextual("application/x-custom"), ShouldBeFalse)
+		r.SetCharset("application/x-custom", "utf-8")
+		So(r.IsTextual("application/x-custom"), ShouldBeTrue)
+		r.SetAlias("application/x-script", "application/javascript")
+		So(r.IsTextual("application/x-script"), ShouldBeTrue)
+	})
+
 	Convey("FromPathOnly", t, func() {
 		So(FromPathOnly("file.txt"), ShouldEqual, "text/plain; charset=utf-8")
 		So(FromPathOnly("file.html.tmpl"), ShouldEqual, "text/html; charset=utf-8")
@@ -187,10 +226,32 @@ func Test(t *testing.T) {
 		So(Mime("."), ShouldEqual, "inode/directory")
 		So(Mime("./testdata/README.md"), ShouldEqual, "text/markdown; charset=utf-8")
 		So(Mime("./testdata/empty-png"), ShouldEqual, "image/png")
+		So(Mime("./LICENSE"), ShouldEqual, "text/plain; charset=utf-8")
+	})
+
+	Convey("generic text", t, func() {
+		So(DetectBytes([]byte("hello world\n")), ShouldEqual, "text/plain; charset=utf-8")
+		So(DetectBytes(nil), ShouldEqual, TextMimeType)
+		So(DetectBytes([]byte("caf\xe9 au lait\n")), ShouldEqual, "text/plain; charset=iso-8859-1")
+		So(DetectBytes([]byte("notes\n<svg xmlns=\"http://www.w3.org/2000/svg\"/>")), ShouldEqual, "text/plain; charset=utf-8")
+		So(DetectBytes([]byte("Intro\n\n## Heading\n")), ShouldEqual, "text/markdown; charset=utf-8")
+		So(DetectBytes([]byte("#+TITLE: Home\n")), ShouldEqual, "text/org-mode; charset=utf-8")
+
+		notice := filepath.Join(t.TempDir(), "NOTICE")
+		So(os.WriteFile(notice, []byte("Copyright (c) 2024\n\nLicensed under the terms.\n"), 0o644), ShouldBeNil)
+		So(Mime(notice), ShouldEqual, "text/plain; charset=utf-8")
 	})
 
 	Convey("PlainTextDetector", t, func() {
 		So(PlainTextDetector([]byte("plain text"), 1024), ShouldBeTrue)
+		So(PlainTextDetector(nil, 1024), ShouldBeTrue)
+		So(PlainTextDetector([]byte("caf\xe9\r\n\tcr\xe8me\x1b[0m"), 1024), ShouldBeTrue)
+		So(PlainTextDetector([]byte("\xff\xfeh\x00i\x00"), 1024), ShouldBeTrue)
+		So(PlainTextDetector([]byte("text\x00with nulls"), 1024), ShouldBeFalse)
+		So(PlainTextDetector([]byte("\x01\x02\x03\x04 mostly control"), 1024), ShouldBeFalse)
+		So(PlainTextDetector([]byte("plain text\x00"), 10), ShouldBeTrue)
+		png, _ := os.ReadFile("./testdata/empty-png")
+		So(PlainTextDetector(png, 1024), ShouldBeFalse)
 	})
 
 }
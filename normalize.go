@@ -0,0 +1,45 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"strings"
+)
+
+// Normalize returns the canonical form of the given `mime` string: the type,
+// subtype and parameter names are lowercased, the charset parameter value is
+// lowercased (charset names are case-insensitive) and all other parameter
+// values are preserved as-is. If `mime` cannot be parsed, only the portion
+// before the first semicolon is lowercased
+func Normalize(mime string) (normalized string) {
+	if mime == "" {
+		return
+	}
+	if mediatype, params, err := goMime.ParseMediaType(mime); err == nil {
+		if charset, ok := params["charset"]; ok {
+			params["charset"] = strings.ToLower(charset)
+		}
+		if normalized = goMime.FormatMediaType(mediatype, params); normalized != "" {
+			return
+		}
+	}
+	mediatype, rest, found := strings.Cut(mime, ";")
+	normalized = strings.ToLower(strings.TrimSpace(mediatype))
+	if found {
+		normalized += ";" + rest
+	}
+	return
+}
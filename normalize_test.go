@@ -0,0 +1,51 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNormalize(t *testing.T) {
+	Convey("Normalize", t, func() {
+		So(Normalize(""), ShouldBeEmpty)
+		So(Normalize("Text/HTML"), ShouldEqual, "text/html")
+		So(Normalize("Text/HTML; Charset=UTF-8"), ShouldEqual, "text/html; charset=utf-8")
+		So(Normalize("multipart/Mixed; Boundary=AbC"), ShouldEqual, "multipart/mixed; boundary=AbC")
+		So(Normalize("Not A Type; X=Y"), ShouldEqual, "not a type; X=Y")
+	})
+
+	Convey("GetExtension normalization", t, func() {
+		r := New()
+		r.SetExtension("thing", "Application/X-Thing; Version=A")
+		mime, ok := r.GetExtension("thing")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "application/x-thing; version=A")
+		mime, ok = r.GetExtensionRaw("thing")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "Application/X-Thing; Version=A")
+		_, ok = r.GetExtension("not-a-thing")
+		So(ok, ShouldBeFalse)
+
+		SetExtension("normal-thing", "Text/X-Thing")
+		mime, _ = GetExtension("normal-thing")
+		So(mime, ShouldEqual, "text/x-thing")
+		mime, _ = GetExtensionRaw("normal-thing")
+		So(mime, ShouldEqual, "Text/X-Thing")
+		SetExtension("normal-thing", "")
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+const (
+	// DocxMimeType is the Office Open XML word-processing document type
+	DocxMimeType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	// DotxMimeType is the Office Open XML word-processing template type
+	DotxMimeType = "application/vnd.openxmlformats-officedocument.wordprocessingml.template"
+	// DocmMimeType is the macro-enabled Office Open XML word-processing
+	// document type
+	DocmMimeType = "application/vnd.ms-word.document.macroenabled.12"
+	// XlsxMimeType is the Office Open XML spreadsheet type
+	XlsxMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	// XltxMimeType is the Office Open XML spreadsheet template type
+	XltxMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.template"
+	// XlsmMimeType is the macro-enabled Office Open XML spreadsheet type
+	XlsmMimeType = "application/vnd.ms-excel.sheet.macroenabled.12"
+	// PptxMimeType is the Office Open XML presentation type
+	PptxMimeType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	// PpsxMimeType is the Office Open XML slideshow type
+	PpsxMimeType = "application/vnd.openxmlformats-officedocument.presentationml.slideshow"
+	// PotxMimeType is the Office Open XML presentation template type
+	PotxMimeType = "application/vnd.openxmlformats-officedocument.presentationml.template"
+	// PptmMimeType is the macro-enabled Office Open XML presentation type
+	PptmMimeType = "application/vnd.ms-powerpoint.presentation.macroenabled.12"
+
+	// OdtMimeType is the OpenDocument text type
+	OdtMimeType = "application/vnd.oasis.opendocument.text"
+	// OttMimeType is the OpenDocument text template type
+	OttMimeType = "application/vnd.oasis.opendocument.text-template"
+	// OdsMimeType is the OpenDocument spreadsheet type
+	OdsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+	// OtsMimeType is the OpenDocument spreadsheet template type
+	OtsMimeType = "application/vnd.oasis.opendocument.spreadsheet-template"
+	// OdpMimeType is the OpenDocument presentation type
+	OdpMimeType = "application/vnd.oasis.opendocument.presentation"
+	// OtpMimeType is the OpenDocument presentation template type
+	OtpMimeType = "application/vnd.oasis.opendocument.presentation-template"
+	// OdgMimeType is the OpenDocument graphics type
+	OdgMimeType = "application/vnd.oasis.opendocument.graphics"
+)
+
+// gOOXMLContentTypes are the [Content_Types].xml content types of the main
+// part of each Office Open XML type, used to tell the types apart
+var gOOXMLContentTypes = map[string]string{
+	DocxMimeType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml",
+	DotxMimeType: "application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml",
+	DocmMimeType: "application/vnd.ms-word.document.macroEnabled.main+xml",
+	XlsxMimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml",
+	XltxMimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml",
+	XlsmMimeType: "application/vnd.ms-excel.sheet.macroEnabled.main+xml",
+	PptxMimeType: "application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml",
+	PpsxMimeType: "application/vnd.openxmlformats-officedocument.presentationml.slideshow.main+xml",
+	PotxMimeType: "application/vnd.openxmlformats-officedocument.presentationml.template.main+xml",
+	PptmMimeType: "application/vnd.ms-powerpoint.presentation.macroEnabled.main+xml",
+}
+
+// OfficeFamily identifies the kind of office document a mime type represents
+type OfficeFamily uint8
+
+const (
+	// NotOffice is the OfficeFamily of anything not an office document
+	NotOffice OfficeFamily = iota
+	// WordProcessing is the OfficeFamily of text documents
+	WordProcessing
+	// Spreadsheet is the OfficeFamily of spreadsheets
+	Spreadsheet
+	// Presentation is the OfficeFamily of slide presentations
+	Presentation
+)
+
+// String returns the name of the OfficeFamily
+func (f OfficeFamily) String() string {
+	switch f {
+	case WordProcessing:
+		return "word-processing"
+	case Spreadsheet:
+		return "spreadsheet"
+	case Presentation:
+		return "presentation"
+	}
+	return "none"
+}
+
+var gOfficeFamilies = map[string]OfficeFamily{
+	// legacy office
+	"application/msword": WordProcessing,
+	DocmMimeType:         WordProcessing,
+	"application/vnd.ms-word.template.macroenabled.12": WordProcessing,
+	"application/rtf":          WordProcessing,
+	"application/vnd.ms-excel": Spreadsheet,
+	XlsmMimeType:               Spreadsheet,
+	"application/vnd.ms-excel.sheet.binary.macroenabled.12":   Spreadsheet,
+	"application/vnd.ms-excel.template.macroenabled.12":       Spreadsheet,
+	"application/vnd.ms-powerpoint":                           Presentation,
+	PptmMimeType:                                              Presentation,
+	"application/vnd.ms-powerpoint.slideshow.macroenabled.12": Presentation,
+	"application/vnd.ms-powerpoint.template.macroenabled.12":  Presentation,
+	// office open xml
+	DocxMimeType: WordProcessing,
+	DotxMimeType: WordProcessing,
+	XlsxMimeType: Spreadsheet,
+	XltxMimeType: Spreadsheet,
+	PptxMimeType: Presentation,
+	PpsxMimeType: Presentation,
+	PotxMimeType: Presentation,
+	// opendocument
+	OdtMimeType: WordProcessing,
+	OttMimeType: WordProcessing,
+	"application/vnd.oasis.opendocument.text-master":     WordProcessing,
+	"application/x-vnd.oasis.opendocument.text":          WordProcessing,
+	"application/x-vnd.oasis.opendocument.text-template": WordProcessing,
+	OdsMimeType: Spreadsheet,
+	OtsMimeType: Spreadsheet,
+	"application/x-vnd.oasis.opendocument.spreadsheet":          Spreadsheet,
+	"application/x-vnd.oasis.opendocument.spreadsheet-template": Spreadsheet,
+	OdpMimeType: Presentation,
+	OtpMimeType: Presentation,
+	"application/x-vnd.oasis.opendocument.presentation":          Presentation,
+	"application/x-vnd.oasis.opendocument.presentation-template": Presentation,
+}
+
+// GetOfficeFamily returns the OfficeFamily of the given `mime`, covering the
+// legacy Microsoft Office, Office Open XML and OpenDocument formats
+func GetOfficeFamily(mime string) (family OfficeFamily) {
+	return gOfficeFamilies[PruneCharset(mime)]
+}
+
+// IsDocument returns true if the given `mime` is a word-processing document
+func IsDocument(mime string) bool {
+	return GetOfficeFamily(mime) == WordProcessing
+}
+
+// IsSpreadsheet returns true if the given `mime` is a spreadsheet
+func IsSpreadsheet(mime string) bool {
+	return GetOfficeFamily(mime) == Spreadsheet
+}
+
+// IsPresentation returns true if the given `mime` is a slide presentation
+func IsPresentation(mime string) bool {
+	return GetOfficeFamily(mime) == Presentation
+}
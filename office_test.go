@@ -0,0 +1,93 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOfficeFamily(t *testing.T) {
+	Convey("GetOfficeFamily", t, func() {
+		So(GetOfficeFamily("application/msword"), ShouldEqual, WordProcessing)
+		So(GetOfficeFamily("Application/VND.MS-Word.Document.MacroEnabled.12"), ShouldEqual, WordProcessing)
+		So(GetOfficeFamily("application/vnd.oasis.opendocument.spreadsheet"), ShouldEqual, Spreadsheet)
+		So(GetOfficeFamily("application/vnd.openxmlformats-officedocument.presentationml.presentation"), ShouldEqual, Presentation)
+		So(GetOfficeFamily("image/png"), ShouldEqual, NotOffice)
+		So(WordProcessing.String(), ShouldEqual, "word-processing")
+		So(NotOffice.String(), ShouldEqual, "none")
+	})
+
+	Convey("predicates", t, func() {
+		So(IsDocument("application/vnd.openxmlformats-officedocument.wordprocessingml.document"), ShouldBeTrue)
+		So(IsDocument("application/vnd.ms-excel"), ShouldBeFalse)
+		So(IsSpreadsheet("application/vnd.ms-excel"), ShouldBeTrue)
+		So(IsSpreadsheet("application/vnd.ms-powerpoint"), ShouldBeFalse)
+		So(IsPresentation("application/vnd.oasis.opendocument.presentation"), ShouldBeTrue)
+		So(IsPresentation("application/vnd.oasis.opendocument.text"), ShouldBeFalse)
+	})
+}
+
+func makeTestContentTypes(main string) string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/main.xml" ContentType="` + main + `"/>
+</Types>`
+}
+
+func TestOfficeDetection(t *testing.T) {
+	Convey("content types", t, func() {
+		for _, mime := range []string{DocxMimeType, DocmMimeType, DotxMimeType, XlsxMimeType, XlsmMimeType, PptxMimeType, PpsxMimeType} {
+			data := makeTestContainer("[Content_Types].xml", makeTestContentTypes(gOOXMLContentTypes[mime]), "main.xml", "<main/>")
+			detected, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+			So(err, ShouldBeNil)
+			So(detected, ShouldEqual, mime)
+		}
+	})
+
+	Convey("upload validation", t, func() {
+		xlsx := makeTestContainer("[Content_Types].xml", makeTestContentTypes(gOOXMLContentTypes[XlsxMimeType]), "word/document.xml", "<w:document/>")
+		detected, err := DetectAt(bytes.NewReader(xlsx), int64(len(xlsx)))
+		So(err, ShouldBeNil)
+		So(IsSpreadsheet(detected), ShouldBeTrue)
+		So(IsDocument(detected), ShouldBeFalse)
+	})
+
+	Convey("refines the github.com/gabriel-vasile/mimetype result", t, func() {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("word/document.xml")
+		_, _ = w.Write([]byte("<w:document/>"))
+		w, _ = zw.Create("[Content_Types].xml")
+		_, _ = w.Write([]byte(makeTestContentTypes(gOOXMLContentTypes[DocmMimeType])))
+		_ = zw.Close()
+		So(DetectBytes(buf.Bytes()), ShouldEqual, DocxMimeType)
+		detected, err := DetectAt(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		So(err, ShouldBeNil)
+		So(detected, ShouldEqual, DocmMimeType)
+	})
+
+	Convey("opendocument", t, func() {
+		data := makeTestContainer("mimetype", OtsMimeType, "content.xml", "<x/>")
+		detected, err := DetectAt(bytes.NewReader(data), int64(len(data)))
+		So(err, ShouldBeNil)
+		So(detected, ShouldEqual, OtsMimeType)
+		So(IsSpreadsheet(detected), ShouldBeTrue)
+	})
+}
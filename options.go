@@ -0,0 +1,136 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// DefaultCharset is the charset used when Options.DefaultCharset is empty
+const DefaultCharset = "utf-8"
+
+// Options are the Registry defaults which are not mappings, gathered in one
+// place so that they can be inspected and replaced together. The zero value
+// of each field selects the built-in default
+type Options struct {
+	// SniffLimit is the number of leading content bytes read by content
+	// detection, see SetReadLimit. Zero selects HeadWindow
+	SniffLimit int
+	// DefaultCharset is the charset associated with the types registered
+	// with RegisterTextType and its variants. Empty selects DefaultCharset
+	DefaultCharset string
+	// Strategy is the Strategy used by MimeWith when not given WithStrategy
+	Strategy Strategy
+	// TemplateExtensions are the extensions that FromPathOnly looks through
+	// to the extension before them, so that "page.html.tmpl" is HTML. Nil
+	// selects "tmpl" while an empty, non-nil slice disables the behaviour
+	TemplateExtensions []string
+	// CharsetPolicy determines whether the returned mime types of textual
+	// types include a charset parameter. Unknown values select
+	// CharsetAsRegistered
+	CharsetPolicy CharsetPolicy
+}
+
+// normalize returns a copy of the Options with the values cleaned up and
+// the TemplateExtensions copied
+func (o Options) normalize() (normalized Options) {
+	normalized = o
+	if normalized.SniffLimit < 0 {
+		normalized.SniffLimit = 0
+	}
+	normalized.DefaultCharset = strings.ToLower(strings.TrimSpace(o.DefaultCharset))
+	if normalized.CharsetPolicy > CharsetNever {
+		normalized.CharsetPolicy = CharsetAsRegistered
+	}
+	if o.TemplateExtensions != nil {
+		normalized.TemplateExtensions = make([]string, 0, len(o.TemplateExtensions))
+		for _, extension := range o.TemplateExtensions {
+			if extension = strings.ToLower(strings.TrimPrefix(extension, ".")); extension != "" {
+				normalized.TemplateExtensions = append(normalized.TemplateExtensions, extension)
+			}
+		}
+	}
+	return
+}
+
+// SetOptions replaces all the Options of the Registry at once, see GetOptions
+func (r *Registry) SetOptions(options Options) {
+	normalized := options.normalize()
+	r.options.Store(&normalized)
+}
+
+// GetOptions returns the Options of the Registry with the built-in defaults
+// filled in, so that every field reports the value in effect
+func (r *Registry) GetOptions() (options Options) {
+	options = *r.options.Load()
+	options.SniffLimit = r.GetReadLimit()
+	options.DefaultCharset = r.defaultCharset()
+	options.TemplateExtensions = append([]string{}, r.templateExtensions()...)
+	return
+}
+
+// updateOptions publishes a copy of the Options modified by `fn`
+func (r *Registry) updateOptions(fn func(o *Options)) {
+	for {
+		current := r.options.Load()
+		next := *current
+		fn(&next)
+		next = next.normalize()
+		if r.options.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+// defaultCharset returns the Options.DefaultCharset in effect
+func (r *Registry) defaultCharset() (charset string) {
+	if charset = r.options.Load().DefaultCharset; charset == "" {
+		charset = DefaultCharset
+	}
+	return
+}
+
+// templateExtensions returns the Options.TemplateExtensions in effect
+func (r *Registry) templateExtensions() (extensions []string) {
+	if extensions = r.options.Load().TemplateExtensions; extensions == nil {
+		extensions = []string{"tmpl"}
+	}
+	return
+}
+
+// isTemplateExtension returns true if the `extension` is one of the
+// Options.TemplateExtensions
+func (r *Registry) isTemplateExtension(extension string) bool {
+	for _, ext := range r.templateExtensions() {
+		if strings.EqualFold(ext, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOptions replaces the Options of the default Registry, including the
+// github.com/gabriel-vasile/mimetype read limit, see Registry.SetOptions
+func SetOptions(options Options) {
+	gRegistry.SetOptions(options)
+	mimetype.SetLimit(uint32(gRegistry.GetReadLimit()))
+}
+
+// GetOptions returns the Options of the default Registry
+func GetOptions() (options Options) {
+	return gRegistry.GetOptions()
+}
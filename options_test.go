@@ -0,0 +1,92 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOptions(t *testing.T) {
+	Convey("defaults", t, func() {
+		r := New()
+		So(r.GetOptions(), ShouldResemble, Options{
+			SniffLimit:         HeadWindow,
+			DefaultCharset:     DefaultCharset,
+			Strategy:           StrategyExtensionFirst,
+			TemplateExtensions: []string{"tmpl"},
+		})
+	})
+
+	Convey("SetOptions", t, func() {
+		r := New()
+		r.SetOptions(Options{
+			SniffLimit:         512,
+			DefaultCharset:     " UTF-16 ",
+			Strategy:           StrategyContentVerified,
+			TemplateExtensions: []string{".j2", "", "TPL"},
+		})
+		So(r.GetOptions(), ShouldResemble, Options{
+			SniffLimit:         512,
+			DefaultCharset:     "utf-16",
+			Strategy:           StrategyContentVerified,
+			TemplateExtensions: []string{"j2", "tpl"},
+		})
+		So(r.GetReadLimit(), ShouldEqual, 512)
+
+		r.SetReadLimit(1024)
+		So(r.GetOptions().SniffLimit, ShouldEqual, 1024)
+		So(r.GetOptions().Strategy, ShouldEqual, StrategyContentVerified)
+
+		So(r.FromPathOnly("page.html.j2"), ShouldEqual, "text/html; charset=utf-8")
+		So(r.FromPathOnly("page.html.TPL"), ShouldEqual, "text/html; charset=utf-8")
+		So(r.FromPathOnly("page.html.tmpl"), ShouldEqual, "")
+
+		So(r.RegisterTextType("text/x-optioned", "optd", nil), ShouldBeNil)
+		registered, _ := r.GetExtensionRaw("optd")
+		So(registered, ShouldEqual, "text/x-optioned; charset=utf-16")
+		charset, _ := r.GetCharset("text/x-optioned")
+		So(charset, ShouldEqual, "utf-16")
+
+		r.SetOptions(Options{TemplateExtensions: []string{}})
+		So(r.FromPathOnly("page.html.tmpl"), ShouldEqual, "")
+		So(r.GetOptions().TemplateExtensions, ShouldBeEmpty)
+
+		r.SetOptions(Options{SniffLimit: -1})
+		So(r.GetOptions().SniffLimit, ShouldEqual, HeadWindow)
+	})
+
+	Convey("Strategy", t, func() {
+		fake := filepath.Join(t.TempDir(), "fake.png")
+		So(os.WriteFile(fake, []byte("%PDF-1.4\n"), 0o600), ShouldBeNil)
+		r := New()
+		So(r.MimeWith(fake), ShouldEqual, "image/png")
+		r.SetOptions(Options{Strategy: StrategyContentFirst})
+		So(r.MimeWith(fake), ShouldEqual, "application/pdf")
+		So(r.MimeWith(fake, ExtensionFirst()), ShouldEqual, "image/png")
+		r.Reset()
+		So(r.GetOptions().Strategy, ShouldEqual, StrategyExtensionFirst)
+	})
+
+	Convey("default Registry", t, func() {
+		defer SetOptions(Options{})
+		SetOptions(Options{SniffLimit: 2048})
+		So(GetOptions().SniffLimit, ShouldEqual, 2048)
+		So(GetReadLimit(), ShouldEqual, 2048)
+	})
+}
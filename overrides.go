@@ -0,0 +1,138 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOverrideFile is the conventional name of per-directory override
+// files, see Registry.SetOverrideFile
+const DefaultOverrideFile = ".mimetypes"
+
+type overrideRule struct {
+	pattern string
+	mime    string
+}
+
+type overrideEntry struct {
+	modTime time.Time
+	size    int64
+	rules   []overrideRule
+}
+
+type overrideCache struct {
+	m map[string]*overrideEntry
+	sync.Mutex
+}
+
+// SetOverrideFile enables per-directory override files with the given
+// `name`, typically DefaultOverrideFile. When enabled, Mime checks the
+// directory of each file for an override file and if present, uses the first
+// rule matching the file name. Override files contain one rule per line, a
+// filepath.Match glob pattern and a mime type separated by whitespace, blank
+// lines and lines starting with a "#" are ignored:
+//
+//	# pin the types of odd files
+//	CNAME      text/plain
+//	*.webmanifest application/manifest+json
+//
+// Parsed override files are cached until their size or modification time
+// changes. An empty `name` disables override files, which is the default
+func (r *Registry) SetOverrideFile(name string) {
+	r.overrides.Lock()
+	r.overrides.m = make(map[string]*overrideEntry)
+	r.overrides.Unlock()
+	r.overrideFile.Store(&name)
+}
+
+// GetOverrideFile returns the name of the override files, empty when
+// override files are disabled
+func (r *Registry) GetOverrideFile() (name string) {
+	if ptr := r.overrideFile.Load(); ptr != nil {
+		name = *ptr
+	}
+	return
+}
+
+// SetOverrideFile enables per-directory override files for the default
+// Registry, see Registry.SetOverrideFile
+func SetOverrideFile(name string) {
+	gRegistry.SetOverrideFile(name)
+}
+
+// GetOverrideFile returns the name of the override files used by the
+// default Registry
+func GetOverrideFile() (name string) {
+	return gRegistry.GetOverrideFile()
+}
+
+// fromOverrides looks for a rule matching the base name of `path` within the
+// override file in the same directory as `path`
+func (r *Registry) fromOverrides(path string) (mime string, ok bool) {
+	name := r.GetOverrideFile()
+	if name == "" {
+		return
+	}
+	base := filepath.Base(path)
+	if base == name {
+		return
+	}
+	overrides := filepath.Join(filepath.Dir(path), name)
+	info, err := os.Stat(overrides)
+	if err != nil || info.IsDir() {
+		return
+	}
+
+	r.overrides.Lock()
+	entry, present := r.overrides.m[overrides]
+	if !present || !entry.modTime.Equal(info.ModTime()) || entry.size != info.Size() {
+		entry = &overrideEntry{modTime: info.ModTime(), size: info.Size(), rules: parseOverrides(overrides)}
+		r.overrides.m[overrides] = entry
+	}
+	r.overrides.Unlock()
+
+	base = r.normalizePath(base)
+	for _, rule := range entry.rules {
+		if matched, _ := filepath.Match(r.normalizePath(rule.pattern), base); matched {
+			return rule.mime, true
+		}
+	}
+	return
+}
+
+func parseOverrides(path string) (rules []overrideRule) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer fh.Close()
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			rules = append(rules, overrideRule{pattern: fields[0], mime: strings.Join(fields[1:], " ")})
+		}
+	}
+	return
+}
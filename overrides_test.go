@@ -0,0 +1,59 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOverrideFile(t *testing.T) {
+	dir := t.TempDir()
+	overrides := filepath.Join(dir, DefaultOverrideFile)
+	_ = os.WriteFile(overrides, []byte("# comment\n\nCNAME text/plain\n*.txt text/x-custom; charset=utf-8\nbroken\n"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "CNAME"), []byte("example.com"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644)
+
+	Convey("SetOverrideFile", t, func() {
+		r := New()
+		So(r.GetOverrideFile(), ShouldBeEmpty)
+		So(r.Mime(filepath.Join(dir, "notes.txt")), ShouldEqual, "text/plain; charset=utf-8")
+
+		r.SetOverrideFile(DefaultOverrideFile)
+		So(r.GetOverrideFile(), ShouldEqual, DefaultOverrideFile)
+		So(r.Mime(filepath.Join(dir, "CNAME")), ShouldEqual, "text/plain")
+		So(r.Mime(filepath.Join(dir, "notes.txt")), ShouldEqual, "text/x-custom; charset=utf-8")
+		So(r.Mime("./testdata/empty-png"), ShouldEqual, "image/png")
+
+		Convey("changes are noticed", func() {
+			_ = os.WriteFile(overrides, []byte("*.txt text/x-changed\n"), 0644)
+			later := time.Now().Add(time.Minute)
+			_ = os.Chtimes(overrides, later, later)
+			So(r.Mime(filepath.Join(dir, "notes.txt")), ShouldEqual, "text/x-changed")
+		})
+
+		r.SetOverrideFile("")
+		So(r.Mime(filepath.Join(dir, "notes.txt")), ShouldEqual, "text/plain; charset=utf-8")
+
+		So(GetOverrideFile(), ShouldBeEmpty)
+		SetOverrideFile(DefaultOverrideFile)
+		So(GetOverrideFile(), ShouldEqual, DefaultOverrideFile)
+		SetOverrideFile("")
+	})
+}
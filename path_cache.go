@@ -0,0 +1,137 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultPathCacheSize is the size used when NewPathCache is given a size of
+// zero
+const DefaultPathCacheSize = 4096
+
+// PathCache is a Mime result cache keyed by path, which is invalidated when
+// the size or modification time of the file changes, so that repeatedly
+// classifying an unchanged tree, such as a static asset server re-walking
+// its files, only stats the files instead of reading their content. When
+// full, the oldest entries are evicted first. Changes to the Registry
+// configuration are not tracked, call Reset after making any
+type PathCache struct {
+	r      *Registry
+	size   int
+	m      map[string]pathCacheEntry
+	order  []string
+	hits   uint64
+	misses uint64
+
+	sync.Mutex
+}
+
+type pathCacheEntry struct {
+	size    int64
+	modTime time.Time
+	mime    string
+}
+
+// NewPathCache constructs a new PathCache classifying with the given
+// Registry, or the default Registry if `r` is nil, and holding at most
+// `size` entries
+func NewPathCache(r *Registry, size int) (c *PathCache) {
+	if r == nil {
+		r = gRegistry
+	}
+	if size <= 0 {
+		size = DefaultPathCacheSize
+	}
+	return &PathCache{r: r, size: size, m: make(map[string]pathCacheEntry)}
+}
+
+// Mime returns the Registry.Mime result for the given `path`, using the
+// cached result when the size and modification time of the file are the
+// same as when it was cached. Only regular files are cached
+func (c *PathCache) Mime(path string) (mime string) {
+	if c.r.GetPathOnly() {
+		return c.r.Mime(path)
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		c.Invalidate(path)
+		return c.r.Mime(path)
+	}
+
+	c.Lock()
+	if entry, ok := c.m[path]; ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		c.hits += 1
+		c.Unlock()
+		return entry.mime
+	}
+	c.misses += 1
+	c.Unlock()
+
+	mime = c.r.Mime(path)
+
+	c.Lock()
+	if _, present := c.m[path]; !present {
+		if len(c.order) >= c.size {
+			delete(c.m, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, path)
+	}
+	c.m[path] = pathCacheEntry{size: info.Size(), modTime: info.ModTime(), mime: mime}
+	c.Unlock()
+	return
+}
+
+// Invalidate removes the cached result for the given `path`, if any
+func (c *PathCache) Invalidate(path string) {
+	c.Lock()
+	defer c.Unlock()
+	if _, present := c.m[path]; !present {
+		return
+	}
+	delete(c.m, path)
+	for idx, key := range c.order {
+		if key == path {
+			c.order = append(c.order[:idx], c.order[idx+1:]...)
+			break
+		}
+	}
+}
+
+// Len returns the number of entries in the cache
+func (c *PathCache) Len() (count int) {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.m)
+}
+
+// Stats returns the number of cache hits and misses so far
+func (c *PathCache) Stats() (hits, misses uint64) {
+	c.Lock()
+	defer c.Unlock()
+	return c.hits, c.misses
+}
+
+// Reset removes all entries from the cache and zeroes the Stats
+func (c *PathCache) Reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.m = make(map[string]pathCacheEntry)
+	c.order = nil
+	c.hits, c.misses = 0, 0
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPathCache(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	pdf := []byte("%PDF-1.4\n")
+	dir := t.TempDir()
+
+	Convey("PathCache", t, func() {
+		c := NewPathCache(New(), 1)
+		blob := filepath.Join(dir, "blob")
+		So(os.WriteFile(blob, png, 0644), ShouldBeNil)
+		So(c.Mime(blob), ShouldEqual, "image/png")
+		So(c.Mime(blob), ShouldEqual, "image/png")
+		hits, misses := c.Stats()
+		So(hits, ShouldEqual, 1)
+		So(misses, ShouldEqual, 1)
+		So(c.Len(), ShouldEqual, 1)
+
+		Convey("changed files are classified again", func() {
+			So(os.WriteFile(blob, pdf, 0644), ShouldBeNil)
+			later := time.Now().Add(time.Minute)
+			So(os.Chtimes(blob, later, later), ShouldBeNil)
+			So(c.Mime(blob), ShouldEqual, "application/pdf")
+			_, misses = c.Stats()
+			So(misses, ShouldEqual, 2)
+		})
+
+		Convey("the oldest entries are evicted", func() {
+			other := filepath.Join(dir, "other")
+			So(os.WriteFile(other, pdf, 0644), ShouldBeNil)
+			So(c.Mime(other), ShouldEqual, "application/pdf")
+			So(c.Len(), ShouldEqual, 1)
+			So(c.Mime(blob), ShouldEqual, "image/png")
+			_, misses = c.Stats()
+			So(misses, ShouldEqual, 3)
+		})
+
+		Convey("directories and missing files are not cached", func() {
+			So(c.Mime(dir), ShouldEqual, DirectoryMimeType)
+			So(c.Mime(filepath.Join(dir, "missing")), ShouldBeEmpty)
+			So(c.Len(), ShouldEqual, 1)
+		})
+
+		Convey("Invalidate and Reset", func() {
+			c.Invalidate(blob)
+			So(c.Len(), ShouldEqual, 0)
+			So(c.Mime(blob), ShouldEqual, "image/png")
+			c.Reset()
+			So(c.Len(), ShouldEqual, 0)
+			hits, misses = c.Stats()
+			So(hits+misses, ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrPathOnly is returned by the functions which need to read from the
+// local filesystem when path-only mode is enabled, see SetPathOnly
+var ErrPathOnly = errors.New("filesystem access disabled in path-only mode")
+
+// PathMime classifies the given `path` by name alone, never accessing the
+// local filesystem, for use with virtual paths such as CMS slugs and object
+// storage keys. Paths ending with a slash are DirectoryMimeType and all
+// other paths are classified with FromPathChecked, so that names CheckPath
+// considers suspicious return an empty string
+func (r *Registry) PathMime(path string) (mime string) {
+	if strings.HasSuffix(path, "/") || (r.GetWindowsPaths() && strings.HasSuffix(path, `\`)) {
+		return DirectoryMimeType
+	}
+	mime, _ = r.FromPathChecked(path)
+	return
+}
+
+// SetPathOnly configures path-only mode, which guarantees that the Registry
+// never stats or opens local files. Mime, MimeE, MimeWith, MimeContext and
+// PathCache classify paths with PathMime, Detect reports the PathMime result
+// with ConfidenceExtension and the functions which cannot work without the
+// content, such as Describe and Scan, return ErrPathOnly. For the default
+// Registry, DetectFile, Classify and ListArchive return ErrPathOnly as well.
+// MimeFS and WalkFS only access the fs.FS they are given
+func (r *Registry) SetPathOnly(enabled bool) {
+	r.pathOnly.Store(enabled)
+}
+
+// GetPathOnly returns true if path-only mode is enabled
+func (r *Registry) GetPathOnly() (enabled bool) {
+	return r.pathOnly.Load()
+}
+
+// PathMime classifies the given `path` by name alone using the default
+// Registry, see Registry.PathMime
+func PathMime(path string) (mime string) {
+	return gRegistry.PathMime(path)
+}
+
+// SetPathOnly configures path-only mode of the default Registry, see
+// Registry.SetPathOnly
+func SetPathOnly(enabled bool) {
+	gRegistry.SetPathOnly(enabled)
+}
+
+// GetPathOnly returns true if path-only mode of the default Registry is
+// enabled
+func GetPathOnly() (enabled bool) {
+	return gRegistry.GetPathOnly()
+}
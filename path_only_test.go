@@ -0,0 +1,98 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPathOnly(t *testing.T) {
+	Convey("PathMime", t, func() {
+		r := New()
+		So(r.PathMime("posts/hello-world/"), ShouldEqual, DirectoryMimeType)
+		So(r.PathMime("bucket/images/logo.html"), ShouldEqual, "text/html; charset=utf-8")
+		So(r.PathMime("bucket/images/logo"), ShouldEqual, "")
+		So(r.PathMime("invoice.pdf\u202etxt.exe"), ShouldEqual, "")
+		So(PathMime("index.html"), ShouldEqual, "text/html; charset=utf-8")
+	})
+
+	Convey("SetPathOnly", t, func() {
+		dir := t.TempDir()
+		fake := filepath.Join(dir, "fake.html")
+		So(os.WriteFile(fake, []byte("%PDF-1.4\n"), 0o600), ShouldBeNil)
+		bare := filepath.Join(dir, "bare")
+		So(os.WriteFile(bare, []byte("%PDF-1.4\n"), 0o600), ShouldBeNil)
+
+		r := New()
+		So(r.GetPathOnly(), ShouldBeFalse)
+		So(r.Mime(bare), ShouldEqual, "application/pdf")
+		r.SetPathOnly(true)
+		So(r.GetPathOnly(), ShouldBeTrue)
+
+		So(r.Mime(dir), ShouldEqual, "")
+		So(r.Mime(dir+"/"), ShouldEqual, DirectoryMimeType)
+		So(r.Mime(bare), ShouldEqual, "")
+		So(r.Mime("missing/page.html"), ShouldEqual, "text/html; charset=utf-8")
+		So(r.MimeWith(fake, ContentFirst()), ShouldEqual, "text/html; charset=utf-8")
+
+		_, err := r.MimeE(bare)
+		So(err, ShouldEqual, ErrUnknownExtension)
+		result, err := r.Detect(fake)
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, Result{Mime: "text/html; charset=utf-8", Confidence: ConfidenceExtension, Source: SourceExtension})
+		_, err = r.Detect(bare)
+		So(err, ShouldEqual, ErrUnknownExtension)
+		_, err = r.Describe(fake)
+		So(err, ShouldEqual, ErrPathOnly)
+		_, err = r.Scan(dir, ScanOptions{})
+		So(err, ShouldEqual, ErrPathOnly)
+		So(NewPathCache(r, 0).Mime(bare), ShouldEqual, "")
+
+		r.Reset()
+		So(r.GetPathOnly(), ShouldBeFalse)
+	})
+
+	Convey("default Registry", t, func() {
+		defer SetPathOnly(false)
+		SetPathOnly(true)
+		So(GetPathOnly(), ShouldBeTrue)
+		_, err := DetectFile("./testdata/empty-png")
+		So(err, ShouldEqual, ErrPathOnly)
+		_, err = Classify("./testdata/empty-png", ClassifyLimits{})
+		So(err, ShouldEqual, ErrPathOnly)
+
+		// each of these succeeds when the file is opened
+		dir := t.TempDir()
+		archive := filepath.Join(dir, "archive.tar")
+		So(os.WriteFile(archive, makeTestTar(), 0o600), ShouldBeNil)
+		_, err = ListArchive(archive)
+		So(err, ShouldEqual, ErrPathOnly)
+		report := filepath.Join(dir, "report")
+		So(os.WriteFile(report, makeTestContainer("[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>"), 0o600), ShouldBeNil)
+		_, ok := gRegistry.fromContainerFile(nil, report)
+		So(ok, ShouldBeFalse)
+
+		SetPathOnly(false)
+		entries, err := ListArchive(archive)
+		So(err, ShouldBeNil)
+		So(entries, ShouldNotBeEmpty)
+		_, ok = gRegistry.fromContainerFile(nil, report)
+		So(ok, ShouldBeTrue)
+	})
+}
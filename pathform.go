@@ -0,0 +1,79 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"golang.org/x/text/unicode/norm"
+)
+
+// PathForm is the Unicode normalization form applied to paths and extensions
+// before they are matched against registered extensions and override rules
+type PathForm uint32
+
+const (
+	// PathNFC normalizes to the composed form used by most systems and
+	// registries, this is the default
+	PathNFC PathForm = iota
+	// PathNFD normalizes to the decomposed form used by macOS filesystems
+	PathNFD
+	// PathAsIs disables normalization
+	PathAsIs
+)
+
+// String returns the name of the PathForm
+func (f PathForm) String() string {
+	switch f {
+	case PathNFC:
+		return "nfc"
+	case PathNFD:
+		return "nfd"
+	case PathAsIs:
+		return "as-is"
+	}
+	return "unknown"
+}
+
+// SetPathForm configures the Unicode normalization form applied to paths and
+// extensions, so that "café.md" is classified the same regardless of which
+// filesystem the name came from
+func (r *Registry) SetPathForm(form PathForm) {
+	r.pathForm.Store(uint32(form))
+}
+
+// GetPathForm returns the current PathForm
+func (r *Registry) GetPathForm() (form PathForm) {
+	return PathForm(r.pathForm.Load())
+}
+
+// SetPathForm configures the PathForm of the default Registry
+func SetPathForm(form PathForm) {
+	gRegistry.SetPathForm(form)
+}
+
+// GetPathForm returns the PathForm of the default Registry
+func GetPathForm() (form PathForm) {
+	return gRegistry.GetPathForm()
+}
+
+// normalizePath applies the current PathForm to the given `path`
+func (r *Registry) normalizePath(path string) (normalized string) {
+	switch r.GetPathForm() {
+	case PathNFC:
+		return norm.NFC.String(path)
+	case PathNFD:
+		return norm.NFD.String(path)
+	}
+	return path
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPathForm(t *testing.T) {
+	const nfc = "caf\u00e9"
+	const nfd = "cafe\u0301"
+
+	Convey("PathForm", t, func() {
+		r := New()
+		So(r.GetPathForm(), ShouldEqual, PathNFC)
+		So(r.GetPathForm().String(), ShouldEqual, "nfc")
+
+		r.SetExtension(nfc, "text/x-cafe")
+		So(r.FromPathOnly("menu."+nfc), ShouldEqual, "text/x-cafe")
+		So(r.FromPathOnly("menu."+nfd), ShouldEqual, "text/x-cafe")
+
+		r.SetPathForm(PathAsIs)
+		So(r.GetPathForm().String(), ShouldEqual, "as-is")
+		So(r.FromPathOnly("menu."+nfc), ShouldEqual, "text/x-cafe")
+		So(r.FromPathOnly("menu."+nfd), ShouldBeEmpty)
+
+		r.SetPathForm(PathNFD)
+		So(r.GetPathForm().String(), ShouldEqual, "nfd")
+		r.SetExtension(nfc, "text/x-cafe-nfd")
+		So(r.FromPathOnly("menu."+nfd), ShouldEqual, "text/x-cafe-nfd")
+		So(PathForm(99).String(), ShouldEqual, "unknown")
+
+		So(GetPathForm(), ShouldEqual, PathNFC)
+		SetPathForm(PathNFD)
+		So(GetPathForm(), ShouldEqual, PathNFD)
+		SetPathForm(PathNFC)
+	})
+
+	Convey("override rules", t, func() {
+		dir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(dir, DefaultOverrideFile), []byte(nfc+".* text/x-cafe\n"), 0644)
+		_ = os.WriteFile(filepath.Join(dir, nfd+".txt"), []byte("menu"), 0644)
+		r := New()
+		r.SetOverrideFile(DefaultOverrideFile)
+		So(r.Mime(filepath.Join(dir, nfd+".txt")), ShouldEqual, "text/x-cafe")
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Detector is a content detector contributed by a Plugin
+type Detector struct {
+	// Mime is the mime type reported when Detect returns true
+	Mime string
+	// Detect returns true if the `raw` content is of the Mime type, `limit`
+	// is the maximum number of bytes that Detect should inspect
+	Detect func(raw []byte, limit uint32) bool
+	// Priority orders the detectors given to RegisterDetector and
+	// RegisterTextTypePriority, higher priorities are consulted first and
+	// equal priorities are consulted most recently registered first
+	Priority int
+}
+
+// Plugin is the extension point through which other modules contribute
+// format support to this package, typically from an init function:
+//
+//	func init() {
+//	    _ = mime.RegisterPlugin(myPlugin{}, 10)
+//	}
+type Plugin interface {
+	// Name returns the unique name of the Plugin
+	Name() string
+	// Extensions returns the extension to mime type mappings to install
+	Extensions() map[string]string
+	// Detectors returns the content detectors to install
+	Detectors() []Detector
+}
+
+// PluginInfo describes a registered Plugin
+type PluginInfo struct {
+	Name       string
+	Priority   int
+	Extensions []string
+	Detectors  []string
+}
+
+type pluginEntry struct {
+	plugin     Plugin
+	priority   int
+	extensions map[string]string
+	detectors  []Detector
+}
+
+type pluginList struct {
+	list []*pluginEntry
+	sync.RWMutex
+}
+
+var gPlugins = &pluginList{}
+
+// RegisterPlugin installs the given Plugin with the given priority. The
+// Plugin extensions are added to the default Registry and to all Registry
+// instances created afterwards. Plugin detectors are consulted before the
+// github.com/gabriel-vasile/mimetype detectors, in order of highest priority
+// first and then in order of registration. RegisterPlugin returns an error
+// if the Plugin is nil, unnamed or the name is already registered
+func RegisterPlugin(p Plugin, priority int) (err error) {
+	if p == nil || p.Name() == "" {
+		return errors.New("plugin must not be nil and must have a name")
+	}
+	entry := &pluginEntry{
+		plugin:     p,
+		priority:   priority,
+		extensions: p.Extensions(),
+		detectors:  p.Detectors(),
+	}
+
+	gPlugins.Lock()
+	for _, existing := range gPlugins.list {
+		if existing.plugin.Name() == p.Name() {
+			gPlugins.Unlock()
+			return errors.New("plugin already registered: " + p.Name())
+		}
+	}
+	gPlugins.list = append(gPlugins.list, entry)
+	sort.SliceStable(gPlugins.list, func(i, j int) bool {
+		return gPlugins.list[i].priority > gPlugins.list[j].priority
+	})
+	gPlugins.Unlock()
+
+	gRegistry.installPlugin(entry)
+	return
+}
+
+// Plugins returns the list of registered plugins, in priority order
+func Plugins() (list []PluginInfo) {
+	gPlugins.RLock()
+	defer gPlugins.RUnlock()
+	for _, entry := range gPlugins.list {
+		info := PluginInfo{Name: entry.plugin.Name(), Priority: entry.priority}
+		for extension := range entry.extensions {
+			info.Extensions = append(info.Extensions, extension)
+		}
+		sort.Strings(info.Extensions)
+		for _, d := range entry.detectors {
+			info.Detectors = append(info.Detectors, d.Mime)
+		}
+		list = append(list, info)
+	}
+	return
+}
+
+func (r *Registry) installPlugin(entry *pluginEntry) {
+	for extension, mime := range entry.extensions {
+		r.SetExtension(extension, mime)
+	}
+}
+
+func (r *Registry) installPlugins() {
+	gPlugins.RLock()
+	defer gPlugins.RUnlock()
+	// lowest priority first so that higher priorities win any conflicts
+	for idx := len(gPlugins.list) - 1; idx >= 0; idx-- {
+		r.installPlugin(gPlugins.list[idx])
+	}
+}
+
+// detectPlugins runs the registered plugin detectors against `raw`
+func detectPlugins(raw []byte, limit uint32) (mime string, ok bool) {
+	gPlugins.RLock()
+	defer gPlugins.RUnlock()
+	for _, entry := range gPlugins.list {
+		for _, d := range entry.detectors {
+			if d.Detect != nil && d.Detect(raw, limit) {
+				return d.Mime, true
+			}
+		}
+	}
+	return
+}
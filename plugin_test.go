@@ -0,0 +1,70 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testPlugin struct {
+	name  string
+	magic string
+	mime  string
+	exts  map[string]string
+}
+
+func (p testPlugin) Name() string                  { return p.name }
+func (p testPlugin) Extensions() map[string]string { return p.exts }
+func (p testPlugin) Detectors() []Detector {
+	return []Detector{{Mime: p.mime, Detect: func(raw []byte, limit uint32) bool {
+		return bytes.HasPrefix(raw, []byte(p.magic))
+	}}}
+}
+
+func TestPlugins(t *testing.T) {
+	Convey("RegisterPlugin", t, func() {
+		So(RegisterPlugin(nil, 0), ShouldNotBeNil)
+		So(RegisterPlugin(testPlugin{}, 0), ShouldNotBeNil)
+
+		low := testPlugin{name: "test-low", magic: "TSTFMT", mime: "application/x-test-low", exts: map[string]string{"tstlow": "application/x-test-low"}}
+		high := testPlugin{name: "test-high", magic: "TSTFMT", mime: "application/x-test-high", exts: map[string]string{"tsthigh": "application/x-test-high"}}
+		So(RegisterPlugin(low, 1), ShouldBeNil)
+		So(RegisterPlugin(high, 5), ShouldBeNil)
+		So(RegisterPlugin(high, 5), ShouldNotBeNil)
+
+		var found []PluginInfo
+		for _, info := range Plugins() {
+			if info.Name == "test-low" || info.Name == "test-high" {
+				found = append(found, info)
+			}
+		}
+		So(found, ShouldResemble, []PluginInfo{
+			{Name: "test-high", Priority: 5, Extensions: []string{"tsthigh"}, Detectors: []string{"application/x-test-high"}},
+			{Name: "test-low", Priority: 1, Extensions: []string{"tstlow"}, Detectors: []string{"application/x-test-low"}},
+		})
+
+		So(FromPathOnly("file.tstlow"), ShouldEqual, "application/x-test-low")
+		So(New().FromPathOnly("file.tsthigh"), ShouldEqual, "application/x-test-high")
+
+		path := filepath.Join(t.TempDir(), "data")
+		So(os.WriteFile(path, []byte("TSTFMT\x00\x01"), 0644), ShouldBeNil)
+		So(Mime(path), ShouldEqual, "application/x-test-high")
+	})
+}
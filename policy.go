@@ -0,0 +1,150 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+)
+
+// OutputPolicy determines the shape of the mime type strings returned by a
+// Registry
+type OutputPolicy uint32
+
+const (
+	// AsRegistered returns mime types with whatever parameters they were
+	// registered or detected with, this is the default
+	AsRegistered OutputPolicy = iota
+	// BareType returns only the type/subtype, without any parameters
+	BareType
+	// WithCharset returns the type/subtype with only the charset parameter,
+	// which is added from the registered charsets when not already present
+	WithCharset
+	// FullParameters returns the type/subtype with all parameters, adding the
+	// charset from the registered charsets when not already present
+	FullParameters
+)
+
+// String returns the name of the OutputPolicy
+func (p OutputPolicy) String() string {
+	switch p {
+	case AsRegistered:
+		return "as-registered"
+	case BareType:
+		return "bare-type"
+	case WithCharset:
+		return "with-charset"
+	case FullParameters:
+		return "full-parameters"
+	}
+	return "unknown"
+}
+
+// CharsetPolicy determines whether the mime type strings returned by a
+// Registry include a charset parameter, applied after the OutputPolicy, see
+// Options.CharsetPolicy
+type CharsetPolicy uint8
+
+const (
+	// CharsetAsRegistered leaves the charset parameter as shaped by the
+	// OutputPolicy, this is the default
+	CharsetAsRegistered CharsetPolicy = iota
+	// CharsetAlways adds a charset parameter to every type IsTextual returns
+	// true for, using the registered charset of the type or otherwise the
+	// Options.DefaultCharset
+	CharsetAlways
+	// CharsetNever removes the charset parameter from every type
+	CharsetNever
+)
+
+// String returns the name of the CharsetPolicy
+func (p CharsetPolicy) String() string {
+	switch p {
+	case CharsetAsRegistered:
+		return "as-registered"
+	case CharsetAlways:
+		return "always"
+	case CharsetNever:
+		return "never"
+	}
+	return "unknown"
+}
+
+// SetOutputPolicy configures the shape of the mime type strings returned by
+// GetExtension, FromPathOnly and Mime
+func (r *Registry) SetOutputPolicy(policy OutputPolicy) {
+	r.policy.Store(uint32(policy))
+}
+
+// GetOutputPolicy returns the current OutputPolicy
+func (r *Registry) GetOutputPolicy() (policy OutputPolicy) {
+	return OutputPolicy(r.policy.Load())
+}
+
+// SetOutputPolicy configures the OutputPolicy of the default Registry
+func SetOutputPolicy(policy OutputPolicy) {
+	gRegistry.SetOutputPolicy(policy)
+}
+
+// GetOutputPolicy returns the OutputPolicy of the default Registry
+func GetOutputPolicy() (policy OutputPolicy) {
+	return gRegistry.GetOutputPolicy()
+}
+
+// output normalizes the given `mime` and applies the current OutputPolicy
+// and Options.CharsetPolicy
+func (r *Registry) output(mime string) (shaped string) {
+	shaped = Normalize(mime)
+	policy := r.GetOutputPolicy()
+	charsets := r.options.Load().CharsetPolicy
+	if policy == AsRegistered && charsets == CharsetAsRegistered {
+		return
+	}
+	mediatype, params, err := goMime.ParseMediaType(shaped)
+	if err != nil {
+		return
+	}
+	switch policy {
+	case BareType:
+		params = nil
+	case WithCharset:
+		params = map[string]string{"charset": params["charset"]}
+		fallthrough
+	case FullParameters:
+		if params["charset"] == "" {
+			if charset, ok := r.GetCharset(mediatype); ok {
+				params["charset"] = charset
+			} else {
+				delete(params, "charset")
+			}
+		}
+	}
+	switch charsets {
+	case CharsetAlways:
+		if params["charset"] == "" && r.IsTextual(mediatype) {
+			charset, ok := r.GetCharset(mediatype)
+			if !ok || charset == "" {
+				charset = r.defaultCharset()
+			}
+			if params == nil {
+				params = map[string]string{}
+			}
+			params["charset"] = charset
+		}
+	case CharsetNever:
+		delete(params, "charset")
+	}
+	shaped = goMime.FormatMediaType(mediatype, params)
+	return
+}
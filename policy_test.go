@@ -0,0 +1,106 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOutputPolicy(t *testing.T) {
+	Convey("OutputPolicy", t, func() {
+		r := New()
+		r.SetExtension("md", "text/markdown")
+		r.SetExtension("tpl", "text/x-template; engine=go; charset=UTF-8")
+
+		check := func(ext string) string {
+			mime, _ := r.GetExtension(ext)
+			return mime
+		}
+
+		So(r.GetOutputPolicy(), ShouldEqual, AsRegistered)
+		So(check("md"), ShouldEqual, "text/markdown")
+		So(check("tpl"), ShouldEqual, "text/x-template; charset=utf-8; engine=go")
+		So(r.FromPathOnly("file.txt"), ShouldEqual, "text/plain; charset=utf-8")
+
+		r.SetOutputPolicy(BareType)
+		So(r.GetOutputPolicy().String(), ShouldEqual, "bare-type")
+		So(check("md"), ShouldEqual, "text/markdown")
+		So(check("tpl"), ShouldEqual, "text/x-template")
+		So(r.FromPathOnly("file.txt"), ShouldEqual, "text/plain")
+		So(r.Mime("./testdata/empty-png"), ShouldEqual, "image/png")
+
+		r.SetOutputPolicy(WithCharset)
+		So(check("md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(check("tpl"), ShouldEqual, "text/x-template; charset=utf-8")
+		So(r.Mime("./testdata/empty-png"), ShouldEqual, "image/png")
+
+		r.SetOutputPolicy(FullParameters)
+		So(check("md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(check("tpl"), ShouldEqual, "text/x-template; charset=utf-8; engine=go")
+
+		raw, _ := r.GetExtensionRaw("md")
+		So(raw, ShouldEqual, "text/markdown")
+
+		So(GetOutputPolicy(), ShouldEqual, AsRegistered)
+		SetOutputPolicy(BareType)
+		So(FromPathOnly("file.txt"), ShouldEqual, "text/plain")
+		SetOutputPolicy(AsRegistered)
+		So(FromPathOnly("file.txt"), ShouldEqual, "text/plain; charset=utf-8")
+		So(OutputPolicy(99).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("CharsetPolicy", t, func() {
+		r := New()
+		r.SetExtension("md", "text/markdown")
+		r.SetExtension("xcustom", "text/x-custom")
+		r.SetExtension("tpl", "text/x-template; engine=go; charset=UTF-8")
+		r.SetExtension("xpng", "image/png")
+
+		check := func(ext string) string {
+			mime, _ := r.GetExtension(ext)
+			return mime
+		}
+
+		So(r.GetOptions().CharsetPolicy, ShouldEqual, CharsetAsRegistered)
+		So(check("md"), ShouldEqual, "text/markdown")
+		So(check("xcustom"), ShouldEqual, "text/x-custom")
+
+		r.SetOptions(Options{CharsetPolicy: CharsetAlways})
+		So(r.GetOptions().CharsetPolicy.String(), ShouldEqual, "always")
+		So(check("md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(check("xcustom"), ShouldEqual, "text/x-custom; charset=utf-8")
+		So(check("tpl"), ShouldEqual, "text/x-template; charset=utf-8; engine=go")
+		So(check("xpng"), ShouldEqual, "image/png")
+		So(r.DetectString(`{"key": "value"}`), ShouldEqual, JsonMimeType+"; charset=utf-8")
+
+		r.SetOptions(Options{CharsetPolicy: CharsetAlways, DefaultCharset: "ISO-8859-1"})
+		So(check("xcustom"), ShouldEqual, "text/x-custom; charset=iso-8859-1")
+		r.SetOutputPolicy(BareType)
+		So(check("tpl"), ShouldEqual, "text/x-template; charset=iso-8859-1")
+		r.SetOutputPolicy(AsRegistered)
+
+		r.SetOptions(Options{CharsetPolicy: CharsetNever})
+		So(check("tpl"), ShouldEqual, "text/x-template; engine=go")
+		So(r.FromPathOnly("file.txt"), ShouldEqual, "text/plain")
+		r.SetOutputPolicy(WithCharset)
+		So(check("md"), ShouldEqual, "text/markdown")
+
+		r.SetOptions(Options{CharsetPolicy: CharsetPolicy(99)})
+		So(r.GetOptions().CharsetPolicy, ShouldEqual, CharsetAsRegistered)
+		So(CharsetPolicy(99).String(), ShouldEqual, "unknown")
+	})
+}
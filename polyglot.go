@@ -0,0 +1,99 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+)
+
+// polyglotCheck is a single format signature used by CompetingTypes
+type polyglotCheck struct {
+	mime  string
+	check func(data []byte) bool
+}
+
+// gPolyglotChecks are the format signatures checked by CompetingTypes, in
+// the order reported
+var gPolyglotChecks = []polyglotCheck{
+	{mime: "image/gif", check: func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))
+	}},
+	{mime: "image/jpeg", check: func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("\xff\xd8\xff"))
+	}},
+	{mime: "image/png", check: func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n"))
+	}},
+	{mime: "application/pdf", check: func(data []byte) bool {
+		// PDF readers accept the header anywhere within the first 1024 bytes
+		return bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-"))
+	}},
+	{mime: ZipMimeType, check: func(data []byte) bool {
+		// ZIP readers locate the central directory from the end of the file
+		tail := data[max(len(data)-TailWindow, 0):]
+		return bytes.HasPrefix(data, []byte("PK\x03\x04")) || bytes.LastIndex(tail, []byte("PK\x05\x06")) >= 0
+	}},
+	{mime: HtmlMimeType, check: func(data []byte) bool {
+		lower := bytes.ToLower(data)
+		for _, tag := range []string{"<html", "<script", "<iframe", "<body", "<svg", "<object", "<embed"} {
+			if bytes.Contains(lower, []byte(tag)) {
+				return true
+			}
+		}
+		return false
+	}},
+	{mime: PhpMimeType, check: func(data []byte) bool {
+		return bytes.Contains(bytes.ToLower(data), []byte("<?php"))
+	}},
+	{mime: JavaScriptMimeType, check: func(data []byte) bool {
+		// an identifier followed by a comment or an assignment, such as the
+		// "GIF89a/*" prologue of GIF and JavaScript polyglots
+		idx := 0
+		for idx < len(data) && isIdentifierByte(data[idx]) {
+			idx += 1
+		}
+		if idx == 0 {
+			return false
+		}
+		rest := bytes.TrimLeft(data[idx:], " \t")
+		return bytes.HasPrefix(rest, []byte("/*")) || (bytes.HasPrefix(rest, []byte("=")) && !bytes.HasPrefix(rest, []byte("==")))
+	}},
+}
+
+// CompetingTypes checks the given `data` against format signatures which
+// browsers, interpreters and document readers accept in unexpected places,
+// returning every type matched. More than one type indicates polyglot or
+// ambiguous content, for example a file that is both a valid GIF and valid
+// JavaScript, or a JPEG with HTML hidden in a comment, which can be used in
+// content-sniffing attacks. Upload services should reject or quarantine
+// content where IsPolyglot is true
+func CompetingTypes(data []byte) (types []string) {
+	for _, pc := range gPolyglotChecks {
+		if pc.check(data) {
+			types = append(types, pc.mime)
+		}
+	}
+	return
+}
+
+// IsPolyglot returns true if CompetingTypes finds more than one type within
+// the given `data`
+func IsPolyglot(data []byte) bool {
+	return len(CompetingTypes(data)) > 1
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompetingTypes(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	Convey("CompetingTypes", t, func() {
+		Convey("single formats", func() {
+			So(CompetingTypes(png), ShouldResemble, []string{"image/png"})
+			So(IsPolyglot(png), ShouldBeFalse)
+			So(CompetingTypes([]byte("plain words")), ShouldBeEmpty)
+			So(CompetingTypes([]byte("<!doctype html><html></html>")), ShouldResemble, []string{HtmlMimeType})
+		})
+
+		Convey("GIF and JavaScript", func() {
+			data := []byte("GIF89a/*\x01\x00\x01\x00\x00\xff\x00*/=1;alert(document.domain);")
+			So(CompetingTypes(data), ShouldResemble, []string{"image/gif", JavaScriptMimeType})
+			So(IsPolyglot(data), ShouldBeTrue)
+		})
+
+		Convey("HTML in a JPEG comment", func() {
+			data := []byte("\xff\xd8\xff\xfe\x00\x20<SCRIPT>alert(1)</SCRIPT>\xff\xd9")
+			So(CompetingTypes(data), ShouldResemble, []string{"image/jpeg", HtmlMimeType})
+		})
+
+		Convey("PDF and ZIP", func() {
+			data := append([]byte("junk%PDF-1.4\n"), []byte("PK\x05\x06\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")...)
+			So(CompetingTypes(data), ShouldResemble, []string{"application/pdf", ZipMimeType})
+		})
+
+		Convey("PHP in a PNG", func() {
+			data := append(append([]byte{}, png...), []byte("<?php system($_GET['c']); ?>")...)
+			So(CompetingTypes(data), ShouldResemble, []string{"image/png", PhpMimeType})
+		})
+	})
+}
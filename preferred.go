@@ -0,0 +1,103 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+)
+
+// ExtensionByType returns the single preferred extension, without a leading
+// period, for the given `mime` type. This is the extension configured with
+// SetPreferredExtension, or when none was configured, the shortest (and then
+// alphabetically first) extension registered for the type, followed by the
+// extension known to github.com/gabriel-vasile/mimetype and finally the
+// first of mime.ExtensionsByType. Aliases and parameters of the `mime` are
+// ignored, so "image/jpg" and "image/jpeg; q=1" return "jpg" as well
+func (r *Registry) ExtensionByType(mime string) (extension string, ok bool) {
+	return r.preferredExtension(mime)
+}
+
+// SetPreferredExtension configures the `extension` returned by
+// ExtensionByType and used by SuggestFilename for the given `mime` type, for
+// example "jpeg" instead of "jpg" for "image/jpeg". The `extension` is not
+// registered for FromPathOnly lookups, use SetExtension for that. If
+// `extension` is empty, any preference for the `mime` type is cleared
+func (r *Registry) SetPreferredExtension(mime, extension string) {
+	mediatype := r.Key(PruneCharset(mime))
+	if mediatype == "" {
+		return
+	} else if extension = r.normalizeExtension(extension); extension == "" {
+		r.preferred.unset(mediatype)
+		return
+	}
+	r.preferred.set(mediatype, extension)
+}
+
+// ExtensionByType returns the preferred extension for the given `mime` type
+// using the default Registry, see Registry.ExtensionByType
+func ExtensionByType(mime string) (extension string, ok bool) {
+	return gRegistry.ExtensionByType(mime)
+}
+
+// SetPreferredExtension configures the preferred extension for the given
+// `mime` type of the default Registry, see Registry.SetPreferredExtension
+func SetPreferredExtension(mime, extension string) {
+	gRegistry.SetPreferredExtension(mime, extension)
+}
+
+// ExtensionsByType returns all of the extensions registered with the
+// Registry for the given `mime` type, without leading periods. The
+// ExtensionByType result is first when it is one of them, followed by the
+// others in shortest and then alphabetical order
+func (r *Registry) ExtensionsByType(mime string) (extensions []string) {
+	mediatype := r.Key(PruneCharset(mime))
+	if mediatype == "" {
+		return
+	}
+	extensions = r.registeredExtensions(mediatype)
+	if preferred, ok := r.preferred.get(mediatype); ok {
+		for idx, extension := range extensions {
+			if extension == preferred {
+				copy(extensions[1:idx+1], extensions[:idx])
+				extensions[0] = preferred
+				break
+			}
+		}
+	}
+	return
+}
+
+// ExtensionsByType returns the extensions registered with the default
+// Registry for the given `mime` type, see Registry.ExtensionsByType
+func ExtensionsByType(mime string) (extensions []string) {
+	return gRegistry.ExtensionsByType(mime)
+}
+
+// registeredExtensions returns the extensions registered for the given
+// `mediatype` Key, in shortest and then alphabetical order
+func (r *Registry) registeredExtensions(mediatype string) (found []string) {
+	for ext, value := range r.snapshot(ExtensionTable) {
+		if r.Key(PruneCharset(value)) == mediatype {
+			found = append(found, ext)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if len(found[i]) != len(found[j]) {
+			return len(found[i]) < len(found[j])
+		}
+		return found[i] < found[j]
+	})
+	return
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	goMime "mime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExtensionByType(t *testing.T) {
+	Convey("ExtensionByType", t, func() {
+		r := New()
+		for _, check := range []struct {
+			mime      string
+			extension string
+		}{
+			{"image/jpeg", "jpg"},
+			{"image/jpg", "jpg"},
+			{"image/jpeg; q=1", "jpg"},
+			{"text/html; charset=utf-8", "html"},
+			{"application/gzip", "gz"},
+		} {
+			extension, ok := r.ExtensionByType(check.mime)
+			So(ok, ShouldBeTrue)
+			So(extension, ShouldEqual, check.extension)
+		}
+		_, ok := r.ExtensionByType("application/x-not-a-thing")
+		So(ok, ShouldBeFalse)
+		_, ok = r.ExtensionByType("")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("SetPreferredExtension", t, func() {
+		r := New()
+		r.SetPreferredExtension("image/jpg", ".JPEG")
+		extension, ok := r.ExtensionByType("image/jpeg")
+		So(ok, ShouldBeTrue)
+		So(extension, ShouldEqual, "jpeg")
+		So(r.SuggestFilename("photo", "image/jpeg"), ShouldEqual, "photo.jpeg")
+
+		r.SetPreferredExtension("image/jpeg", "")
+		extension, _ = r.ExtensionByType("image/jpeg")
+		So(extension, ShouldEqual, "jpg")
+
+		r.SetPreferredExtension("image/jpeg", "jpeg")
+		r.Reset()
+		extension, _ = r.ExtensionByType("image/jpeg")
+		So(extension, ShouldEqual, "jpg")
+	})
+
+	Convey("default registry", t, func() {
+		SetPreferredExtension("image/jpeg", "jpeg")
+		extension, ok := ExtensionByType("image/jpeg")
+		So(ok, ShouldBeTrue)
+		So(extension, ShouldEqual, "jpeg")
+		SetPreferredExtension("image/jpeg", "")
+	})
+}
+
+func TestRegisterTextTypeExt(t *testing.T) {
+	detector := func(raw []byte, limit uint32) bool {
+		return bytes.HasPrefix(raw, []byte("%%EXT-TEST"))
+	}
+
+	Convey("Registry", t, func() {
+		r := New()
+		So(r.RegisterTextTypeExt("text/x-rst", nil), ShouldNotBeNil)
+		So(r.RegisterTextTypeExt("text/x-rst", nil, "rst", ""), ShouldNotBeNil)
+		So(r.ExtensionsByType("text/x-rst"), ShouldBeEmpty)
+
+		So(r.RegisterTextTypeExt("text/x-rst", nil, "rst", ".rest", "restx"), ShouldBeNil)
+		for _, extension := range []string{"rst", "rest", "restx"} {
+			So(r.FromPathOnly("index."+extension), ShouldEqual, "text/x-rst; charset=utf-8")
+		}
+		So(r.ExtensionsByType("text/x-rst"), ShouldResemble, []string{"rst", "rest", "restx"})
+		r.SetPreferredExtension("text/x-rst", "rest")
+		So(r.ExtensionsByType("text/x-rst"), ShouldResemble, []string{"rest", "rst", "restx"})
+		So(r.ExtensionsByType(""), ShouldBeEmpty)
+	})
+
+	Convey("default registry", t, func() {
+		So(RegisterTextTypeExt("text/x-ext-test", detector), ShouldNotBeNil)
+		So(RegisterTextTypeExt("text/x-ext-test", detector, "xta", "xtest"), ShouldBeNil)
+		defer UnregisterType("text/x-ext-test")
+		So(ExtensionsByType("text/x-ext-test"), ShouldResemble, []string{"xta", "xtest"})
+		extensions, err := goMime.ExtensionsByType("text/x-ext-test")
+		So(err, ShouldBeNil)
+		So(extensions, ShouldContain, ".xta")
+		So(extensions, ShouldContain, ".xtest")
+		So(DetectBytes([]byte("%%EXT-TEST\n")), ShouldEqual, "text/x-ext-test; charset=utf-8")
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetectorPriority(t *testing.T) {
+	always := func(raw []byte, limit uint32) bool { return true }
+
+	Convey("textDetectorList ordering", t, func() {
+		l := &textDetectorList{}
+		l.add(Detector{Mime: "a", Detect: always})
+		l.add(Detector{Mime: "b", Detect: always, Priority: 10})
+		l.add(Detector{Mime: "c", Detect: always})
+		l.add(Detector{Mime: "d", Detect: always, Priority: -5})
+		l.add(Detector{Mime: "e", Detect: always, Priority: 10})
+		So(l.mimes(), ShouldResemble, []string{"e", "b", "c", "a", "d"})
+		mime, ok := l.detect(nil, 0)
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "e")
+	})
+
+	Convey("Registry detectors", t, func() {
+		r := New()
+		strict := func(raw []byte, limit uint32) bool { return bytes.HasPrefix(raw, []byte("\x00STRICT")) }
+		So(r.RegisterDetector(Detector{Mime: "application/x-strict", Detect: strict, Priority: 10}), ShouldBeNil)
+		So(r.RegisterDetector(Detector{Mime: "application/x-loose", Detect: always}), ShouldBeNil)
+		So(r.DetectBytes([]byte("\x00STRICT")), ShouldEqual, "application/x-strict")
+		So(r.DetectBytes([]byte("\x00OTHER")), ShouldEqual, "application/x-loose")
+
+		So(r.RegisterTextTypePriority("text/x-strict-org", "sorg", strict, 20), ShouldBeNil)
+		So(r.DetectBytes([]byte("\x00STRICT")), ShouldEqual, "text/x-strict-org; charset=utf-8")
+	})
+
+	Convey("RegisterTextTypePriority chain", t, func() {
+		// a catch-all registered last is checked first within the mimetype
+		// tree, but the lower priority keeps the existing catch-all in place
+		So(DetectBytes([]byte("just some words\n")), ShouldEqual, "text/plain; charset=utf-8")
+		So(RegisterTextTypePriority("text/x-highly", "highly", nil, 0), ShouldBeNil)
+		defer UnregisterType("text/x-highly")
+		before := DetectBytes([]byte("just some words\n"))
+		So(before, ShouldEqual, "text/x-highly; charset=utf-8")
+		So(RegisterTextTypePriority("text/x-lowly", "lowly", nil, -1), ShouldBeNil)
+		defer UnregisterType("text/x-lowly")
+		So(DetectBytes([]byte("just some words\n")), ShouldEqual, before)
+
+		strict := func(raw []byte, limit uint32) bool { return bytes.HasPrefix(raw, []byte("#+STRICT:")) }
+		So(RegisterTextTypePriority("text/x-strict-org", "sorg", strict, 5), ShouldBeNil)
+		defer UnregisterType("text/x-strict-org")
+		So(DetectBytes([]byte("#+STRICT: yes\n")), ShouldEqual, "text/x-strict-org; charset=utf-8")
+		So(DetectBytes([]byte("just some words\n")), ShouldEqual, before)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// SetReadLimit configures the number of leading content bytes read and
+// inspected by content detection, such as Mime, DetectBytes and
+// DetectReader. Larger limits help formats with long preambles, such as
+// ID3-padded MP3 files, while smaller limits reduce latency. A `limit` of
+// zero or less restores the HeadWindow default.
+//
+// The github.com/gabriel-vasile/mimetype read limit is global and only the
+// default Registry changes it, see the package level SetReadLimit. Other
+// Registry instances pass the configured number of bytes to their own
+// detectors while the mimetype signatures still see no more than the global
+// limit. The read limit is the Options.SniffLimit
+func (r *Registry) SetReadLimit(limit int) {
+	r.updateOptions(func(o *Options) {
+		o.SniffLimit = limit
+	})
+}
+
+// GetReadLimit returns the number of leading content bytes read by content
+// detection, see SetReadLimit
+func (r *Registry) GetReadLimit() (limit int) {
+	if limit = r.options.Load().SniffLimit; limit == 0 {
+		limit = HeadWindow
+	}
+	return
+}
+
+// SetReadLimit configures the content detection read limit of the default
+// Registry and of github.com/gabriel-vasile/mimetype, see
+// Registry.SetReadLimit
+func SetReadLimit(limit int) {
+	gRegistry.SetReadLimit(limit)
+	mimetype.SetLimit(uint32(gRegistry.GetReadLimit()))
+}
+
+// GetReadLimit returns the content detection read limit of the default
+// Registry
+func GetReadLimit() (limit int) {
+	return gRegistry.GetReadLimit()
+}
+
+// readHeadN returns the leading `limit` bytes of `r`, or less when `r` is
+// shorter. The buffer grows with the content read, so that a large `limit`
+// does not cost that much memory for short content
+func readHeadN(r io.Reader, limit int) (head []byte, err error) {
+	return io.ReadAll(io.LimitReader(r, int64(limit)))
+}
+
+// cReadChunk is the size of the reads made by readHeadContext
+const cReadChunk = 512
+
+// readHeadContext is readHeadN reading in chunks of cReadChunk bytes on the
+// calling goroutine, checking `ctx` between the reads. When `ctx` ends, the
+// bytes read so far are returned with the context error. A read which is
+// already in progress is not interrupted
+func readHeadContext(ctx context.Context, r io.Reader, limit int) (head []byte, err error) {
+	var chunk [cReadChunk]byte
+	for len(head) < limit {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		var n int
+		n, err = r.Read(chunk[:min(cReadChunk, limit-len(head))])
+		head = append(head, chunk[:n]...)
+		if err == io.EOF {
+			return head, nil
+		} else if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readFileHead returns the leading GetReadLimit bytes of the file at `path`
+func (r *Registry) readFileHead(path string) (head []byte, err error) {
+	var fh *os.File
+	if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	return readHeadN(fh, r.GetReadLimit())
+}
+
+// readFileHeadContext is readFileHead using readHeadContext
+func (r *Registry) readFileHeadContext(ctx context.Context, path string) (head []byte, err error) {
+	var fh *os.File
+	if fh, err = os.Open(path); err != nil {
+		return
+	}
+	defer fh.Close()
+	return readHeadContext(ctx, fh, r.GetReadLimit())
+}
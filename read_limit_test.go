@@ -0,0 +1,150 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadLimit(t *testing.T) {
+	deep := make([]byte, 5000)
+	deep[4000] = 'X'
+
+	Convey("SetReadLimit", t, func() {
+		r := New()
+		So(r.GetReadLimit(), ShouldEqual, HeadWindow)
+		r.SetReadLimit(512)
+		So(r.GetReadLimit(), ShouldEqual, 512)
+		r.SetReadLimit(-1)
+		So(r.GetReadLimit(), ShouldEqual, HeadWindow)
+
+		SetReadLimit(8192)
+		So(GetReadLimit(), ShouldEqual, 8192)
+		SetReadLimit(0)
+		So(GetReadLimit(), ShouldEqual, HeadWindow)
+	})
+
+	Convey("detection honours the read limit", t, func() {
+		r := New()
+		So(r.RegisterDetector(Detector{Mime: "application/x-deep", Detect: func(raw []byte, limit uint32) bool {
+			return len(raw) > 4000 && raw[4000] == 'X'
+		}}), ShouldBeNil)
+		So(r.DetectBytes(deep), ShouldEqual, BinaryMimeType)
+
+		r.SetReadLimit(8192)
+		So(r.DetectBytes(deep), ShouldEqual, "application/x-deep")
+
+		path := filepath.Join(t.TempDir(), "deep")
+		So(os.WriteFile(path, deep, 0644), ShouldBeNil)
+		So(r.Mime(path), ShouldEqual, "application/x-deep")
+
+		mime, rebuilt, err := r.DetectReader(bytes.NewReader(deep))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/x-deep")
+		replayed := new(bytes.Buffer)
+		_, _ = replayed.ReadFrom(rebuilt)
+		So(replayed.Len(), ShouldEqual, len(deep))
+
+		var seen int
+		r.SetReadLimit(16)
+		So(r.RegisterDetector(Detector{Mime: "application/x-seen", Detect: func(raw []byte, limit uint32) bool {
+			seen = len(raw)
+			return false
+		}}), ShouldBeNil)
+		_ = r.Mime(path)
+		So(seen, ShouldEqual, 16)
+	})
+
+	Convey("every entry point honours the read limit", t, func() {
+		So(gRegistry.RegisterDetector(Detector{Mime: "application/x-deep", Detect: func(raw []byte, limit uint32) bool {
+			return len(raw) > 4000 && raw[4000] == 'X'
+		}}), ShouldBeNil)
+		defer UnregisterType("application/x-deep")
+		SetReadLimit(8192)
+		defer SetReadLimit(0)
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "deep")
+		So(os.WriteFile(path, deep, 0644), ShouldBeNil)
+
+		mime, err := DetectAt(bytes.NewReader(deep), int64(len(deep)))
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/x-deep")
+		mime, err = DetectFile(path)
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/x-deep")
+
+		report, err := Scan(dir, ScanOptions{})
+		So(err, ShouldBeNil)
+		So(report.Entries, ShouldHaveLength, 1)
+		So(report.Entries[0].Mime, ShouldEqual, "application/x-deep")
+
+		var archive bytes.Buffer
+		tw := tar.NewWriter(&archive)
+		_ = tw.WriteHeader(&tar.Header{Name: "deep", Mode: 0o644, Size: int64(len(deep))})
+		_, _ = tw.Write(deep)
+		_ = tw.Close()
+		entries, err := ListArchiveAt(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		So(err, ShouldBeNil)
+		So(entries, ShouldHaveLength, 1)
+		So(entries[0].Mime, ShouldEqual, "application/x-deep")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(deep))
+		}))
+		defer server.Close()
+		mime, err = RemoteMime(context.Background(), server.URL, RemoteOptions{})
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, "application/x-deep")
+
+		var sniffed []string
+		handler := SniffRequest(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			sniffed = SniffedTypes(req)
+		}), SniffOptions{Limit: 2 * len(deep)})
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(deep))
+		req.Header.Set("Content-Type", "application/x-deep")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		So(sniffed, ShouldResemble, []string{"application/x-deep"})
+
+		var form bytes.Buffer
+		mw := multipart.NewWriter(&form)
+		pw, _ := mw.CreateFormFile("file", "deep")
+		_, _ = pw.Write(deep)
+		_ = mw.Close()
+		req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(form.Bytes()))
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		So(sniffed, ShouldResemble, []string{"application/x-deep"})
+	})
+
+	Convey("readHeadN grows with the content", t, func() {
+		head, err := readHeadN(strings.NewReader("short"), 1<<30)
+		So(err, ShouldBeNil)
+		So(string(head), ShouldEqual, "short")
+		So(cap(head), ShouldBeLessThan, 1<<20)
+	})
+}
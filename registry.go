@@ -0,0 +1,580 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	goMime "mime"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	clPath "github.com/go-corelibs/path"
+)
+
+// Table identifies one of the mapping tables maintained by a Registry
+type Table string
+
+const (
+	// ExtensionTable is the extension to mime type mapping table
+	ExtensionTable Table = "extension"
+	// CharsetTable is the mime type to charset mapping table
+	CharsetTable Table = "charset"
+	// AliasTable is the alias to canonical mime type mapping table
+	AliasTable Table = "alias"
+	// FilenameTable is the well-known file name to mime type mapping table
+	FilenameTable Table = "filename"
+	// GlobTable is the glob pattern to mime type mapping table
+	GlobTable Table = "glob"
+)
+
+// Registry is a set of extension, charset, alias, filename and glob mappings.
+// The package level functions all operate on a default Registry instance
+type Registry struct {
+	extensions *lookup
+	charsets   *lookup
+	aliases    *lookup
+	filenames  *lookup
+	globs      *lookup
+	preferred  *lookup
+	xmlRoots   *lookup
+	icons      *lookup
+	parents    *lookup
+	policy     atomic.Uint32
+	resolver   atomic.Pointer[Resolver]
+	pathForm   atomic.Uint32
+	options    atomic.Pointer[Options]
+	logger     atomic.Pointer[slog.Logger]
+
+	windowsPaths     atomic.Bool
+	noFollowSymlinks atomic.Bool
+	noCaseFolding    atomic.Bool
+	specialFiles     atomic.Bool
+	pathOnly         atomic.Bool
+	windowsRegistry  atomic.Bool
+
+	overrideFile atomic.Pointer[string]
+	overrides    *overrideCache
+
+	relations  *relationList
+	detectors  *textDetectorList
+	containers *containerRuleList
+	iana       *ianaTable
+	hooks      *hookList
+	utis       *utiTable
+}
+
+var gRegistry = New()
+
+// New constructs a new Registry instance, populated with the same built-in
+// extension and charset mappings as the default Registry
+func New() (r *Registry) {
+	r = &Registry{
+		extensions: newLookup(map[string]string{
+			"txt":     TextMimeType + "; charset=utf-8",
+			"html":    HtmlMimeType + "; charset=utf-8",
+			"css":     CssMimeType + "; charset=utf-8",
+			"scss":    ScssMimeType + "; charset=utf-8",
+			"json":    JsonMimeType + "; charset=utf-8",
+			"js":      JavaScriptMimeType + "; charset=utf-8",
+			"ndjson":  NdjsonMimeType + "; charset=utf-8",
+			"jsonl":   NdjsonMimeType + "; charset=utf-8",
+			"geojson": GeoJsonMimeType + "; charset=utf-8",
+		}),
+		charsets: newLookup(map[string]string{
+			TextMimeType:       "utf-8",
+			HtmlMimeType:       "utf-8",
+			CssMimeType:        "utf-8",
+			ScssMimeType:       "utf-8",
+			JsonMimeType:       "utf-8",
+			JavaScriptMimeType: "utf-8",
+			NdjsonMimeType:     "utf-8",
+			GeoJsonMimeType:    "utf-8",
+			EnjinMimeType:      "utf-8",
+			OrgModeMimeType:    "utf-8",
+			MarkdownMimeType:   "utf-8",
+			MakefileMimeType:   "utf-8",
+			DockerfileMimeType: "utf-8",
+		}),
+		aliases:    defaultAliases(),
+		filenames:  defaultFilenames(),
+		globs:      newLookup(map[string]string{}),
+		preferred:  newLookup(map[string]string{}),
+		xmlRoots:   defaultXMLRoots(),
+		icons:      newLookup(map[string]string{}),
+		parents:    newLookup(map[string]string{}),
+		overrides:  &overrideCache{m: map[string]*overrideEntry{}},
+		relations:  defaultRelations(),
+		detectors:  &textDetectorList{},
+		containers: defaultContainerRules(),
+		iana:       &ianaTable{m: map[string]IANARegistration{}},
+		hooks:      &hookList{},
+		utis:       defaultUTIs(),
+	}
+	r.extensions.update(func(m map[string]string) {
+		for extension, mime := range gCompoundExtensions {
+			m[extension] = mime
+		}
+	})
+	r.options.Store(&Options{})
+	r.windowsPaths.Store(runtime.GOOS == "windows")
+	r.watchTables()
+	r.installPlugins()
+	return
+}
+
+// Default returns the Registry instance used by the package level functions
+func Default() (r *Registry) {
+	return gRegistry
+}
+
+// GetExtension returns the mime type associated with the given extension
+// using SetExtension, or if not present uses mime.TypeByExtension to lookup
+// further. The mime type returned is always in the canonical form produced
+// by Normalize and shaped by the OutputPolicy, use GetExtensionRaw to get the
+// value as registered
+func (r *Registry) GetExtension(extension string) (mime string, ok bool) {
+	if mime, ok = r.GetExtensionRaw(extension); ok {
+		mime = r.output(mime)
+	}
+	return
+}
+
+// GetExtensionRaw is the same as GetExtension except that the mime type is
+// returned exactly as it was registered with SetExtension, as returned by
+// mime.TypeByExtension or, when enabled with SetWindowsRegistry, as found in
+// the Windows registry. The `extension` is matched without regard to case
+// unless case folding is disabled with SetCaseFolding
+func (r *Registry) GetExtensionRaw(extension string) (mime string, ok bool) {
+	if mime, ok = r.lookupExtension(extension); !ok {
+		extension = r.normalizeExtension(extension)
+		if mime = goMime.TypeByExtension("." + extension); maskedExtension("."+extension, mime) {
+			mime = ""
+		}
+		if ok = mime != ""; !ok {
+			mime, ok = r.fromWindowsRegistry(extension)
+		}
+	}
+	return
+}
+
+// SetExtension registers the given extension with the given mime type string.
+// There can only be one mime type associated per extension and SetExtension
+// will overwrite any existing value. If `mime` is empty, any association with
+// the extension is cleared
+func (r *Registry) SetExtension(extension, mime string) {
+	extension = r.normalizeExtension(extension)
+	if mime == "" {
+		r.extensions.unset(extension)
+		return
+	}
+	r.extensions.set(extension, mime)
+}
+
+// setExtensions is SetExtension for many extension and mime type pairs at
+// once, publishing a single copy of the extensions table
+func (r *Registry) setExtensions(entries [][2]string) {
+	r.extensions.update(func(m map[string]string) {
+		for _, entry := range entries {
+			extension := r.normalizeExtension(entry[0])
+			if entry[1] == "" {
+				delete(m, extension)
+			} else {
+				m[extension] = entry[1]
+			}
+		}
+	})
+}
+
+// GetCharset returns the `charset` associated with the given mime type, or
+// with its canonical type when the `mime` is a registered alias without a
+// charset of its own. When the `mime` has a charset parameter, that value is
+// returned instead unless it is CharsetEqual to the registered charset, in
+// which case the registered spelling is returned
+func (r *Registry) GetCharset(mime string) (charset string, ok bool) {
+	mediatype, params, _ := goMime.ParseMediaType(mime)
+	if charset, ok = r.charsets.get(mediatype); !ok {
+		if canonical, aliased := r.GetAlias(mediatype); aliased {
+			charset, ok = r.charsets.get(canonical)
+		}
+	}
+	if given := params["charset"]; given != "" && !CharsetEqual(given, charset) {
+		charset, ok = given, true
+	}
+	return
+}
+
+// SetCharset registers the given mime type with the given charset string.
+// There can only be one charset associated per mime type and SetCharset
+// will overwrite any existing value. If `charset` is empty, any association
+// with the mime type is cleared
+func (r *Registry) SetCharset(mime, charset string) {
+	mime = PruneCharset(mime)
+	if charset == "" {
+		r.charsets.unset(mime)
+		return
+	}
+	r.charsets.set(mime, charset)
+}
+
+// GetAlias returns the canonical mime type associated with the given alias
+func (r *Registry) GetAlias(alias string) (canonical string, ok bool) {
+	canonical, ok = r.aliases.get(PruneCharset(alias))
+	return
+}
+
+// SetAlias registers the given alias mime type as another name for the
+// canonical mime type given. If `canonical` is empty, any association with
+// the alias is cleared
+func (r *Registry) SetAlias(alias, canonical string) {
+	alias = PruneCharset(alias)
+	if canonical == "" {
+		r.aliases.unset(alias)
+		return
+	}
+	r.aliases.set(alias, PruneCharset(canonical))
+}
+
+// IsPlainText returns true if the given `mime` is of `text/plain` type.
+// Registered aliases are replaced with their canonical type (see Canonical)
+// and IsPlainText checks if it has a registered charset first and if so, returns
+// true early. Types with a structured syntax suffix implying textual
+// content, such as +json, +xml or +yaml, are also plain text. Otherwise,
+// IsPlainText uses IsKindOf to check if the given `mime` is exactly
+// TextMimeType or if any of its Ancestors are TextMimeType
+func (r *Registry) IsPlainText(mime string) (yes bool) {
+	mime = PruneCharset(r.Canonical(mime))
+	if _, yes = r.GetCharset(mime); yes {
+		return
+	} else if yes = hasTextualSuffix(mime); yes {
+		return
+	}
+	yes = r.IsKindOf(mime, TextMimeType)
+	return
+}
+
+// IsTextual returns true if the given `mime` can reasonably be rendered as
+// text, a broader check than IsPlainText: any type of the "text" top-level
+// type, well-known textual application types such as JsonMimeType and
+// "application/javascript", checked both as given and in their Canonical
+// form, as well as every type IsPlainText returns true for, which includes
+// the types with a registered charset or a textual structured syntax suffix
+func (r *Registry) IsTextual(mime string) (yes bool) {
+	for _, mediatype := range []string{PruneCharset(mime), PruneCharset(r.Canonical(mime))} {
+		if yes = strings.HasPrefix(mediatype, "text/"); yes {
+			return
+		} else if _, yes = gTextualTypes[mediatype]; yes {
+			return
+		}
+	}
+	yes = r.IsPlainText(mime)
+	return
+}
+
+// FromPathOnly checks the given `path` for any extensions using
+// github.com/go-corelibs/path.ExtExt and uses GetExtension with any
+// extensions found. The `path` is normalized according to the PathForm, and
+// cleaned with SplitWindowsName when Windows path handling is enabled,
+// before the extensions are extracted. Well-known file names registered with
+// SetFilename are checked first, then glob patterns registered with SetGlob,
+// then the longest compound extension (see SetCompoundExtension) and then
+// any other extensions, looking through a trailing template extension (see
+// Options.TemplateExtensions) to the one before it
+func (r *Registry) FromPathOnly(path string) (mime string) {
+	if path != "" {
+		path = r.normalizePath(path)
+		if r.GetWindowsPaths() {
+			path, _ = SplitWindowsName(path)
+		}
+		if known, ok := r.GetFilename(r.baseName(path)); ok {
+			mime = known
+		} else if matched, ok := r.fromGlobs(path); ok {
+			mime = matched
+		} else if compound, ok := r.fromCompound(path); ok {
+			mime = compound
+		} else if a, b := clPath.ExtExt(path); b != "" && r.isTemplateExtension(a) {
+			mime, _ = r.GetExtension(b)
+		} else if a != "" {
+			mime, _ = r.GetExtension(a)
+		}
+	}
+	return
+}
+
+// Mime returns the MIME type string of a local filesystem directory or file.
+// The specific type returned for directories is defined by the
+// DirectoryMimeType constant. Files with names that CheckPath considers
+// suspicious are classified by their content only. Per-directory override
+// files, when enabled with SetOverrideFile, take precedence over all other
+// rules for files. Textual types found by extension are given the charset
+// detected from the file content, see DetectCharset. When a Resolver is
+// set, it is consulted before content detection for extension-less names
+// and after content detection fails to identify anything more specific
+// than BinaryMimeType for all other names. Errors are not returned, an empty
+// string is returned for paths which cannot be classified, use MimeE to
+// receive the reason. In path-only mode, see SetPathOnly, Mime is PathMime
+func (r *Registry) Mime(path string) (mime string) {
+	mime, _ = r.mime(context.Background(), path)
+	return
+}
+
+// mime is Mime reading the file content with readFileHeadContext, returning
+// the context error instead of a classification once `ctx` ends
+func (r *Registry) mime(ctx context.Context, path string) (mime string, err error) {
+	if r.GetPathOnly() {
+		return r.PathMime(path), nil
+	} else if inode, ok := r.inodeMime(path); ok {
+		return inode, nil
+	} else if clPath.IsDir(path) {
+		mime = DirectoryMimeType
+		return
+	} else if clPath.IsRegularFile(path) {
+		if overridden, ok := r.fromOverrides(path); ok {
+			return r.output(overridden), nil
+		} else if mime, _ = r.FromPathChecked(path); mime != "" && !r.IsPlainText(mime) {
+			return
+		}
+		head, ee := r.readFileHeadContext(ctx, path)
+		if err = ctx.Err(); err != nil {
+			return "", err
+		}
+		mime = r.fromHead(nil, path, mime, head, ee)
+	}
+	return
+}
+
+// fromHead classifies the regular file at `path` within `fsys`, nil for the
+// local filesystem, which has no override, where `byExtension` is the
+// FromPathChecked result and `head` is the leading content of the file, with
+// `err` being any error encountered reading it. Textual extension matches
+// carry the charset of the actual content, see extensionMime, files not
+// identified by name are classified by fromContent
+func (r *Registry) fromHead(fsys fs.FS, path, byExtension string, head []byte, err error) (mime string) {
+	if byExtension == "" {
+		return r.fromContent(fsys, path, head, err)
+	} else if err == nil {
+		return r.extensionMime(byExtension, head)
+	}
+	return byExtension
+}
+
+// extensionMime returns the extension based `byExtension` type of a file
+// with the leading `head` content, textual types carry the charset of the
+// actual content instead of the registered charset
+func (r *Registry) extensionMime(byExtension string, head []byte) (mime string) {
+	if r.IsPlainText(byExtension) {
+		return r.output(r.withDetectedCharset(byExtension, head))
+	}
+	return byExtension
+}
+
+// fromContent classifies the `head` content of the file at `path` within
+// `fsys`, nil for the local filesystem, consulting any Resolver, with `err`
+// being any error encountered reading the `head`
+func (r *Registry) fromContent(fsys fs.FS, path string, head []byte, err error) (mime string) {
+	resolver := r.GetResolver()
+	extensionless := clPath.Ext(path) == ""
+	if resolver != nil && extensionless {
+		if resolved, ok := resolver(path, head); ok {
+			return r.output(resolved)
+		}
+	}
+	if err != nil {
+		r.logWarn("mime: unable to read file", "path", path, "err", err)
+		return
+	}
+	detected := r.detect(head)
+	if r.IsZipContainer(detected) {
+		if contained, ok := r.fromContainerFile(fsys, path); ok {
+			return r.output(contained)
+		}
+	}
+	if resolver != nil && !extensionless && PruneCharset(detected) == BinaryMimeType {
+		if resolved, ok := resolver(path, head); ok {
+			return r.output(resolved)
+		}
+	}
+	if PruneCharset(detected) == BinaryMimeType {
+		r.logDebug("mime: content not recognized", "path", path, "mime", detected)
+	}
+	return r.output(r.withDetectedCharset(detected, head))
+}
+
+// RegisterDetector adds the given content Detector to the Registry only,
+// other Registry instances are not affected. Registry detectors are
+// consulted before plugin detectors, highest Detector.Priority first and
+// then most recently registered first
+func (r *Registry) RegisterDetector(d Detector) (err error) {
+	defer r.logRegistration(d.Mime, &err)
+	if d.Mime == "" || d.Detect == nil {
+		return errors.New("detector must have a mime type and a detect function")
+	}
+	r.detectors.add(d)
+	return
+}
+
+// RegisterTextType is the Registry instance version of the package level
+// RegisterTextType, associating the given `mime` with the given `extension`
+// and the Options.DefaultCharset within the Registry only. Unlike the
+// package level function, a nil `detector` registers the `extension` without
+// any content detection, because the Registry detectors are not nested
+// beneath TextMimeType the way the github.com/gabriel-vasile/mimetype ones
+// are
+func (r *Registry) RegisterTextType(mime, extension string, detector func(raw []byte, limit uint32) bool) (err error) {
+	return r.RegisterTextTypePriority(mime, extension, detector, 0)
+}
+
+// RegisterTextTypePriority is the Registry instance version of the package
+// level RegisterTextTypePriority, adding any `detector` to the Registry
+// detectors with the given Detector.Priority
+func (r *Registry) RegisterTextTypePriority(mime, extension string, detector func(raw []byte, limit uint32) bool, priority int) (err error) {
+	defer r.logRegistration(mime, &err)
+	var mediatype string
+	charset := r.defaultCharset()
+	if mime, mediatype, extension, err = parseTextType(mime, extension, charset); err != nil {
+		return
+	}
+	r.SetExtension(extension, mime)
+	r.SetCharset(mediatype, charset)
+	r.SetParent(mediatype, TextMimeType)
+	if detector != nil {
+		r.detectors.add(Detector{Mime: mime, Detect: detector, Priority: priority})
+	}
+	return
+}
+
+// RegisterTextTypeExt is the Registry instance version of the package level
+// RegisterTextTypeExt, see RegisterTextType for how the `detector` is used
+func (r *Registry) RegisterTextTypeExt(mime string, detector func(raw []byte, limit uint32) bool, extensions ...string) (err error) {
+	defer r.logRegistration(mime, &err)
+	if mime, _, extensions, err = parseTextTypeExt(mime, r.defaultCharset(), extensions); err != nil {
+		return
+	} else if err = r.RegisterTextType(mime, extensions[0], detector); err != nil {
+		return
+	}
+	for _, extension := range extensions[1:] {
+		r.SetExtension(extension, mime)
+	}
+	return
+}
+
+// detect returns the mime type of the `head` content, consulting the
+// Registry detectors and plugin detectors before
+// github.com/gabriel-vasile/mimetype
+func (r *Registry) detect(head []byte) (mime string) {
+	mime, _ = r.detectSource(head)
+	return
+}
+
+// detectSource is detect which also reports the Source of the result, one
+// of SourceDetector, SourceMagic or, for generic text and binary results,
+// SourceContent
+func (r *Registry) detectSource(head []byte) (mime string, source Source) {
+	limit := r.GetReadLimit()
+	if len(head) > limit {
+		head = head[:limit]
+	}
+	var ok bool
+	if mime, ok = r.detectors.detect(head, uint32(limit)); ok {
+		return mime, SourceDetector
+	} else if mime, ok = detectPlugins(head, uint32(limit)); ok {
+		return mime, SourceDetector
+	}
+	mime = mimetype.Detect(head).String()
+	switch mediatype := PruneCharset(mime); {
+	case mediatype == TextMimeType, mediatype == BinaryMimeType:
+		source = SourceContent
+	case isJSONFamily(mediatype):
+		// github.com/gabriel-vasile/mimetype checks for complete lines and
+		// values only, so apply the same heuristics as for shadowed json
+		if refined, found := detectJSON(head, len(head) >= limit); found {
+			mime = refined
+		}
+		source = SourceMagic
+	case mediatype == XmlMimeType:
+		// generic xml, refined by the root element
+		if refined, found := r.fromXMLRoot(head); found {
+			mime = refined
+		}
+		source = SourceMagic
+	case mediatype == "image/svg+xml" && !startsWithMarkup(head):
+		// github.com/gabriel-vasile/mimetype matches "<svg" anywhere within
+		// the content, so text mentioning svg markup is still text
+		mime, source = r.detectText(head, len(head) >= limit, uint32(limit))
+	case gTextDetectors.has(mediatype), gCatchAllDetectors.has(mediatype):
+		// one of the RegisterTextType types, chosen by registration order
+		// within the mimetype tree, so apply the RegisterTextTypePriority
+		// chain instead, to the body of pages having front-matter
+		if body, found := splitFrontMatter(head); found {
+			mime, source = r.detectText(body, len(head) >= limit, uint32(limit))
+		} else {
+			mime, source = r.detectText(head, len(head) >= limit, uint32(limit))
+		}
+	default:
+		source = SourceMagic
+	}
+	return
+}
+
+// detectText applies the RegisterTextTypePriority chain to the text `head`
+// content, which is `truncated` when it is only the leading portion of the
+// content: the text types with detectors, XML root elements and JSON
+// dialects, which would otherwise be shadowed by the catch-all types, and
+// then the catch-all types
+func (r *Registry) detectText(head []byte, truncated bool, limit uint32) (mime string, source Source) {
+	var ok bool
+	if mime, ok = gTextDetectors.detect(head, limit); ok {
+		return mime, SourceDetector
+	} else if mime, ok = r.fromXMLRoot(head); ok {
+		return mime, SourceMagic
+	} else if mime, ok = detectJSON(head, truncated); ok {
+		return mime, SourceMagic
+	} else if mime, ok = gCatchAllDetectors.detect(head, limit); ok {
+		return mime, SourceContent
+	}
+	return TextMimeType, SourceContent
+}
+
+// detectFile reads the leading GetReadLimit bytes of the file at `path` and
+// returns the detected mime type
+func (r *Registry) detectFile(path string) (mime string, err error) {
+	var head []byte
+	if head, err = r.readFileHead(path); err != nil {
+		return
+	}
+	mime = r.detect(head)
+	return
+}
+
+func (r *Registry) table(t Table) (l *lookup) {
+	switch t {
+	case ExtensionTable:
+		l = r.extensions
+	case CharsetTable:
+		l = r.charsets
+	case AliasTable:
+		l = r.aliases
+	case FilenameTable:
+		l = r.filenames
+	case GlobTable:
+		l = r.globs
+	}
+	return
+}
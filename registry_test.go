@@ -0,0 +1,67 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRegistry(t *testing.T) {
+	Convey("Registry", t, func() {
+		So(Default(), ShouldEqual, gRegistry)
+
+		r := New()
+		r.SetExtension(".thing", "application/x-thing")
+		mime, ok := r.GetExtension("thing")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "application/x-thing")
+		_, ok = GetExtension("thing")
+		So(ok, ShouldBeFalse)
+
+		r.SetAlias("text/x-thing; charset=utf-8", "text/thing")
+		canonical, ok := r.GetAlias("text/x-thing")
+		So(ok, ShouldBeTrue)
+		So(canonical, ShouldEqual, "text/thing")
+		r.SetAlias("text/x-thing", "")
+		_, ok = r.GetAlias("text/x-thing")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Registry detectors", t, func() {
+		detector := func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("%THING"))
+		}
+		r := New()
+		So(r.RegisterTextType("", "thing", detector), ShouldNotBeNil)
+		So(r.RegisterTextType("text/x-thing", ".thing", detector), ShouldBeNil)
+		mime, ok := r.GetExtension("thing")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "text/x-thing; charset=utf-8")
+		So(r.IsPlainText("text/x-thing"), ShouldBeTrue)
+		So(r.detect([]byte("%THING 1")), ShouldEqual, "text/x-thing; charset=utf-8")
+		So(gRegistry.detect([]byte("%THING 1")), ShouldNotStartWith, "text/x-thing")
+		So(r.DetectString("%THING 1"), ShouldEqual, "text/x-thing; charset=utf-8")
+
+		So(r.RegisterDetector(Detector{}), ShouldNotBeNil)
+		So(r.RegisterDetector(Detector{Mime: "application/x-other", Detect: func(raw []byte, limit uint32) bool {
+			return bytes.HasPrefix(raw, []byte("%THING 2"))
+		}}), ShouldBeNil)
+		So(r.detect([]byte("%THING 2")), ShouldEqual, "application/x-other")
+		So(r.detect([]byte("%THING 1")), ShouldEqual, "text/x-thing; charset=utf-8")
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+	"sync"
+)
+
+// Relation identifies the kind of relationship between two mime types
+type Relation string
+
+const (
+	// ConvertsTo relates a source type to the types it can be transformed
+	// into, for example ScssMimeType converts to CssMimeType
+	ConvertsTo Relation = "converts-to"
+	// ConvertsFrom is the inverse of ConvertsTo and is derived from the
+	// ConvertsTo relationships, it cannot be added directly
+	ConvertsFrom Relation = "converts-from"
+)
+
+type relationEdge struct {
+	from     string
+	relation Relation
+	to       string
+}
+
+type relationList struct {
+	edges []relationEdge
+	sync.RWMutex
+}
+
+// remove deletes all relationships from or to the given `mediatype`,
+// returning true if any were present
+func (l *relationList) remove(mediatype string) (removed bool) {
+	l.Lock()
+	defer l.Unlock()
+	kept := make([]relationEdge, 0, len(l.edges))
+	for _, edge := range l.edges {
+		if edge.from == mediatype || edge.to == mediatype {
+			removed = true
+			continue
+		}
+		kept = append(kept, edge)
+	}
+	l.edges = kept
+	return
+}
+
+// defaultRelations returns the built-in relationships of a new Registry
+func defaultRelations() (l *relationList) {
+	return &relationList{edges: []relationEdge{
+		{from: ScssMimeType, relation: ConvertsTo, to: CssMimeType},
+		{from: MarkdownMimeType, relation: ConvertsTo, to: HtmlMimeType},
+		{from: OrgModeMimeType, relation: ConvertsTo, to: HtmlMimeType},
+		{from: EnjinMimeType, relation: ConvertsTo, to: HtmlMimeType},
+	}}
+}
+
+// AddRelation declares that the `from` mime type has the given `relation`
+// with the `to` mime type, for example:
+//
+//	r.AddRelation("text/x-sass", mime.ConvertsTo, mime.CssMimeType)
+//
+// Parameters are ignored. Adding an existing relationship, or any
+// ConvertsFrom relationship, does nothing
+func (r *Registry) AddRelation(from string, relation Relation, to string) {
+	from, to = PruneCharset(from), PruneCharset(to)
+	if from == "" || to == "" || relation == "" || relation == ConvertsFrom {
+		return
+	}
+	r.relations.Lock()
+	defer r.relations.Unlock()
+	edge := relationEdge{from: from, relation: relation, to: to}
+	for _, existing := range r.relations.edges {
+		if existing == edge {
+			return
+		}
+	}
+	r.relations.edges = append(r.relations.edges, edge)
+}
+
+// RemoveRelation removes a relationship previously declared by AddRelation,
+// including the built-in relationships
+func (r *Registry) RemoveRelation(from string, relation Relation, to string) {
+	edge := relationEdge{from: PruneCharset(from), relation: relation, to: PruneCharset(to)}
+	r.relations.Lock()
+	defer r.relations.Unlock()
+	for idx, existing := range r.relations.edges {
+		if existing == edge {
+			r.relations.edges = append(r.relations.edges[:idx], r.relations.edges[idx+1:]...)
+			return
+		}
+	}
+}
+
+// RelatedTypes returns the sorted list of mime types having the given
+// `relation` with the given `mime` type. Build pipelines can use this to
+// discover processing chains, for example the ConvertsTo relations of
+// MarkdownMimeType include HtmlMimeType
+func (r *Registry) RelatedTypes(mime string, relation Relation) (related []string) {
+	mime = PruneCharset(mime)
+	r.relations.RLock()
+	defer r.relations.RUnlock()
+	for _, edge := range r.relations.edges {
+		switch {
+		case relation == ConvertsFrom && edge.relation == ConvertsTo && edge.to == mime:
+			related = append(related, edge.from)
+		case edge.relation == relation && edge.from == mime:
+			related = append(related, edge.to)
+		}
+	}
+	sort.Strings(related)
+	return
+}
+
+// AddRelation declares a relationship within the default Registry, see
+// Registry.AddRelation
+func AddRelation(from string, relation Relation, to string) {
+	gRegistry.AddRelation(from, relation, to)
+}
+
+// RemoveRelation removes a relationship from the default Registry
+func RemoveRelation(from string, relation Relation, to string) {
+	gRegistry.RemoveRelation(from, relation, to)
+}
+
+// RelatedTypes returns the related types within the default Registry, see
+// Registry.RelatedTypes
+func RelatedTypes(mime string, relation Relation) (related []string) {
+	return gRegistry.RelatedTypes(mime, relation)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRelatedTypes(t *testing.T) {
+	Convey("RelatedTypes", t, func() {
+		So(RelatedTypes(ScssMimeType, ConvertsTo), ShouldResemble, []string{CssMimeType})
+		So(RelatedTypes(MarkdownMimeType+"; charset=utf-8", ConvertsTo), ShouldResemble, []string{HtmlMimeType})
+		So(RelatedTypes(HtmlMimeType, ConvertsFrom), ShouldResemble, []string{EnjinMimeType, MarkdownMimeType, OrgModeMimeType})
+		So(RelatedTypes(CssMimeType, ConvertsTo), ShouldBeEmpty)
+
+		r := New()
+		r.AddRelation("text/x-sass", ConvertsTo, CssMimeType)
+		r.AddRelation("text/x-sass", ConvertsTo, CssMimeType)
+		r.AddRelation(CssMimeType, ConvertsFrom, "text/x-less")
+		r.AddRelation(MarkdownMimeType, "previewed-as", "image/png")
+		So(r.RelatedTypes(CssMimeType, ConvertsFrom), ShouldResemble, []string{"text/x-sass", ScssMimeType})
+		So(r.RelatedTypes(MarkdownMimeType, "previewed-as"), ShouldResemble, []string{"image/png"})
+
+		r.RemoveRelation(ScssMimeType, ConvertsTo, CssMimeType)
+		So(r.RelatedTypes(ScssMimeType, ConvertsTo), ShouldBeEmpty)
+		So(RelatedTypes(ScssMimeType, ConvertsTo), ShouldResemble, []string{CssMimeType})
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultRemoteBudget is the Budget used when RemoteOptions.Budget is zero,
+// enough for the default read limit, the TailWindow and any trailing
+// signature probes
+const DefaultRemoteBudget = 128 << 10
+
+// RemoteOptions configures RemoteMime
+type RemoteOptions struct {
+	// Client is the http.Client used, http.DefaultClient when nil
+	Client *http.Client
+	// Budget is the maximum total number of content bytes requested
+	Budget int64
+}
+
+// RemoteMime classifies the remote object at `url` using HTTP Range requests
+// to fetch only the byte ranges needed: the leading bytes, up to the read
+// limit of the default Registry (see SetReadLimit), and, when those are not
+// conclusive, the trailing TailWindow bytes and any signature probes used by
+// DetectAt. The response Content-Type and any HEAD request results are never
+// trusted. When the server does not support Range requests, only the leading
+// bytes of the response are read. No
+// more than the RemoteOptions.Budget is ever read and when the total bytes
+// needed would exceed it, the result of the leading bytes alone is returned.
+// See WithProgress for the `detectOptions` available, any WithByteBudget is
+// replaced by the RemoteOptions.Budget
+func RemoteMime(ctx context.Context, url string, options RemoteOptions, detectOptions ...DetectOption) (mime string, err error) {
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	if options.Budget <= 0 {
+		options.Budget = DefaultRemoteBudget
+	}
+	config := newDetectConfig(detectOptions)
+	rr := &rangeReaderAt{ctx: ctx, client: options.Client, url: url}
+	limit := min(options.Budget, int64(gRegistry.GetReadLimit()))
+
+	var resp *http.Response
+	if resp, err = rr.get(0, limit); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var size int64
+	switch resp.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		// an empty object
+		return gRegistry.output(gRegistry.detect(nil)), nil
+	case http.StatusOK:
+		// the Range header was ignored, do not read any further than needed
+		var head []byte
+		if head, err = readHeadN(resp.Body, int(limit)); err != nil {
+			return
+		} else if err = config.report(Progress{Stage: "head", BytesRead: int64(len(head)), Size: resp.ContentLength}); err != nil {
+			return
+		}
+		return gRegistry.output(gRegistry.detect(head)), nil
+	case http.StatusPartialContent:
+		var start int64
+		if start, size, err = parseContentRange(resp.Header.Get("Content-Range")); err != nil {
+			return
+		} else if start != 0 {
+			err = fmt.Errorf("unexpected Content-Range start: %d, requested 0", start)
+			return
+		}
+		if rr.cached, err = io.ReadAll(io.LimitReader(resp.Body, limit)); err != nil {
+			return
+		}
+	default:
+		err = fmt.Errorf("unexpected response status: %s", resp.Status)
+		return
+	}
+
+	if size < 0 || options.Budget < min(size, int64(gRegistry.GetReadLimit())) {
+		// total size unknown or no budget for more, classify what we have
+		if err = config.report(Progress{Stage: "head", BytesRead: int64(len(rr.cached)), Size: size}); err != nil {
+			return
+		}
+		return gRegistry.output(gRegistry.detect(rr.cached)), nil
+	}
+	// DetectAt reports the progress of the cached leading bytes as its head
+	// stage, followed by any further range requests
+	detectOptions = append(detectOptions[:len(detectOptions):len(detectOptions)], WithByteBudget(options.Budget))
+	if mime, err = DetectAt(rr, size, detectOptions...); errors.Is(err, ErrBudgetExceeded) {
+		mime, err = gRegistry.output(gRegistry.detect(rr.cached)), nil
+	}
+	return
+}
+
+// rangeReaderAt is an io.ReaderAt fetching each read with an HTTP Range
+// request, serving reads of the leading bytes from the cached first response
+type rangeReaderAt struct {
+	ctx    context.Context
+	client *http.Client
+	url    string
+	cached []byte
+}
+
+func (r *rangeReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if off+int64(len(p)) <= int64(len(r.cached)) {
+		return copy(p, r.cached[off:]), nil
+	}
+	var resp *http.Response
+	if resp, err = r.get(off, int64(len(p))); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		err = fmt.Errorf("unexpected range response status: %s", resp.Status)
+		return
+	}
+	var start int64
+	if start, _, err = parseContentRange(resp.Header.Get("Content-Range")); err != nil {
+		return
+	} else if start != off {
+		// the server ignored the requested offset
+		err = fmt.Errorf("unexpected Content-Range start: %d, requested %d", start, off)
+		return
+	}
+	if n, err = io.ReadFull(resp.Body, p); err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return
+}
+
+// get requests `length` bytes starting at `offset`
+func (r *rangeReaderAt) get(offset, length int64) (resp *http.Response, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil); err != nil {
+		return
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+max(length, 1)-1))
+	return r.client.Do(req)
+}
+
+// parseContentRange returns the first byte position and the complete length
+// of a Content-Range header value, the `size` is -1 when the complete length
+// is not known
+func parseContentRange(value string) (start, size int64, err error) {
+	spec, complete, found := strings.Cut(strings.TrimPrefix(value, "bytes "), "/")
+	first, _, ranged := strings.Cut(spec, "-")
+	if !found || !ranged || !strings.HasPrefix(value, "bytes ") {
+		err = fmt.Errorf("invalid Content-Range: %q", value)
+		return
+	} else if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+		err = fmt.Errorf("invalid Content-Range: %q", value)
+		return
+	} else if complete == "*" {
+		return start, -1, nil
+	}
+	if size, err = strconv.ParseInt(complete, 10, 64); err != nil {
+		err = fmt.Errorf("invalid Content-Range: %q", value)
+	}
+	return
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRemoteMime(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	iso := make([]byte, 0x9000)
+	copy(iso[0x8001:], "CD001")
+	large := make([]byte, TailWindow*2)
+	objects := map[string][]byte{"/png": png, "/iso": iso, "/large": large, "/empty": {}}
+
+	var served atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		// lie about the content type, RemoteMime must never trust it
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.URL.Query().Get("ranges") {
+		case "no":
+			r.Header.Del("Range")
+		case "offsetless":
+			// always answer with the leading bytes, whatever was requested
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", HeadWindow-1, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(data[:HeadWindow])
+			return
+		}
+		cw := &countingWriter{ResponseWriter: w, count: &served}
+		http.ServeContent(cw, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	Convey("RemoteMime", t, func() {
+		Convey("head only", func() {
+			served.Store(0)
+			mime, err := RemoteMime(ctx, server.URL+"/png", RemoteOptions{})
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, "image/png")
+			So(served.Load(), ShouldEqual, int64(len(png)))
+		})
+
+		Convey("head, tail and probes", func() {
+			served.Store(0)
+			mime, err := RemoteMime(ctx, server.URL+"/iso", RemoteOptions{})
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, IsoMimeType)
+			So(served.Load(), ShouldEqual, int64(HeadWindow+len(iso)+5))
+		})
+
+		Convey("budget exceeded", func() {
+			served.Store(0)
+			mime, err := RemoteMime(ctx, server.URL+"/iso", RemoteOptions{Budget: HeadWindow})
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, BinaryMimeType)
+			So(served.Load(), ShouldEqual, int64(HeadWindow))
+		})
+
+		Convey("ranges not supported", func() {
+			mime, err := RemoteMime(ctx, server.URL+"/iso?ranges=no", RemoteOptions{})
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, BinaryMimeType)
+
+			var read int64
+			mime, err = RemoteMime(ctx, server.URL+"/png?ranges=no", RemoteOptions{Budget: 16}, WithProgress(func(p Progress) bool {
+				read = p.BytesRead
+				return true
+			}))
+			So(err, ShouldBeNil)
+			So(read, ShouldEqual, 16)
+			So(mime, ShouldEqual, "image/png")
+		})
+
+		Convey("range offset ignored", func() {
+			_, err := RemoteMime(ctx, server.URL+"/large?ranges=offsetless", RemoteOptions{Budget: TailWindow * 4})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "unexpected Content-Range start")
+		})
+
+		Convey("progress", func() {
+			var stages []string
+			mime, err := RemoteMime(ctx, server.URL+"/iso", RemoteOptions{}, WithProgress(func(p Progress) bool {
+				stages = append(stages, p.Stage)
+				So(p.Size, ShouldEqual, int64(len(iso)))
+				return true
+			}))
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, IsoMimeType)
+			So(stages, ShouldResemble, []string{"head", "tail", "probe"})
+
+			served.Store(0)
+			_, err = RemoteMime(ctx, server.URL+"/iso", RemoteOptions{}, WithProgress(func(p Progress) bool {
+				return p.Stage == "head"
+			}))
+			So(err, ShouldEqual, ErrAborted)
+			So(served.Load(), ShouldEqual, int64(HeadWindow+len(iso)))
+
+			_, err = RemoteMime(ctx, server.URL+"/iso", RemoteOptions{Budget: HeadWindow}, WithProgress(func(p Progress) bool {
+				return false
+			}))
+			So(err, ShouldEqual, ErrAborted)
+		})
+
+		Convey("errors", func() {
+			_, err := RemoteMime(ctx, server.URL+"/missing", RemoteOptions{})
+			So(err, ShouldNotBeNil)
+			_, err = RemoteMime(ctx, "http://\x00", RemoteOptions{})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	count *atomic.Int64
+}
+
+func (w *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = w.ResponseWriter.Write(p)
+	w.count.Add(int64(n))
+	return
+}
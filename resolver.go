@@ -0,0 +1,50 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+// Resolver is an application provided callback used by Mime to classify
+// files that the Registry cannot. The `path` is the path given to Mime and
+// `peek` is the leading GetReadLimit bytes of the file content, which may be
+// nil if the file could not be read. Resolver returns false to defer to the
+// Registry result
+type Resolver func(path string, peek []byte) (mime string, ok bool)
+
+// SetResolver configures the Resolver used by Mime, a nil `resolver` removes
+// any existing Resolver
+func (r *Registry) SetResolver(resolver Resolver) {
+	if resolver == nil {
+		r.resolver.Store(nil)
+		return
+	}
+	r.resolver.Store(&resolver)
+}
+
+// GetResolver returns the current Resolver, if any
+func (r *Registry) GetResolver() (resolver Resolver) {
+	if ptr := r.resolver.Load(); ptr != nil {
+		resolver = *ptr
+	}
+	return
+}
+
+// SetResolver configures the Resolver used by the default Registry
+func SetResolver(resolver Resolver) {
+	gRegistry.SetResolver(resolver)
+}
+
+// GetResolver returns the Resolver used by the default Registry
+func GetResolver() (resolver Resolver) {
+	return gRegistry.GetResolver()
+}
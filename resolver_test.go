@@ -0,0 +1,66 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolver(t *testing.T) {
+	dir := t.TempDir()
+	content := filepath.Join(dir, "content")
+	_ = os.Mkdir(content, 0755)
+	_ = os.WriteFile(filepath.Join(content, "about"), []byte("plain words"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "data.unknown-ext"), []byte{0, 1, 2, 3}, 0644)
+	png, _ := os.ReadFile("./testdata/empty-png")
+	_ = os.WriteFile(filepath.Join(content, "logo"), png, 0644)
+
+	Convey("Resolver", t, func() {
+		r := New()
+		So(r.GetResolver(), ShouldBeNil)
+		So(r.Mime(filepath.Join(dir, "data.unknown-ext")), ShouldEqual, BinaryMimeType)
+
+		var calls []string
+		r.SetResolver(func(path string, peek []byte) (mime string, ok bool) {
+			calls = append(calls, filepath.Base(path))
+			if strings.Contains(path, "/content/") && string(peek) == "plain words" {
+				return EnjinMimeType + "; charset=utf-8", true
+			} else if strings.HasSuffix(path, ".unknown-ext") {
+				return "application/x-unknown", true
+			}
+			return "", false
+		})
+		So(r.GetResolver(), ShouldNotBeNil)
+
+		So(r.Mime(filepath.Join(content, "about")), ShouldEqual, "text/enjin; charset=utf-8")
+		So(r.Mime(filepath.Join(content, "logo")), ShouldEqual, "image/png")
+		So(r.Mime(filepath.Join(dir, "data.unknown-ext")), ShouldEqual, "application/x-unknown")
+		So(r.Mime("./testdata/README.md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(calls, ShouldResemble, []string{"about", "logo", "data.unknown-ext"})
+
+		r.SetResolver(nil)
+		So(r.GetResolver(), ShouldBeNil)
+
+		So(GetResolver(), ShouldBeNil)
+		SetResolver(func(path string, peek []byte) (string, bool) { return "", false })
+		So(GetResolver(), ShouldNotBeNil)
+		SetResolver(nil)
+	})
+}
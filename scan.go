@@ -0,0 +1,223 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultScanOpenFiles is the MaxOpenFiles used when
+	// ScanOptions.MaxOpenFiles is zero
+	DefaultScanOpenFiles = 16
+)
+
+// ErrScanTimeout is the ScanEntry.Err of files which could not be read
+// within the ScanOptions.Timeout
+var ErrScanTimeout = errors.New("scan timeout reading file")
+
+// ScanOptions bounds the resources used by Scan
+type ScanOptions struct {
+	// MaxOpenFiles is the maximum number of files open at any one time
+	MaxOpenFiles int
+	// MaxBytes is the maximum number of bytes read from each file, zero or
+	// anything larger than the read limit reads the read limit, see
+	// SetReadLimit
+	MaxBytes int64
+	// Timeout is the maximum duration spent reading each file, zero for no
+	// limit
+	Timeout time.Duration
+	// Skip is a list of filepath.Match glob patterns, matched against both
+	// the base name and the path relative to the scan root. Matching
+	// directories are not descended into
+	Skip []string
+}
+
+// ScanEntry is a single file or directory found by Scan
+type ScanEntry struct {
+	// Path is the path of the entry, relative to the scan root
+	Path string
+	// Size is the size of the entry in bytes
+	Size int64
+	// Mime is the mime type of the entry, empty if Err is not nil
+	Mime string
+	// Err is any error encountered while classifying the entry
+	Err error
+}
+
+// ScanReport is the result of a Scan
+type ScanReport struct {
+	// Entries are all the entries found, sorted by Path
+	Entries []ScanEntry
+	// Counts is the number of entries found for each mime type, with the
+	// parameters pruned
+	Counts map[string]int
+	// Skipped is the number of entries matching ScanOptions.Skip
+	Skipped int
+	// Errors is the number of entries with a non-nil Err
+	Errors int
+}
+
+// Scan walks the directory tree rooted at `root` and classifies every entry
+// below it the same way as Mime, using any override file and the name first
+// and falling back to the content of the file. The resources used are
+// bounded by the given `options`. Scan returns ErrPathOnly in path-only
+// mode, see SetPathOnly
+func (r *Registry) Scan(root string, options ScanOptions) (report ScanReport, err error) {
+	if r.GetPathOnly() {
+		err = ErrPathOnly
+		return
+	}
+	if options.MaxOpenFiles <= 0 {
+		options.MaxOpenFiles = DefaultScanOpenFiles
+	}
+	if limit := int64(r.GetReadLimit()); options.MaxBytes <= 0 || options.MaxBytes > limit {
+		options.MaxBytes = limit
+	}
+	for _, pattern := range options.Skip {
+		if _, err = filepath.Match(pattern, ""); err != nil {
+			return
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, options.MaxOpenFiles)
+	report.Counts = map[string]int{}
+
+	add := func(entry ScanEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Entries = append(report.Entries, entry)
+		if entry.Err != nil {
+			report.Errors += 1
+		} else {
+			report.Counts[PruneCharset(entry.Mime)] += 1
+		}
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		rel, _ := filepath.Rel(root, path)
+		if walkErr != nil {
+			if rel != "." {
+				add(ScanEntry{Path: rel, Err: walkErr})
+				return nil
+			}
+			return walkErr
+		} else if rel == "." {
+			return nil
+		} else if scanSkipped(options.Skip, rel) {
+			report.Skipped += 1
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entry := ScanEntry{Path: rel}
+		if info, ee := d.Info(); ee != nil {
+			entry.Err = ee
+		} else if entry.Size = info.Size(); !info.Mode().IsRegular() {
+			entry.Mime = ModeMime(info.Mode())
+		} else if overridden, ok := r.fromOverrides(path); ok {
+			entry.Mime = r.output(overridden)
+		} else if entry.Mime, _ = r.FromPathChecked(path); entry.Mime == "" || r.IsPlainText(entry.Mime) {
+			// take the slot before starting the goroutine, bounding the
+			// number of goroutines along with the open files
+			slots <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				entry.Mime, entry.Err = r.scanFile(path, entry.Mime, slots, options)
+				add(entry)
+			}()
+			return nil
+		}
+		add(entry)
+		return nil
+	})
+	wg.Wait()
+
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Path < report.Entries[j].Path
+	})
+	return
+}
+
+// scanFile classifies the file at `path` as Mime does, where `byExtension` is
+// the FromPathChecked result, while holding one of the `slots` taken by the
+// caller. The slot is released once the file is closed, even when the
+// Timeout has been reached, so that MaxOpenFiles is never exceeded
+func (r *Registry) scanFile(path, byExtension string, slots chan struct{}, options ScanOptions) (mime string, err error) {
+	type result struct {
+		mime string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() { <-slots }()
+		var res result
+		var fh *os.File
+		var head []byte
+		if fh, res.err = os.Open(path); res.err == nil {
+			head, res.err = io.ReadAll(io.LimitReader(fh, options.MaxBytes))
+			_ = fh.Close()
+		}
+		if res.err == nil {
+			res.mime = r.fromHead(nil, path, byExtension, head, nil)
+		}
+		done <- res
+	}()
+
+	var timeout <-chan time.Time
+	if options.Timeout > 0 {
+		timer := time.NewTimer(options.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case res := <-done:
+		mime, err = res.mime, res.err
+	case <-timeout:
+		err = ErrScanTimeout
+	}
+	return
+}
+
+func scanSkipped(patterns []string, rel string) (skipped bool) {
+	base := filepath.Base(rel)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		} else if ok, _ = filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return
+}
+
+// Scan walks the directory tree rooted at `root` using the default Registry,
+// see Registry.Scan
+func Scan(root string, options ScanOptions) (report ScanReport, err error) {
+	return gRegistry.Scan(root, options)
+}
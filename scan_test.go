@@ -0,0 +1,96 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScan(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	root := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(root, "docs"), 0o755)
+	_ = os.MkdirAll(filepath.Join(root, "node_modules", "pkg"), 0o755)
+	_ = os.WriteFile(filepath.Join(root, "docs", "notes.txt"), []byte("notes"), 0o644)
+	_ = os.WriteFile(filepath.Join(root, "image"), png, 0o644)
+	_ = os.WriteFile(filepath.Join(root, "scratch.tmp"), []byte("scratch"), 0o644)
+	_ = os.WriteFile(filepath.Join(root, "node_modules", "pkg", "index.js"), []byte("x"), 0o644)
+
+	Convey("Scan", t, func() {
+		Convey("everything", func() {
+			report, err := Scan(root, ScanOptions{})
+			So(err, ShouldBeNil)
+			So(report.Entries, ShouldHaveLength, 7)
+			So(report.Errors, ShouldEqual, 0)
+			So(report.Counts[DirectoryMimeType], ShouldEqual, 3)
+			So(report.Counts["text/javascript"], ShouldEqual, 1)
+			So(report.Counts["image/png"], ShouldEqual, 1)
+			So(report.Entries[0].Path, ShouldEqual, "docs")
+			So(report.Entries[1].Path, ShouldEqual, filepath.Join("docs", "notes.txt"))
+			So(report.Entries[1].Mime, ShouldEqual, "text/plain; charset=utf-8")
+			So(report.Entries[2].Path, ShouldEqual, "image")
+			So(report.Entries[2].Size, ShouldEqual, int64(len(png)))
+		})
+
+		Convey("skip patterns", func() {
+			report, err := Scan(root, ScanOptions{Skip: []string{"node_modules", "*.tmp"}})
+			So(err, ShouldBeNil)
+			So(report.Skipped, ShouldEqual, 2)
+			So(report.Entries, ShouldHaveLength, 3)
+			_, err = Scan(root, ScanOptions{Skip: []string{"["}})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("byte limit", func() {
+			report, err := Scan(root, ScanOptions{MaxOpenFiles: 1, MaxBytes: 4, Skip: []string{"docs", "node_modules", "*.tmp"}})
+			So(err, ShouldBeNil)
+			So(report.Entries, ShouldHaveLength, 1)
+			So(report.Entries[0].Path, ShouldEqual, "image")
+			So(report.Entries[0].Mime, ShouldNotEqual, "image/png")
+		})
+
+		Convey("same as Mime", func() {
+			dir := t.TempDir()
+			_ = os.WriteFile(filepath.Join(dir, DefaultOverrideFile), []byte("CNAME text/x-cname\n"), 0o644)
+			_ = os.WriteFile(filepath.Join(dir, "CNAME"), []byte("example.com"), 0o644)
+			_ = os.WriteFile(filepath.Join(dir, "report"), makeTestContainer("[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>"), 0o644)
+			_ = os.WriteFile(filepath.Join(dir, "latin.txt"), []byte("caf\xe9 cr\xe8me"), 0o644)
+			_ = os.WriteFile(filepath.Join(dir, "image"), png, 0o644)
+
+			r := New()
+			r.SetOverrideFile(DefaultOverrideFile)
+			report, err := r.Scan(dir, ScanOptions{MaxOpenFiles: 1})
+			So(err, ShouldBeNil)
+			So(report.Errors, ShouldEqual, 0)
+			So(report.Entries, ShouldHaveLength, 5)
+			for _, entry := range report.Entries {
+				So(entry.Mime, ShouldNotBeEmpty)
+				So(entry.Mime, ShouldEqual, r.Mime(filepath.Join(dir, entry.Path)))
+			}
+			So(report.Counts["text/x-cname"], ShouldEqual, 1)
+			So(report.Counts["application/vnd.openxmlformats-officedocument.wordprocessingml.document"], ShouldEqual, 1)
+			So(report.Counts["image/png"], ShouldEqual, 1)
+		})
+
+		Convey("missing root", func() {
+			_, err := Scan(filepath.Join(root, "nope"), ScanOptions{})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	goMime "mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// DefaultSniffLimit is the SniffOptions.Limit used when none is given, large
+// enough to include multipart headers ahead of the first HeadWindow of file
+// content
+const DefaultSniffLimit = 4 * HeadWindow
+
+// SniffOptions configures the SniffRequest middleware
+type SniffOptions struct {
+	// Limit is the maximum number of body bytes peeked at
+	Limit int
+	// Allowed is the route policy, a list of Match patterns the sniffed type
+	// must satisfy, or the declared type when the sniffed type IsKindOf it,
+	// such as markdown content declared as text/plain. An empty list allows
+	// any type
+	Allowed []string
+	// Strict rejects requests where the declared Content-Type is not
+	// compatible with the sniffed type
+	Strict bool
+}
+
+type cSniffedKey struct{}
+
+// SniffedTypes returns the types detected by the SniffRequest middleware
+// for the given request. Raw uploads have one entry, multipart uploads have
+// one entry per file part found within the peeked bytes
+func SniffedTypes(r *http.Request) (types []string) {
+	types, _ = r.Context().Value(cSniffedKey{}).([]string)
+	return
+}
+
+// SniffRequest returns an http.Handler which peeks at the first bytes of the
+// request body, detects the content type and verifies it against the
+// declared Content-Type and the route policy before calling `next` with a
+// body that replays the peeked bytes. The entire upload is never buffered.
+// For multipart requests, each file part within the peeked bytes is checked
+// against its own declared Content-Type. Requests that fail verification are
+// rejected with a 415 Unsupported Media Type problem response. Detection
+// uses the Registry attached to the request context (see WithContext), or
+// the default Registry
+func SniffRequest(next http.Handler, options SniffOptions) http.Handler {
+	if options.Limit <= 0 {
+		options.Limit = DefaultSniffLimit
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		br := bufio.NewReaderSize(r.Body, options.Limit)
+		peek, _ := br.Peek(options.Limit)
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{Reader: br, Closer: r.Body}
+
+		reg := FromContext(r.Context())
+		declared := r.Header.Get("Content-Type")
+		var checks [][2]string // declared, sniffed
+		if mediatype, params, err := goMime.ParseMediaType(declared); err == nil && strings.HasPrefix(mediatype, "multipart/") {
+			checks = reg.sniffMultipart(peek, params["boundary"])
+		} else {
+			checks = [][2]string{{declared, Normalize(reg.detect(peek))}}
+		}
+
+		var sniffed []string
+		for _, check := range checks {
+			if len(options.Allowed) > 0 && !reg.sniffAllowed(check[0], check[1], options.Allowed) {
+				WriteProblem(w, Problem{
+					Status:    http.StatusUnsupportedMediaType,
+					Detail:    "content detected as unsupported type: " + PruneCharset(check[1]),
+					Supported: options.Allowed,
+				})
+				return
+			} else if options.Strict && reg.mismatched(check[0], check[1]) {
+				WriteProblem(w, Problem{
+					Status: http.StatusUnsupportedMediaType,
+					Detail: "declared " + PruneCharset(check[0]) + " but content detected as " + PruneCharset(check[1]),
+				})
+				return
+			}
+			sniffed = append(sniffed, check[1])
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), cSniffedKey{}, sniffed)))
+	})
+}
+
+// sniffAllowed returns true if the `sniffed` type satisfies the `allowed`
+// route policy, or the `declared` type does and the `sniffed` type is a
+// kind of it, such as markdown content declared as text/plain
+func (r *Registry) sniffAllowed(declared, sniffed string, allowed []string) bool {
+	if MatchAny(sniffed, allowed...) {
+		return true
+	}
+	return declared != "" && MatchAny(declared, allowed...) && r.IsKindOf(sniffed, declared)
+}
+
+// sniffMultipart detects the types of the file parts found within the
+// peeked bytes of a multipart body
+func (r *Registry) sniffMultipart(peek []byte, boundary string) (checks [][2]string) {
+	if boundary == "" {
+		return
+	}
+	mr := multipart.NewReader(bytes.NewReader(peek), boundary)
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		// truncated parts produce an error along with the available data
+		head, _ := readHeadN(part, r.GetReadLimit())
+		checks = append(checks, [2]string{part.Header.Get("Content-Type"), Normalize(r.detect(head))})
+	}
+}
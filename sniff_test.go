@@ -0,0 +1,131 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSniffRequest(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+
+	var received []byte
+	var sniffed []string
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		sniffed = SniffedTypes(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	serve := func(handler http.Handler, contentType string, body []byte) int {
+		received, sniffed = nil, nil
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	Convey("SniffRequest", t, func() {
+		Convey("raw uploads", func() {
+			handler := SniffRequest(echo, SniffOptions{Allowed: []string{"image/*"}, Strict: true})
+			So(serve(handler, "image/png", png), ShouldEqual, http.StatusNoContent)
+			So(received, ShouldResemble, png)
+			So(sniffed, ShouldResemble, []string{"image/png"})
+			So(serve(handler, "image/jpeg", png), ShouldEqual, http.StatusUnsupportedMediaType)
+			So(serve(handler, "application/pdf", []byte("%PDF-1.4\n")), ShouldEqual, http.StatusUnsupportedMediaType)
+
+			lenient := SniffRequest(echo, SniffOptions{Limit: 4})
+			So(serve(lenient, "image/jpeg", png), ShouldEqual, http.StatusNoContent)
+			So(received, ShouldResemble, png)
+		})
+
+		Convey("plain text uploads", func() {
+			text := []byte("Meeting notes\n\nBring the slides.\n")
+			page := []byte("# Notes\n\nBring the slides.\n")
+
+			strict := SniffRequest(echo, SniffOptions{Strict: true})
+			So(serve(strict, "text/plain", text), ShouldEqual, http.StatusNoContent)
+			So(sniffed, ShouldResemble, []string{"text/plain; charset=utf-8"})
+			So(serve(strict, "text/plain; charset=utf-8", page), ShouldEqual, http.StatusNoContent)
+			So(received, ShouldResemble, page)
+			So(serve(strict, "text/plain", png), ShouldEqual, http.StatusUnsupportedMediaType)
+
+			allowed := SniffRequest(echo, SniffOptions{Allowed: []string{"text/plain"}})
+			So(serve(allowed, "text/plain", text), ShouldEqual, http.StatusNoContent)
+			So(received, ShouldResemble, text)
+			So(serve(allowed, "text/plain", page), ShouldEqual, http.StatusNoContent)
+			So(serve(allowed, "application/octet-stream", page), ShouldEqual, http.StatusUnsupportedMediaType)
+			So(serve(allowed, "text/plain", png), ShouldEqual, http.StatusUnsupportedMediaType)
+		})
+
+		Convey("multipart uploads", func() {
+			var buf bytes.Buffer
+			mw := multipart.NewWriter(&buf)
+			_ = mw.WriteField("name", "value")
+			h := textproto.MIMEHeader{}
+			h.Set("Content-Disposition", `form-data; name="file"; filename="image.png"`)
+			h.Set("Content-Type", "image/png")
+			pw, _ := mw.CreatePart(h)
+			_, _ = pw.Write(png)
+			_ = mw.Close()
+			body := buf.Bytes()
+
+			handler := SniffRequest(echo, SniffOptions{Allowed: []string{"image/png"}, Strict: true})
+			So(serve(handler, mw.FormDataContentType(), body), ShouldEqual, http.StatusNoContent)
+			So(received, ShouldResemble, body)
+			So(sniffed, ShouldResemble, []string{"image/png"})
+
+			handler = SniffRequest(echo, SniffOptions{Allowed: []string{"application/pdf"}})
+			So(serve(handler, mw.FormDataContentType(), body), ShouldEqual, http.StatusUnsupportedMediaType)
+		})
+
+		Convey("context registry", func() {
+			r := New()
+			So(r.RegisterTextType("text/x-ticket", "ticket", func(raw []byte, limit uint32) bool {
+				return bytes.HasPrefix(raw, []byte("TICKET:"))
+			}), ShouldBeNil)
+			handler := SniffRequest(echo, SniffOptions{Allowed: []string{"text/x-ticket"}, Strict: true})
+			body := []byte("TICKET: 42\n")
+
+			So(serve(handler, "text/x-ticket", body), ShouldEqual, http.StatusUnsupportedMediaType)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "text/x-ticket")
+			req = req.WithContext(WithContext(req.Context(), r))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+			So(sniffed, ShouldResemble, []string{"text/x-ticket; charset=utf-8"})
+		})
+
+		Convey("no body", func() {
+			handler := SniffRequest(echo, SniffOptions{Allowed: []string{"image/*"}})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			So(rec.Code, ShouldEqual, http.StatusNoContent)
+		})
+	})
+}
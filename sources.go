@@ -0,0 +1,137 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"fmt"
+	goMime "mime"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// gOsMimeFiles are the system files loaded by the standard library mime
+// package on unix systems
+var gOsMimeFiles = []string{
+	"/usr/local/share/mime/globs2",
+	"/usr/share/mime/globs2",
+	"/etc/mime.types",
+	"/etc/apache2/mime.types",
+	"/etc/apache/mime.types",
+	"/etc/httpd/conf/mime.types",
+}
+
+// SourceStatus reports whether a single lookup source is active and
+// functional in the current environment
+type SourceStatus struct {
+	// Name is the name of the lookup source
+	Name string
+	// Active is true when the source is contributing to lookups
+	Active bool
+	// Detail is a brief human-readable explanation of the status
+	Detail string
+}
+
+// String returns the SourceStatus formatted for logging
+func (s SourceStatus) String() string {
+	state := "inactive"
+	if s.Active {
+		state = "active"
+	}
+	return fmt.Sprintf("%s: %s (%s)", s.Name, state, s.Detail)
+}
+
+// Sources reports which lookup sources are active and functional in the
+// current environment, in the order they are consulted. This is useful to
+// log at startup, explaining why results differ between environments such
+// as development machines and scratch containers without OS mime tables
+func (r *Registry) Sources() (sources []SourceStatus) {
+	overrides := SourceStatus{Name: "overrides", Detail: "disabled"}
+	if name := r.GetOverrideFile(); name != "" {
+		overrides.Active, overrides.Detail = true, "override file: "+name
+	}
+	sources = append(sources, overrides)
+
+	extensions := len(r.snapshot(ExtensionTable))
+	sources = append(sources, SourceStatus{
+		Name:   "internal",
+		Active: extensions > 0,
+		Detail: fmt.Sprintf("%d extensions", extensions),
+	})
+
+	sources = append(sources, SourceStatus{
+		Name:   "stdlib",
+		Active: goMime.TypeByExtension(".html") != "",
+		Detail: "built-in mime package table",
+	})
+
+	if r.GetPathOnly() {
+		sources = append(sources, SourceStatus{Name: "os", Detail: "not inspected in path-only mode"})
+	} else {
+		sources = append(sources, osSourceStatus())
+	}
+
+	plugins := Plugins()
+	sources = append(sources, SourceStatus{
+		Name:   "plugins",
+		Active: len(plugins) > 0,
+		Detail: fmt.Sprintf("%d plugins", len(plugins)),
+	})
+
+	png := []byte("\x89PNG\r\n\x1a\n")
+	sources = append(sources, SourceStatus{
+		Name:   "content",
+		Active: mimetype.Detect(png).Is("image/png"),
+		Detail: "github.com/gabriel-vasile/mimetype",
+	})
+
+	resolver := SourceStatus{Name: "resolver", Detail: "no resolver set"}
+	if r.GetResolver() != nil {
+		resolver.Active, resolver.Detail = true, "resolver set"
+	}
+	sources = append(sources, resolver)
+	return
+}
+
+// osSourceStatus reports the presence of the OS mime tables loaded by the
+// standard library mime package
+func osSourceStatus() (status SourceStatus) {
+	status.Name = "os"
+	if runtime.GOOS == "windows" {
+		status.Active = true
+		status.Detail = "windows registry"
+		return
+	}
+	var found []string
+	for _, file := range gOsMimeFiles {
+		if info, err := os.Stat(file); err == nil && info.Mode().IsRegular() {
+			found = append(found, file)
+		}
+	}
+	if status.Active = len(found) > 0; status.Active {
+		status.Detail = strings.Join(found, ", ")
+	} else {
+		status.Detail = "no mime.types files found"
+	}
+	return
+}
+
+// Sources reports the lookup sources of the default Registry, see
+// Registry.Sources
+func Sources() (sources []SourceStatus) {
+	return gRegistry.Sources()
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSources(t *testing.T) {
+	Convey("Sources", t, func() {
+		r := New()
+		sources := r.Sources()
+		var names []string
+		for _, s := range sources {
+			names = append(names, s.Name)
+		}
+		So(names, ShouldResemble, []string{"overrides", "internal", "stdlib", "os", "plugins", "content", "resolver"})
+		So(sources[0].Active, ShouldBeFalse)
+		So(sources[0].String(), ShouldEqual, "overrides: inactive (disabled)")
+		So(sources[1].Active, ShouldBeTrue)
+		So(sources[2].Active, ShouldBeTrue)
+		So(sources[5].Active, ShouldBeTrue)
+		So(sources[6].Active, ShouldBeFalse)
+
+		r.SetOverrideFile(DefaultOverrideFile)
+		r.SetResolver(func(path string, peek []byte) (string, bool) { return "", false })
+		sources = r.Sources()
+		So(sources[0].String(), ShouldEqual, "overrides: active (override file: .mimetypes)")
+		So(sources[6].Active, ShouldBeTrue)
+
+		So(Sources(), ShouldHaveLength, 7)
+	})
+}
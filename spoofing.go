@@ -0,0 +1,103 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+)
+
+// PathFlag is a bitmask of the security concerns found by CheckPath
+type PathFlag uint8
+
+const (
+	// FlagBidiControl indicates the filename contains bidirectional control
+	// characters, such as the RIGHT-TO-LEFT OVERRIDE, which can make the
+	// displayed extension differ from the actual extension
+	FlagBidiControl PathFlag = 1 << iota
+	// FlagConfusableDot indicates the filename contains characters that look
+	// like a full stop but are not, which can fake an extension
+	FlagConfusableDot
+	// FlagInvisible indicates the filename contains zero-width or otherwise
+	// invisible characters
+	FlagInvisible
+)
+
+// String returns a comma separated list of the flags set
+func (f PathFlag) String() string {
+	var names []string
+	if f&FlagBidiControl != 0 {
+		names = append(names, "bidi-control")
+	}
+	if f&FlagConfusableDot != 0 {
+		names = append(names, "confusable-dot")
+	}
+	if f&FlagInvisible != 0 {
+		names = append(names, "invisible")
+	}
+	return strings.Join(names, ",")
+}
+
+// Suspicious returns true if any flags are set
+func (f PathFlag) Suspicious() bool {
+	return f != 0
+}
+
+var gPathFlagRunes = map[rune]PathFlag{
+	// bidirectional controls
+	'\u061c': FlagBidiControl, '\u200e': FlagBidiControl, '\u200f': FlagBidiControl,
+	'\u202a': FlagBidiControl, '\u202b': FlagBidiControl, '\u202c': FlagBidiControl,
+	'\u202d': FlagBidiControl, '\u202e': FlagBidiControl, '\u2066': FlagBidiControl,
+	'\u2067': FlagBidiControl, '\u2068': FlagBidiControl, '\u2069': FlagBidiControl,
+	// full stop lookalikes
+	'\u06d4': FlagConfusableDot, '\u0701': FlagConfusableDot, '\u0702': FlagConfusableDot,
+	'\u2024': FlagConfusableDot, '\u2e3c': FlagConfusableDot, '\u3002': FlagConfusableDot,
+	'\ua4f8': FlagConfusableDot, '\ua60e': FlagConfusableDot, '\ufe52': FlagConfusableDot,
+	'\uff0e': FlagConfusableDot, '\uff61': FlagConfusableDot,
+	// invisible characters
+	'\u00ad': FlagInvisible, '\u180e': FlagInvisible, '\u200b': FlagInvisible,
+	'\u200c': FlagInvisible, '\u200d': FlagInvisible, '\u2060': FlagInvisible,
+	'\ufeff': FlagInvisible,
+}
+
+// CheckPath inspects the base name of the given `path` for characters that
+// can be used to disguise the real extension of a file, for example
+// "evil\u202Egnp.exe" is displayed as "evilexe.png"
+func CheckPath(path string) (flags PathFlag) {
+	if idx := strings.LastIndexAny(path, `/\`); idx >= 0 {
+		path = path[idx+1:]
+	}
+	for _, r := range path {
+		flags |= gPathFlagRunes[r]
+	}
+	return
+}
+
+// FromPathChecked is a security conscious version of FromPathOnly which
+// returns the CheckPath flags of the given `path`. When any flags are set,
+// the returned `mime` is empty so that callers can reject the path or fall
+// back to content based classification
+func (r *Registry) FromPathChecked(path string) (mime string, flags PathFlag) {
+	if flags = CheckPath(path); flags.Suspicious() {
+		return
+	}
+	mime = r.FromPathOnly(path)
+	return
+}
+
+// FromPathChecked is a security conscious version of FromPathOnly, see
+// Registry.FromPathChecked for details
+func FromPathChecked(path string) (mime string, flags PathFlag) {
+	return gRegistry.FromPathChecked(path)
+}
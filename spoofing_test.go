@@ -0,0 +1,55 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckPath(t *testing.T) {
+	Convey("CheckPath", t, func() {
+		So(CheckPath("file.png"), ShouldEqual, PathFlag(0))
+		So(CheckPath("dir\u202e/file.png"), ShouldEqual, PathFlag(0))
+		So(CheckPath("evil\u202egnp.exe"), ShouldEqual, FlagBidiControl)
+		So(CheckPath("C:\\uploads\\photo\u2024png"), ShouldEqual, FlagConfusableDot)
+		So(CheckPath("doc\u200b.pdf"), ShouldEqual, FlagInvisible)
+		flags := CheckPath("a\u202e\uff0e\ufeff")
+		So(flags, ShouldEqual, FlagBidiControl|FlagConfusableDot|FlagInvisible)
+		So(flags.String(), ShouldEqual, "bidi-control,confusable-dot,invisible")
+		So(flags.Suspicious(), ShouldBeTrue)
+	})
+
+	Convey("FromPathChecked", t, func() {
+		mime, flags := FromPathChecked("file.txt")
+		So(flags.Suspicious(), ShouldBeFalse)
+		So(mime, ShouldEqual, "text/plain; charset=utf-8")
+		mime, flags = FromPathChecked("evil\u202etxt.exe")
+		So(flags, ShouldEqual, FlagBidiControl)
+		So(mime, ShouldBeEmpty)
+	})
+
+	Convey("Mime with a suspicious name", t, func() {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "image\u202etxt.md")
+		data, err := os.ReadFile("./testdata/empty-png")
+		So(err, ShouldBeNil)
+		So(os.WriteFile(path, data, 0644), ShouldBeNil)
+		So(Mime(path), ShouldEqual, "image/png")
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	clPath "github.com/go-corelibs/path"
+)
+
+// Strategy selects how MimeWith weighs the file name against the content
+type Strategy uint8
+
+const (
+	// StrategyExtensionFirst trusts the file name whenever it identifies a
+	// type and only reads the content otherwise, the same as Mime
+	StrategyExtensionFirst Strategy = iota
+	// StrategyContentFirst trusts the content whenever it identifies a
+	// specific type and only uses the file name to refine generic text or
+	// binary content, or when the content cannot be read
+	StrategyContentFirst
+	// StrategyContentVerified uses the file name type only when the content
+	// agrees with it, otherwise the content type is used. Unlike
+	// StrategyContentFirst, unreadable content is never classified by name
+	StrategyContentVerified
+)
+
+// String returns the name of the Strategy
+func (s Strategy) String() string {
+	switch s {
+	case StrategyExtensionFirst:
+		return "extension-first"
+	case StrategyContentFirst:
+		return "content-first"
+	case StrategyContentVerified:
+		return "content-verified"
+	}
+	return "unknown"
+}
+
+// MimeOption configures the behaviour of MimeWith
+type MimeOption func(c *mimeConfig)
+
+type mimeConfig struct {
+	strategy Strategy
+}
+
+// WithStrategy configures the Strategy used by MimeWith
+func WithStrategy(strategy Strategy) MimeOption {
+	return func(c *mimeConfig) {
+		c.strategy = strategy
+	}
+}
+
+// ExtensionFirst is shorthand for WithStrategy(StrategyExtensionFirst)
+func ExtensionFirst() MimeOption {
+	return WithStrategy(StrategyExtensionFirst)
+}
+
+// ContentFirst is shorthand for WithStrategy(StrategyContentFirst)
+func ContentFirst() MimeOption {
+	return WithStrategy(StrategyContentFirst)
+}
+
+// ContentVerified is shorthand for WithStrategy(StrategyContentVerified)
+func ContentVerified() MimeOption {
+	return WithStrategy(StrategyContentVerified)
+}
+
+func newMimeConfig(strategy Strategy, options []MimeOption) (c *mimeConfig) {
+	c = &mimeConfig{strategy: strategy}
+	for _, option := range options {
+		if option != nil {
+			option(c)
+		}
+	}
+	return
+}
+
+// MimeWith is Mime with configurable options, such as the Strategy used to
+// weigh the file name against the content. Uploaded files and other
+// untrusted names should use ContentFirst or ContentVerified so that a
+// misleading extension cannot dictate the type. With either content
+// strategy, generic text or binary content only takes the file name type
+// when the name agrees on whether the file is text, otherwise the content
+// is classified the same way as Mime classifies extension-less files. The
+// Strategy defaults to the Options.Strategy of the Registry
+func (r *Registry) MimeWith(path string, options ...MimeOption) (mime string) {
+	c := newMimeConfig(r.options.Load().Strategy, options)
+	if r.GetPathOnly() {
+		return r.PathMime(path)
+	} else if _, inode := r.inodeMime(path); inode || c.strategy == StrategyExtensionFirst || clPath.IsDir(path) || !clPath.IsRegularFile(path) {
+		return r.Mime(path)
+	} else if overridden, ok := r.fromOverrides(path); ok {
+		return r.output(overridden)
+	}
+
+	byExtension, _ := r.FromPathChecked(path)
+	head, err := r.readFileHead(path)
+	if err != nil {
+		if c.strategy == StrategyContentFirst && byExtension != "" {
+			return byExtension
+		}
+		return r.fromContent(nil, path, head, err)
+	}
+
+	if byExtension != "" {
+		byExtension = r.extensionMime(byExtension, head)
+	}
+	detected, source := r.detectSource(head)
+	if source != SourceContent {
+		if byExtension != "" && c.strategy == StrategyContentVerified && !r.typeMismatch(byExtension, detected) {
+			return byExtension
+		}
+		return r.output(r.withDetectedCharset(detected, head))
+	} else if byExtension != "" && r.IsPlainText(byExtension) == r.IsPlainText(detected) {
+		return byExtension
+	}
+	return r.fromContent(nil, path, head, nil)
+}
+
+// MimeWith is Mime with configurable options using the default Registry,
+// see Registry.MimeWith
+func MimeWith(path string, options ...MimeOption) (mime string) {
+	return gRegistry.MimeWith(path, options...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMimeWith(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	zip := append([]byte("PK\x05\x06"), make([]byte, 18)...)
+	dir := t.TempDir()
+	write := func(name string, data []byte) (path string) {
+		path = filepath.Join(dir, name)
+		So(os.WriteFile(path, data, 0644), ShouldBeNil)
+		return
+	}
+
+	Convey("Strategy.String", t, func() {
+		So(StrategyExtensionFirst.String(), ShouldEqual, "extension-first")
+		So(StrategyContentFirst.String(), ShouldEqual, "content-first")
+		So(StrategyContentVerified.String(), ShouldEqual, "content-verified")
+		So(Strategy(99).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("MimeWith", t, func() {
+		r := New()
+
+		spoofed := write("report.pdf", png)
+		So(r.MimeWith(spoofed), ShouldEqual, "application/pdf")
+		So(r.MimeWith(spoofed, ExtensionFirst()), ShouldEqual, "application/pdf")
+		So(r.MimeWith(spoofed, ContentFirst()), ShouldEqual, "image/png")
+		So(r.MimeWith(spoofed, ContentVerified()), ShouldEqual, "image/png")
+
+		honest := write("logo.png", png)
+		So(r.MimeWith(honest, ContentFirst()), ShouldEqual, "image/png")
+		So(r.MimeWith(honest, ContentVerified()), ShouldEqual, "image/png")
+
+		archive := write("letter.docx", zip)
+		So(r.MimeWith(archive, ContentFirst()), ShouldEqual, "application/zip")
+		So(r.MimeWith(archive, ContentVerified()), ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+
+		binary := write("page.html", []byte{0x00, 0x01, 0x02, 0x03})
+		So(r.MimeWith(binary), ShouldEqual, "text/html; charset=utf-8")
+		So(r.MimeWith(binary, ContentFirst()), ShouldEqual, BinaryMimeType)
+		So(r.MimeWith(binary, ContentVerified()), ShouldEqual, BinaryMimeType)
+
+		blob := write("data.bin", []byte{0x00, 0x01, 0x02, 0x03})
+		So(r.MimeWith(blob, ContentVerified()), ShouldEqual, BinaryMimeType)
+
+		So(r.MimeWith(dir, ContentFirst()), ShouldEqual, DirectoryMimeType)
+		So(r.MimeWith(filepath.Join(dir, "missing.png"), ContentFirst()), ShouldEqual, "")
+	})
+}
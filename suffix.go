@@ -0,0 +1,82 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+// gTextualSuffixes are the RFC 6839 (and later) structured syntax suffixes
+// which imply textual content
+var gTextualSuffixes = map[string]struct{}{
+	"json":     {},
+	"json-seq": {},
+	"xml":      {},
+	"yaml":     {},
+}
+
+// gTextualTypes are the types outside of the "text" top-level type which
+// are textual content, used by IsTextual
+var gTextualTypes = map[string]struct{}{
+	JsonMimeType:               {},
+	NdjsonMimeType:             {},
+	"application/javascript":   {},
+	"application/x-javascript": {},
+	"application/ecmascript":   {},
+	"application/xml":          {},
+	"application/yaml":         {},
+	"application/x-yaml":       {},
+	"application/toml":         {},
+	"application/sql":          {},
+	"application/x-sh":         {},
+}
+
+// Suffix returns the RFC 6839 structured syntax suffix of the given `mime`,
+// without the plus sign, for example "json" for "application/vnd.api+json".
+// Suffix returns an empty string when there is no suffix or the `mime` does
+// not parse
+func Suffix(mime string) (suffix string) {
+	if mt, err := ParseMediaType(mime); err == nil {
+		suffix = mt.Suffix
+	}
+	return
+}
+
+// IsJSON returns true if the given `mime` is JSON-shaped: JsonMimeType,
+// "text/json" or any type with the +json structured syntax suffix
+func IsJSON(mime string) (yes bool) {
+	if mt, err := ParseMediaType(mime); err == nil {
+		switch essence := mt.Essence(); {
+		case essence == JsonMimeType, essence == "text/json", mt.Suffix == "json":
+			yes = true
+		}
+	}
+	return
+}
+
+// IsXML returns true if the given `mime` is XML-shaped: "application/xml",
+// "text/xml" or any type with the +xml structured syntax suffix
+func IsXML(mime string) (yes bool) {
+	if mt, err := ParseMediaType(mime); err == nil {
+		switch essence := mt.Essence(); {
+		case essence == "application/xml", essence == "text/xml", mt.Suffix == "xml":
+			yes = true
+		}
+	}
+	return
+}
+
+// hasTextualSuffix returns true if the `mime` has a structured syntax suffix
+// which implies textual content, such as +json or +xml
+func hasTextualSuffix(mime string) (yes bool) {
+	_, yes = gTextualSuffixes[Suffix(mime)]
+	return
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSuffix(t *testing.T) {
+	Convey("Suffix", t, func() {
+		So(Suffix("application/vnd.api+json"), ShouldEqual, "json")
+		So(Suffix("Image/SVG+XML; charset=utf-8"), ShouldEqual, "xml")
+		So(Suffix("application/json"), ShouldEqual, "")
+		So(Suffix("not a type"), ShouldEqual, "")
+	})
+
+	Convey("IsJSON", t, func() {
+		So(IsJSON("application/json"), ShouldBeTrue)
+		So(IsJSON("text/json; charset=utf-8"), ShouldBeTrue)
+		So(IsJSON("application/vnd.api+json"), ShouldBeTrue)
+		So(IsJSON("application/ld+json; profile=x"), ShouldBeTrue)
+		So(IsJSON("application/json-seq"), ShouldBeFalse)
+		So(IsJSON("application/xml"), ShouldBeFalse)
+		So(IsJSON(""), ShouldBeFalse)
+	})
+
+	Convey("IsXML", t, func() {
+		So(IsXML("application/xml"), ShouldBeTrue)
+		So(IsXML("text/xml"), ShouldBeTrue)
+		So(IsXML("image/svg+xml"), ShouldBeTrue)
+		So(IsXML("application/atom+xml"), ShouldBeTrue)
+		So(IsXML("application/json"), ShouldBeFalse)
+	})
+
+	Convey("IsPlainText with suffixes", t, func() {
+		r := New()
+		So(r.IsPlainText("application/vnd.api+json"), ShouldBeTrue)
+		So(r.IsPlainText("application/vnd.custom+yaml"), ShouldBeTrue)
+		So(r.IsPlainText("application/vnd.custom+xml"), ShouldBeTrue)
+		So(r.IsPlainText("application/vnd.custom+zip"), ShouldBeFalse)
+		So(r.IsPlainText("application/vnd.custom+cbor"), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// TextAnalysis is the metadata returned by AnalyzeText
+type TextAnalysis struct {
+	// Charset is the detected character set of the content
+	Charset string
+	// Script is the name of the dominant Unicode script of the letters
+	// within the content, as named by the unicode package (Latin, Cyrillic,
+	// Han and so on)
+	Script string
+	// Language is a coarse ISO 639-1 language hint, only present when the
+	// Script is strongly associated with a single language
+	Language string
+}
+
+var gScripts = []string{
+	"Latin", "Cyrillic", "Greek", "Arabic", "Hebrew", "Han", "Hiragana",
+	"Katakana", "Hangul", "Thai", "Devanagari", "Armenian", "Georgian",
+}
+
+var gScriptLanguages = map[string]string{
+	"Greek":    "el",
+	"Hebrew":   "he",
+	"Hangul":   "ko",
+	"Hiragana": "ja",
+	"Katakana": "ja",
+	"Thai":     "th",
+	"Armenian": "hy",
+	"Georgian": "ka",
+}
+
+// AnalyzeText inspects the given text `data` and reports the charset along
+// with the dominant writing system and, where the script implies one, a
+// coarse language hint. Script detection is based on letter counts only and
+// is not a substitute for actual language identification
+func AnalyzeText(data []byte) (analysis TextAnalysis) {
+	var runes []rune
+	analysis.Charset, runes = decodeText(data)
+
+	counts := make(map[string]int)
+	for _, r := range runes {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, name := range gScripts {
+			if unicode.Is(unicode.Scripts[name], r) {
+				counts[name] += 1
+				break
+			}
+		}
+	}
+
+	var best int
+	for _, name := range gScripts {
+		if counts[name] > best {
+			analysis.Script, best = name, counts[name]
+		}
+	}
+
+	switch {
+	case counts["Hiragana"]+counts["Katakana"] > 0 && (analysis.Script == "Han" || analysis.Script == "Hiragana" || analysis.Script == "Katakana"):
+		// kana alongside kanji is a strong indicator of japanese
+		analysis.Language = "ja"
+	case analysis.Script == "Han":
+		analysis.Language = "zh"
+	default:
+		analysis.Language = gScriptLanguages[analysis.Script]
+	}
+	return
+}
+
+// decodeText determines the charset of the given `data` using byte order
+// marks and UTF-8 validity, returning the decoded runes when possible
+func decodeText(data []byte) (charset string, runes []rune) {
+	encoding, size := DetectBOM(data)
+	switch encoding {
+	case EncodingUTF8:
+		return "utf-8", []rune(string(data[size:]))
+	case EncodingUTF16BE, EncodingUTF16LE:
+		return encoding.Charset(), decodeUTF16(data[size:], encoding == EncodingUTF16BE)
+	case EncodingUTF32BE, EncodingUTF32LE:
+		return encoding.Charset(), decodeUTF32(data[size:], encoding == EncodingUTF32BE)
+	}
+	if utf8.Valid(data) {
+		return "utf-8", []rune(string(data))
+	}
+	// every byte is a valid latin-1 code point
+	runes = make([]rune, len(data))
+	for idx, b := range data {
+		runes[idx] = rune(b)
+	}
+	return "iso-8859-1", runes
+}
+
+func decodeUTF16(data []byte, bigEndian bool) (runes []rune) {
+	units := make([]uint16, len(data)/2)
+	for idx := range units {
+		if bigEndian {
+			units[idx] = uint16(data[idx*2])<<8 | uint16(data[idx*2+1])
+		} else {
+			units[idx] = uint16(data[idx*2+1])<<8 | uint16(data[idx*2])
+		}
+	}
+	return utf16.Decode(units)
+}
+
+// decodeUTF32 decodes UTF-32 `data`, invalid code points are replaced with
+// utf8.RuneError
+func decodeUTF32(data []byte, bigEndian bool) (runes []rune) {
+	runes = make([]rune, len(data)/4)
+	for idx := range runes {
+		unit := data[idx*4 : idx*4+4]
+		if bigEndian {
+			runes[idx] = rune(uint32(unit[0])<<24 | uint32(unit[1])<<16 | uint32(unit[2])<<8 | uint32(unit[3]))
+		} else {
+			runes[idx] = rune(uint32(unit[3])<<24 | uint32(unit[2])<<16 | uint32(unit[1])<<8 | uint32(unit[0]))
+		}
+		if !utf8.ValidRune(runes[idx]) {
+			runes[idx] = utf8.RuneError
+		}
+	}
+	return
+}
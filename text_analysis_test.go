@@ -0,0 +1,36 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAnalyzeText(t *testing.T) {
+	Convey("AnalyzeText", t, func() {
+		So(AnalyzeText([]byte("plain english text")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Latin"})
+		So(AnalyzeText([]byte("Привет, мир")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Cyrillic"})
+		So(AnalyzeText([]byte("Γειά σου κόσμε")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Greek", Language: "el"})
+		So(AnalyzeText([]byte("こんにちは世界")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Hiragana", Language: "ja"})
+		So(AnalyzeText([]byte("你好世界")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Han", Language: "zh"})
+		So(AnalyzeText([]byte("\xef\xbb\xbf안녕하세요")), ShouldResemble, TextAnalysis{Charset: "utf-8", Script: "Hangul", Language: "ko"})
+		So(AnalyzeText([]byte{0xff, 0xfe, 'h', 0, 'i', 0}), ShouldResemble, TextAnalysis{Charset: "utf-16le", Script: "Latin"})
+		So(AnalyzeText([]byte{0xfe, 0xff, 0, 'h', 0, 'i'}), ShouldResemble, TextAnalysis{Charset: "utf-16be", Script: "Latin"})
+		So(AnalyzeText([]byte("caf\xe9")), ShouldResemble, TextAnalysis{Charset: "iso-8859-1", Script: "Latin"})
+		So(AnalyzeText([]byte("1234")), ShouldResemble, TextAnalysis{Charset: "utf-8"})
+	})
+}
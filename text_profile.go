@@ -0,0 +1,129 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"unicode/utf8"
+)
+
+// LineEnding describes the line terminators used within text content
+type LineEnding string
+
+const (
+	LineEndingNone  LineEnding = ""
+	LineEndingLF    LineEnding = "lf"
+	LineEndingCRLF  LineEnding = "crlf"
+	LineEndingCR    LineEnding = "cr"
+	LineEndingMixed LineEnding = "mixed"
+)
+
+// Indentation describes the leading whitespace used within text content
+type Indentation string
+
+const (
+	IndentationNone   Indentation = ""
+	IndentationTabs   Indentation = "tabs"
+	IndentationSpaces Indentation = "spaces"
+	IndentationMixed  Indentation = "mixed"
+)
+
+// TextLayout is the result of TextProfile
+type TextLayout struct {
+	// Lines is the number of lines, including a final unterminated line
+	Lines int
+	// LineEnding is the style of line terminators found
+	LineEnding LineEnding
+	// Indentation is the style of leading whitespace found
+	Indentation Indentation
+	// TabIndented is the number of lines starting with a tab
+	TabIndented int
+	// SpaceIndented is the number of lines starting with a space
+	SpaceIndented int
+	// LongestLine is the length, in runes, of the longest line
+	LongestLine int
+	// TrailingWhitespace is the number of lines ending with spaces or tabs
+	TrailingWhitespace int
+}
+
+// TextProfile reports the line ending style, indentation style, longest line
+// and trailing whitespace of the given text `data`, which formatters and
+// linters can use without reading the content a second time
+func TextProfile(data []byte) (layout TextLayout) {
+	var lf, crlf, cr int
+	start := 0
+	endLine := func(end int) {
+		line := data[start:end]
+		layout.Lines += 1
+		if n := utf8.RuneCount(line); n > layout.LongestLine {
+			layout.LongestLine = n
+		}
+		if len(line) > 0 {
+			switch line[0] {
+			case '\t':
+				layout.TabIndented += 1
+			case ' ':
+				layout.SpaceIndented += 1
+			}
+			switch line[len(line)-1] {
+			case ' ', '\t':
+				layout.TrailingWhitespace += 1
+			}
+		}
+	}
+
+	for idx := 0; idx < len(data); idx++ {
+		switch data[idx] {
+		case '\n':
+			lf += 1
+			endLine(idx)
+			start = idx + 1
+		case '\r':
+			endLine(idx)
+			if idx+1 < len(data) && data[idx+1] == '\n' {
+				crlf += 1
+				idx += 1
+			} else {
+				cr += 1
+			}
+			start = idx + 1
+		}
+	}
+	if start < len(data) {
+		endLine(len(data))
+	}
+
+	switch {
+	case lf+crlf+cr == 0:
+		layout.LineEnding = LineEndingNone
+	case lf == 0 && cr == 0:
+		layout.LineEnding = LineEndingCRLF
+	case crlf == 0 && cr == 0:
+		layout.LineEnding = LineEndingLF
+	case lf == 0 && crlf == 0:
+		layout.LineEnding = LineEndingCR
+	default:
+		layout.LineEnding = LineEndingMixed
+	}
+
+	switch {
+	case layout.TabIndented > 0 && layout.SpaceIndented > 0:
+		layout.Indentation = IndentationMixed
+	case layout.TabIndented > 0:
+		layout.Indentation = IndentationTabs
+	case layout.SpaceIndented > 0:
+		layout.Indentation = IndentationSpaces
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTextProfile(t *testing.T) {
+	Convey("TextProfile", t, func() {
+		So(TextProfile(nil), ShouldResemble, TextLayout{})
+		So(TextProfile([]byte("one line")), ShouldResemble, TextLayout{Lines: 1, LongestLine: 8})
+		So(TextProfile([]byte("a\n\tb\n\tcc \n")), ShouldResemble, TextLayout{
+			Lines:              3,
+			LineEnding:         LineEndingLF,
+			Indentation:        IndentationTabs,
+			TabIndented:        2,
+			LongestLine:        4,
+			TrailingWhitespace: 1,
+		})
+		So(TextProfile([]byte("a\r\n  b\r\n")), ShouldResemble, TextLayout{
+			Lines:         2,
+			LineEnding:    LineEndingCRLF,
+			Indentation:   IndentationSpaces,
+			SpaceIndented: 1,
+			LongestLine:   3,
+		})
+		So(TextProfile([]byte("a\rb\r")).LineEnding, ShouldEqual, LineEndingCR)
+		layout := TextProfile([]byte("a\nb\r\n\tc\n d\rcafé"))
+		So(layout.LineEnding, ShouldEqual, LineEndingMixed)
+		So(layout.Indentation, ShouldEqual, IndentationMixed)
+		So(layout.Lines, ShouldEqual, 5)
+		So(layout.LongestLine, ShouldEqual, 4)
+	})
+}
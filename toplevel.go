@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"sort"
+	"strings"
+)
+
+// TopLevelInfo describes the default behaviours associated with an RFC 6838
+// top-level type
+type TopLevelInfo struct {
+	// Name is the top-level type name
+	Name string
+	// Textual is true when content of this top-level type is assumed to be
+	// text unless more specific information is available
+	Textual bool
+}
+
+var gTopLevels = map[string]TopLevelInfo{
+	"application": {Name: "application"},
+	"audio":       {Name: "audio"},
+	"font":        {Name: "font"},
+	"haptics":     {Name: "haptics"},
+	"image":       {Name: "image"},
+	"message":     {Name: "message", Textual: true},
+	"model":       {Name: "model"},
+	"multipart":   {Name: "multipart"},
+	"text":        {Name: "text", Textual: true},
+	"video":       {Name: "video"},
+}
+
+// TopLevel returns the lowercased top-level type of the given `mime`, for
+// example "text" for "text/html; charset=utf-8". TopLevel does not check
+// that the top-level type is valid, see IsValidTopLevel
+func TopLevel(mime string) (name string) {
+	name, _, _ = strings.Cut(Normalize(mime), "/")
+	name, _, _ = strings.Cut(name, ";")
+	name = strings.TrimSpace(name)
+	return
+}
+
+// IsValidTopLevel returns true if the given `name` is one of the RFC 6838
+// registered top-level types: application, audio, font, haptics, image,
+// message, model, multipart, text or video
+func IsValidTopLevel(name string) bool {
+	_, ok := gTopLevels[strings.ToLower(name)]
+	return ok
+}
+
+// GetTopLevelInfo returns the TopLevelInfo for the top-level type of the
+// given `mime`
+func GetTopLevelInfo(mime string) (info TopLevelInfo, ok bool) {
+	info, ok = gTopLevels[TopLevel(mime)]
+	return
+}
+
+// TopLevels returns the list of RFC 6838 top-level type names, sorted
+func TopLevels() (names []string) {
+	for name := range gTopLevels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
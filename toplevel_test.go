@@ -0,0 +1,54 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTopLevel(t *testing.T) {
+	Convey("TopLevel", t, func() {
+		So(TopLevel("Text/HTML; charset=utf-8"), ShouldEqual, "text")
+		So(TopLevel("image/png"), ShouldEqual, "image")
+		So(TopLevel("inode/directory"), ShouldEqual, "inode")
+		So(TopLevel(""), ShouldBeEmpty)
+	})
+
+	Convey("IsValidTopLevel", t, func() {
+		for _, name := range TopLevels() {
+			So(IsValidTopLevel(name), ShouldBeTrue)
+		}
+		So(TopLevels(), ShouldHaveLength, 10)
+		So(IsValidTopLevel("Haptics"), ShouldBeTrue)
+		So(IsValidTopLevel("inode"), ShouldBeFalse)
+		So(IsValidTopLevel(""), ShouldBeFalse)
+	})
+
+	Convey("GetTopLevelInfo", t, func() {
+		info, ok := GetTopLevelInfo("text/org-mode")
+		So(ok, ShouldBeTrue)
+		So(info.Textual, ShouldBeTrue)
+		info, ok = GetTopLevelInfo("message/rfc822")
+		So(ok, ShouldBeTrue)
+		So(info.Textual, ShouldBeTrue)
+		info, ok = GetTopLevelInfo("font/woff2")
+		So(ok, ShouldBeTrue)
+		So(info.Textual, ShouldBeFalse)
+		_, ok = GetTopLevelInfo("inode/directory")
+		So(ok, ShouldBeFalse)
+	})
+}
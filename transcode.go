@@ -0,0 +1,91 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"fmt"
+	goMime "mime"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode/utf32"
+	"golang.org/x/text/transform"
+)
+
+// ErrUnknownCharset is returned by ToUTF8 when the charset of the content
+// is not declared and can not be detected, or is not supported
+var ErrUnknownCharset = errors.New("unknown charset")
+
+// ToUTF8 converts the given text `data` to UTF-8, returning the converted
+// data and the `mime` type with its charset parameter rewritten to utf-8.
+// Any byte order mark (see DetectBOM) is removed and takes precedence over
+// the charset parameter of the `mime` type, which in turn is used instead
+// of DetectCharset when present. Charset names are resolved using the WHATWG
+// encoding labels, so "latin1" and "iso-8859-1" decode as windows-1252, as
+// web browsers do. An empty `mime` is accepted and returned as-is.
+// ErrUnknownCharset is returned when the charset is not supported or can
+// not be determined, such as for binary `data`
+func ToUTF8(mime string, data []byte) (converted []byte, updated string, err error) {
+	var mediatype string
+	var params map[string]string
+	if mime != "" {
+		if mediatype, params, err = goMime.ParseMediaType(mime); err != nil {
+			return
+		}
+	}
+
+	charset := params["charset"]
+	if encoding, size := DetectBOM(data); encoding != EncodingNone {
+		// a byte order mark takes precedence over the declared charset
+		charset, data = encoding.Charset(), data[size:]
+	} else if charset == "" {
+		if charset = DetectCharset(data); charset == "" && len(data) > 0 {
+			err = ErrUnknownCharset
+			return
+		}
+	}
+
+	if len(data) == 0 || CharsetEqual(charset, "utf-8") {
+		converted = data
+	} else if enc, ee := charsetEncoding(charset); ee != nil {
+		err = fmt.Errorf("%w: %q", ErrUnknownCharset, charset)
+		return
+	} else if converted, _, err = transform.Bytes(enc.NewDecoder(), data); err != nil {
+		return
+	}
+
+	if mediatype != "" {
+		if params == nil {
+			params = map[string]string{}
+		}
+		params["charset"] = "utf-8"
+		updated = goMime.FormatMediaType(mediatype, params)
+	}
+	return
+}
+
+// charsetEncoding returns the encoding.Encoding of the given `charset`,
+// including the UTF-32 charsets reported by DetectCharset which are not
+// WHATWG encodings
+func charsetEncoding(charset string) (enc encoding.Encoding, err error) {
+	switch {
+	case CharsetEqual(charset, "utf-32le"):
+		return utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM), nil
+	case CharsetEqual(charset, "utf-32be"), CharsetEqual(charset, "utf-32"):
+		return utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM), nil
+	}
+	return htmlindex.Get(charset)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestToUTF8(t *testing.T) {
+	Convey("declared charsets", t, func() {
+		for _, check := range []struct {
+			mime   string
+			input  []byte
+			output string
+		}{
+			{"text/plain; charset=iso-8859-1", []byte("caf\xe9"), "café"},
+			{"text/plain; charset=latin1", []byte("caf\xe9"), "café"},
+			{"text/html; charset=windows-1252", []byte("\x93quoted\x94"), "“quoted”"},
+			{"text/plain; charset=shift_jis", []byte("\x93\xfa\x96\x7b"), "日本"},
+			{"text/plain; charset=UTF-8", []byte("\xef\xbb\xbfhello"), "hello"},
+		} {
+			converted, updated, err := ToUTF8(check.mime, check.input)
+			So(err, ShouldBeNil)
+			So(string(converted), ShouldEqual, check.output)
+			So(updated, ShouldEndWith, "; charset=utf-8")
+		}
+	})
+
+	Convey("detected charsets", t, func() {
+		converted, updated, err := ToUTF8("text/plain", []byte("caf\xe9"))
+		So(err, ShouldBeNil)
+		So(string(converted), ShouldEqual, "café")
+		So(updated, ShouldEqual, "text/plain; charset=utf-8")
+
+		converted, updated, err = ToUTF8("text/markdown", []byte{0xff, 0xfe, 'h', 0, 'i', 0})
+		So(err, ShouldBeNil)
+		So(string(converted), ShouldEqual, "hi")
+		So(updated, ShouldEqual, "text/markdown; charset=utf-8")
+
+		converted, updated, err = ToUTF8("", []byte("plain"))
+		So(err, ShouldBeNil)
+		So(string(converted), ShouldEqual, "plain")
+		So(updated, ShouldBeEmpty)
+	})
+
+	Convey("errors", t, func() {
+		_, _, err := ToUTF8("application/octet-stream", []byte{0x00, 0x00, 0x01, 0x02})
+		So(errors.Is(err, ErrUnknownCharset), ShouldBeTrue)
+		_, _, err = ToUTF8("text/plain; charset=x-not-a-charset", []byte("text"))
+		So(errors.Is(err, ErrUnknownCharset), ShouldBeTrue)
+		_, _, err = ToUTF8("text/", []byte("text"))
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	goMime "mime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// graft is a detector added to the github.com/gabriel-vasile/mimetype
+// hierarchy. Nodes cannot be removed from the hierarchy, so a graft is
+// detached by disabling it, after which it never matches
+type graft struct {
+	mime    string
+	enabled atomic.Bool
+}
+
+type graftList struct {
+	list []*graft
+	sync.Mutex
+}
+
+// gGrafts are all the detectors this package has added to the mimetype
+// hierarchy, in order of registration
+var gGrafts = &graftList{}
+
+// gAddedExtensions are the extension to mime type associations this package
+// has added to the standard library mime package, which cannot be removed
+var gAddedExtensions = newLookup(map[string]string{})
+
+// gMaskedExtensions are the gAddedExtensions of unregistered types, which
+// GetExtension no longer falls back to
+var gMaskedExtensions = newLookup(map[string]string{})
+
+// gPristine is the state of the default Registry and the package detectors
+// at the end of package initialization
+var gPristine struct {
+	tables   map[Table]map[string]string
+	text     []Detector
+	catchAll []Detector
+	binary   []Detector
+	grafts   int
+	added    map[string]string
+	parents  map[string]string
+}
+
+// graftDetector extends the `parent` mimetype node, or the root node when
+// `parent` is nil, with a detector that can later be detached
+func graftDetector(parent *mimetype.MIME, detector func(raw []byte, limit uint32) bool, mime, extension string) {
+	g := &graft{mime: PruneCharset(mime)}
+	g.enabled.Store(true)
+	wrapped := func(raw []byte, limit uint32) bool {
+		return g.enabled.Load() && detector(raw, limit)
+	}
+	gGrafts.Lock()
+	gGrafts.list = append(gGrafts.list, g)
+	gGrafts.Unlock()
+	if parent != nil {
+		parent.Extend(wrapped, mime, extension)
+	} else {
+		mimetype.Extend(wrapped, mime, extension)
+	}
+}
+
+// addExtensionType is mime.AddExtensionType, recording the association so
+// that it can be masked by UnregisterType
+func addExtensionType(extension, mime string) (err error) {
+	if err = goMime.AddExtensionType(extension, mime); err == nil {
+		mediatype := PruneCharset(mime)
+		gAddedExtensions.set(extension, mediatype)
+		gMaskedExtensions.unset(extension)
+	}
+	return
+}
+
+// maskedExtension returns true if the standard library association of the
+// given `extension`, which includes the leading period, was masked
+func maskedExtension(extension, mime string) bool {
+	masked, ok := gMaskedExtensions.get(extension)
+	return ok && masked == PruneCharset(mime)
+}
+
+// snapshotPristine records gPristine, called once at the end of the package
+// initialization
+func snapshotPristine() {
+	gPristine.tables = make(map[Table]map[string]string)
+	for _, t := range []Table{ExtensionTable, CharsetTable, AliasTable, FilenameTable, GlobTable} {
+		gPristine.tables[t] = gRegistry.table(t).snapshot()
+	}
+	gPristine.text = gTextDetectors.snapshot()
+	gPristine.catchAll = gCatchAllDetectors.snapshot()
+	gPristine.binary = gBinaryDetectors.snapshot()
+	gGrafts.Lock()
+	gPristine.grafts = len(gGrafts.list)
+	gGrafts.Unlock()
+	gPristine.added = gAddedExtensions.snapshot()
+	gPristine.parents = gRegistry.parents.snapshot()
+}
+
+// UnregisterType removes every association with the given `mime` type from
+// the Registry: extensions, well-known file names, glob patterns, aliases to
+// and from the type, parents to and from the type, its charset, its
+// preferred extension, its icon name, the XML root elements and container
+// rules identifying it, relationships from and to it, its Uniform Type
+// Identifier mapping and its content detectors. For the default Registry,
+// the detectors given to RegisterTextType and RegisterBinaryType are
+// detached from the github.com/gabriel-vasile/mimetype hierarchy and the
+// extensions these functions added to the standard library mime package are
+// no longer used. UnregisterType returns true if anything was removed
+func (r *Registry) UnregisterType(mime string) (found bool) {
+	mediatype := PruneCharset(mime)
+	if mediatype == "" {
+		return
+	}
+	var removed []string
+	for _, t := range []Table{ExtensionTable, FilenameTable, GlobTable, AliasTable} {
+		r.table(t).update(func(m map[string]string) {
+			for key, value := range m {
+				if PruneCharset(value) == mediatype || (t == AliasTable && key == mediatype) {
+					delete(m, key)
+					if t == ExtensionTable {
+						removed = append(removed, key)
+					}
+					found = true
+				}
+			}
+		})
+	}
+	if _, ok := r.charsets.get(mediatype); ok {
+		r.charsets.unset(mediatype)
+		found = true
+	}
+	for _, l := range []*lookup{r.preferred, r.icons} {
+		if _, ok := l.get(mediatype); ok {
+			l.unset(mediatype)
+			found = true
+		}
+	}
+	r.xmlRoots.update(func(m map[string]string) {
+		for key, value := range m {
+			if value == mediatype {
+				delete(m, key)
+				found = true
+			}
+		}
+	})
+	if r.containers.remove(mediatype) {
+		found = true
+	}
+	if r.relations.remove(mediatype) {
+		found = true
+	}
+	if r.utis.removeMime(mediatype) {
+		found = true
+	}
+	r.parents.update(func(m map[string]string) {
+		for key, value := range m {
+			if key == mediatype || value == mediatype {
+				delete(m, key)
+				found = true
+			}
+		}
+	})
+	if r.detectors.remove(mediatype) {
+		found = true
+	}
+
+	if r == gRegistry {
+		for _, list := range []*textDetectorList{gTextDetectors, gCatchAllDetectors, gBinaryDetectors} {
+			if list.remove(mediatype) {
+				found = true
+			}
+		}
+		gGrafts.Lock()
+		for _, g := range gGrafts.list {
+			if g.mime == mediatype && g.enabled.Load() {
+				g.enabled.Store(false)
+				found = true
+			}
+		}
+		gGrafts.Unlock()
+		for _, extension := range removed {
+			if added, ok := gAddedExtensions.get("." + extension); ok && added == mediatype {
+				gMaskedExtensions.set("."+extension, mediatype)
+			}
+		}
+	}
+	return
+}
+
+// UnregisterType removes every association with the given `mime` type from
+// the default Registry, see Registry.UnregisterType
+func UnregisterType(mime string) (found bool) {
+	return gRegistry.UnregisterType(mime)
+}
+
+// Reset restores the Registry to the state of a New Registry, removing all
+// registered types and detectors and restoring the default settings, except
+// for the logger and the OnRegister and OnUnset hooks, which are notified of
+// the changes. Resetting the default Registry restores the state it had
+// after the package initialization instead: the types this package registers
+// are kept and all other detectors are detached from the
+// github.com/gabriel-vasile/mimetype hierarchy, see ResetRegistry. The
+// extensions of registered plugins are installed again in both cases
+func (r *Registry) Reset() {
+	fresh := New()
+	for _, t := range []Table{ExtensionTable, CharsetTable, AliasTable, FilenameTable, GlobTable} {
+		contents := fresh.table(t).snapshot()
+		if r == gRegistry {
+			contents = gPristine.tables[t]
+		}
+		r.table(t).replace(contents)
+	}
+	r.preferred.replace(map[string]string{})
+	r.icons.replace(map[string]string{})
+	if r == gRegistry {
+		r.parents.replace(gPristine.parents)
+	} else {
+		r.parents.replace(map[string]string{})
+	}
+	r.xmlRoots.replace(fresh.xmlRoots.snapshot())
+	r.detectors.replace(nil)
+	r.containers.replace(fresh.containers.snapshot())
+	r.utis.replace(gUTIDeclarations)
+	r.relations.Lock()
+	r.relations.edges = defaultRelations().edges
+	r.relations.Unlock()
+	r.iana.Lock()
+	r.iana.m = make(map[string]IANARegistration)
+	r.iana.Unlock()
+
+	r.SetOutputPolicy(fresh.GetOutputPolicy())
+	r.SetResolver(nil)
+	r.SetPathForm(fresh.GetPathForm())
+	r.SetOptions(Options{})
+	r.SetWindowsPaths(fresh.GetWindowsPaths())
+	r.SetFollowSymlinks(true)
+	r.SetCaseFolding(true)
+	r.SetSpecialFiles(false)
+	r.SetPathOnly(false)
+	r.SetWindowsRegistry(false)
+	r.SetOverrideFile("")
+
+	if r == gRegistry {
+		gTextDetectors.replace(gPristine.text)
+		gCatchAllDetectors.replace(gPristine.catchAll)
+		gBinaryDetectors.replace(gPristine.binary)
+		gGrafts.Lock()
+		for idx, g := range gGrafts.list {
+			g.enabled.Store(idx < gPristine.grafts)
+		}
+		gGrafts.Unlock()
+		masked := map[string]string{}
+		for extension, mediatype := range gAddedExtensions.snapshot() {
+			if _, builtin := gPristine.added[extension]; !builtin {
+				masked[extension] = mediatype
+			}
+		}
+		gMaskedExtensions.replace(masked)
+		mimetype.SetLimit(HeadWindow)
+	}
+	r.installPlugins()
+}
+
+// ResetRegistry restores the default Registry to the state it had after the
+// package initialization, for use by tests and plugin systems needing to
+// undo registrations, see Registry.Reset
+func ResetRegistry() {
+	gRegistry.Reset()
+}
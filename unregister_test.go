@@ -0,0 +1,173 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnregisterType(t *testing.T) {
+	gone := func(raw []byte, limit uint32) bool { return bytes.HasPrefix(raw, []byte("GONE:")) }
+
+	Convey("Registry.UnregisterType", t, func() {
+		r := New()
+		So(r.RegisterTextType("text/x-gone", "gone", gone), ShouldBeNil)
+		r.SetFilename("GONEFILE", "text/x-gone")
+		So(r.SetGlob("gone-*", "text/x-gone"), ShouldBeNil)
+		r.SetAlias("text/x-old-gone", "text/x-gone")
+		So(r.DetectBytes([]byte("GONE: yes\n")), ShouldEqual, "text/x-gone; charset=utf-8")
+
+		So(r.UnregisterType("text/x-gone; charset=utf-8"), ShouldBeTrue)
+		So(r.FromPathOnly("file.gone"), ShouldBeEmpty)
+		So(r.FromPathOnly("GONEFILE"), ShouldBeEmpty)
+		So(r.FromPathOnly("gone-file"), ShouldBeEmpty)
+		_, ok := r.GetAlias("text/x-old-gone")
+		So(ok, ShouldBeFalse)
+		_, ok = r.GetCharset("text/x-gone")
+		So(ok, ShouldBeFalse)
+		So(r.DetectBytes([]byte("GONE: yes\n")), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.UnregisterType("text/x-gone"), ShouldBeFalse)
+		So(r.UnregisterType(""), ShouldBeFalse)
+
+		Convey("xml roots", func() {
+			r := New()
+			xslt := []byte(`<?xml version="1.0"?><xsl:stylesheet xmlns:xsl="http://www.w3.org/1999/XSL/Transform" version="1.0"/>`)
+			So(PruneCharset(r.DetectBytes(xslt)), ShouldEqual, "application/xslt+xml")
+			So(r.UnregisterType("application/xslt+xml"), ShouldBeTrue)
+			So(PruneCharset(r.DetectBytes(xslt)), ShouldNotEqual, "application/xslt+xml")
+		})
+
+		Convey("icons", func() {
+			r := New()
+			r.SetIconName("text/x-iconic", "iconic")
+			So(r.IconName("text/x-iconic"), ShouldEqual, "iconic")
+			So(r.UnregisterType("text/x-iconic"), ShouldBeTrue)
+			So(r.IconName("text/x-iconic"), ShouldNotEqual, "iconic")
+		})
+
+		Convey("container rules", func() {
+			r := New()
+			So(r.RegisterContainerRule(ContainerRule{Mime: "application/x-bundle", Members: []string{"bundle.json"}}), ShouldBeNil)
+			path := filepath.Join(t.TempDir(), "bundle")
+			So(os.WriteFile(path, makeTestContainer("bundle.json", "{}"), 0o644), ShouldBeNil)
+			So(r.Mime(path), ShouldEqual, "application/x-bundle")
+			So(r.UnregisterType("application/x-bundle"), ShouldBeTrue)
+			So(r.IsZipContainer("application/x-bundle"), ShouldBeFalse)
+			So(r.Mime(path), ShouldEqual, ZipMimeType)
+		})
+
+		Convey("relations", func() {
+			r := New()
+			r.AddRelation("text/x-source", ConvertsTo, "text/x-target")
+			So(r.RelatedTypes("text/x-source", ConvertsTo), ShouldResemble, []string{"text/x-target"})
+			So(r.UnregisterType("text/x-target"), ShouldBeTrue)
+			So(r.RelatedTypes("text/x-source", ConvertsTo), ShouldBeEmpty)
+		})
+
+		Convey("uniform type identifiers", func() {
+			r := New()
+			So(r.RegisterUTI("com.example.gone", "application/x-gone-uti", "public.data"), ShouldBeNil)
+			uti, ok := r.UTIForType("application/x-gone-uti")
+			So(ok, ShouldBeTrue)
+			So(uti, ShouldEqual, "com.example.gone")
+			So(r.UnregisterType("application/x-gone-uti"), ShouldBeTrue)
+			_, ok = r.UTIForType("application/x-gone-uti")
+			So(ok, ShouldBeFalse)
+			_, ok = r.TypeForUTI("com.example.gone")
+			So(ok, ShouldBeFalse)
+			So(r.UTIConformsTo("com.example.gone", "public.data"), ShouldBeTrue)
+
+			// the built-in declarations are not modified
+			uti, ok = New().UTIForType("image/png")
+			So(ok, ShouldBeTrue)
+			So(r.UnregisterType("image/png"), ShouldBeTrue)
+			_, ok = New().TypeForUTI(uti)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("preferred extensions", func() {
+			r := New()
+			r.SetPreferredExtension("image/jpeg", "jpe")
+			extension, _ := r.ExtensionByType("image/jpeg")
+			So(extension, ShouldEqual, "jpe")
+			So(r.UnregisterType("image/jpeg"), ShouldBeTrue)
+			extension, _ = r.ExtensionByType("image/jpeg")
+			So(extension, ShouldNotEqual, "jpe")
+
+			r.SetPreferredExtension("text/x-preferred", "pref")
+			So(r.UnregisterType("text/x-preferred"), ShouldBeTrue)
+			_, ok := r.ExtensionByType("text/x-preferred")
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("UnregisterType detaches grafted detectors", t, func() {
+		data := []byte("UNRG\x00\x01\x02")
+		So(RegisterBinaryType("application/x-unregistered", "unrg", []byte("UNRG\x00"), 0), ShouldBeNil)
+		So(DetectBytes(data), ShouldEqual, "application/x-unregistered")
+		So(FromPathOnly("file.unrg"), ShouldEqual, "application/x-unregistered")
+
+		So(UnregisterType("application/x-unregistered"), ShouldBeTrue)
+		So(DetectBytes(data), ShouldEqual, BinaryMimeType)
+		So(FromPathOnly("file.unrg"), ShouldBeEmpty)
+		So(gRegistry.Export().Types, ShouldNotContain, "application/x-unregistered")
+
+		So(RegisterBinaryType("application/x-unregistered", "unrg", []byte("UNRG\x00"), 0), ShouldBeNil)
+		So(DetectBytes(data), ShouldEqual, "application/x-unregistered")
+		So(FromPathOnly("file.unrg"), ShouldEqual, "application/x-unregistered")
+		So(UnregisterType("application/x-unregistered"), ShouldBeTrue)
+	})
+}
+
+func TestReset(t *testing.T) {
+	Convey("Registry.Reset", t, func() {
+		r := New()
+		r.SetExtension("resetme", "text/x-resetme")
+		r.SetOutputPolicy(BareType)
+		r.SetReadLimit(16)
+		So(r.RegisterDetector(Detector{Mime: "application/x-any", Detect: func(raw []byte, limit uint32) bool { return true }}), ShouldBeNil)
+
+		r.Reset()
+		So(r.FromPathOnly("file.resetme"), ShouldBeEmpty)
+		So(r.FromPathOnly("file.txt"), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.GetOutputPolicy(), ShouldEqual, AsRegistered)
+		So(r.GetReadLimit(), ShouldEqual, HeadWindow)
+		So(r.DetectBytes([]byte("%PDF-1.4\n")), ShouldEqual, "application/pdf")
+	})
+
+	Convey("ResetRegistry", t, func() {
+		data := []byte("RSTB\x00\x01\x02")
+		text := []byte("some notes\n")
+		So(RegisterBinaryType("application/x-reset", "rstb", []byte("RSTB\x00"), 0), ShouldBeNil)
+		So(RegisterTextType("text/x-reset-notes", "rstn", nil), ShouldBeNil)
+		SetExtension("resetme", "text/x-resetme")
+		So(DetectBytes(data), ShouldEqual, "application/x-reset")
+		So(DetectBytes(text), ShouldEqual, "text/x-reset-notes; charset=utf-8")
+
+		ResetRegistry()
+		So(DetectBytes(data), ShouldEqual, BinaryMimeType)
+		So(DetectBytes(text), ShouldEqual, "text/plain; charset=utf-8")
+		So(FromPathOnly("file.rstn"), ShouldBeEmpty)
+		So(FromPathOnly("file.rstb"), ShouldBeEmpty)
+		So(FromPathOnly("file.resetme"), ShouldBeEmpty)
+		So(FromPathOnly("file.md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(DetectBytes([]byte("BEGIN:VCARD\r\nVERSION:4.0\r\nEND:VCARD\r\n")), ShouldEqual, "text/vcard; charset=utf-8")
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"net/url"
+	"strings"
+)
+
+// FromURL classifies the path of the given URL with FromPathOnly. The query
+// string and fragment are ignored and the path is percent-decoded, so that
+// "/static/app.js?v=123" and "/docs/read%20me.md#intro" are classified by
+// their "js" and "md" extensions. URLs without a path, or with a path ending
+// in a slash, return an empty string
+func (r *Registry) FromURL(u *url.URL) (mime string) {
+	if u == nil || u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		return
+	}
+	return r.FromPathOnly(u.Path)
+}
+
+// FromURLString parses the given `rawURL` with url.Parse and classifies it
+// with FromURL
+func (r *Registry) FromURLString(rawURL string) (mime string, err error) {
+	var u *url.URL
+	if u, err = url.Parse(rawURL); err != nil {
+		return
+	}
+	mime = r.FromURL(u)
+	return
+}
+
+// FromURL classifies the path of the given URL with the default Registry,
+// see Registry.FromURL
+func FromURL(u *url.URL) (mime string) {
+	return gRegistry.FromURL(u)
+}
+
+// FromURLString classifies the given `rawURL` with the default Registry, see
+// Registry.FromURLString
+func FromURLString(rawURL string) (mime string, err error) {
+	return gRegistry.FromURLString(rawURL)
+}
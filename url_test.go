@@ -0,0 +1,60 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFromURL(t *testing.T) {
+	Convey("FromURL", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		So(r.FromURL(nil), ShouldBeEmpty)
+
+		for _, check := range []struct {
+			input  string
+			output string
+		}{
+			{"/static/app.js?v=123", "text/javascript"},
+			{"https://example.com/static/app.css?v=1#top", "text/css"},
+			{"https://example.com/docs/read%20me.md#intro", "text/markdown"},
+			{"https://example.com/docs/caf%C3%A9.html", "text/html"},
+			{"https://example.com/page.html%3Fv=1", ""},
+			{"https://example.com/docs/", ""},
+			{"https://example.com", ""},
+			{"https://example.com/?file=app.js", ""},
+		} {
+			mime, err := r.FromURLString(check.input)
+			So(err, ShouldBeNil)
+			So(mime, ShouldEqual, check.output)
+		}
+
+		_, err := r.FromURLString("http://[::1")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("default registry", t, func() {
+		u, err := url.Parse("/index.html?lang=en")
+		So(err, ShouldBeNil)
+		So(FromURL(u), ShouldEqual, FromPathOnly("index.html"))
+		mime, err := FromURLString("/index.html?lang=en")
+		So(err, ShouldBeNil)
+		So(mime, ShouldEqual, FromPathOnly("index.html"))
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// utiDeclaration is a Uniform Type Identifier with its mime types, the
+// first being the preferred one, and the identifiers it conforms to
+type utiDeclaration struct {
+	uti        string
+	mimes      []string
+	conformsTo []string
+}
+
+// gUTIDeclarations are the built-in Uniform Type Identifiers, a subset of
+// the system-declared types of Apple platforms
+var gUTIDeclarations = []utiDeclaration{
+	{uti: "public.item"},
+	{uti: "public.content"},
+	{uti: "public.data", conformsTo: []string{"public.item"}},
+	{uti: "public.directory", conformsTo: []string{"public.item"}},
+	{uti: "public.folder", mimes: []string{DirectoryMimeType}, conformsTo: []string{"public.directory"}},
+	{uti: "public.symlink", mimes: []string{SymlinkMimeType}, conformsTo: []string{"public.item"}},
+
+	{uti: "public.text", conformsTo: []string{"public.data", "public.content"}},
+	{uti: "public.plain-text", mimes: []string{TextMimeType}, conformsTo: []string{"public.text"}},
+	{uti: "public.utf8-plain-text", conformsTo: []string{"public.plain-text"}},
+	{uti: "public.html", mimes: []string{HtmlMimeType}, conformsTo: []string{"public.text"}},
+	{uti: "public.xml", mimes: []string{XmlMimeType, "application/xml"}, conformsTo: []string{"public.text"}},
+	{uti: "public.json", mimes: []string{JsonMimeType}, conformsTo: []string{"public.text"}},
+	{uti: "public.yaml", mimes: []string{YamlMimeType}, conformsTo: []string{"public.text"}},
+	{uti: "public.css", mimes: []string{CssMimeType}, conformsTo: []string{"public.text"}},
+	{uti: "public.comma-separated-values-text", mimes: []string{"text/csv"}, conformsTo: []string{"public.text"}},
+	{uti: "public.rtf", mimes: []string{"text/rtf"}, conformsTo: []string{"public.text"}},
+	{uti: "public.vcard", mimes: []string{"text/vcard"}, conformsTo: []string{"public.text"}},
+	{uti: "public.calendar-event", mimes: []string{"text/calendar"}, conformsTo: []string{"public.text"}},
+	{uti: "net.daringfireball.markdown", mimes: []string{MarkdownMimeType}, conformsTo: []string{"public.plain-text"}},
+	{uti: "public.source-code", conformsTo: []string{"public.plain-text"}},
+	{uti: "public.script", conformsTo: []string{"public.source-code"}},
+	{uti: "com.netscape.javascript-source", mimes: []string{JavaScriptMimeType, "application/javascript"}, conformsTo: []string{"public.script"}},
+	{uti: "public.python-script", mimes: []string{"text/x-python"}, conformsTo: []string{"public.script"}},
+	{uti: "public.php-script", mimes: []string{PhpMimeType}, conformsTo: []string{"public.script"}},
+
+	{uti: "public.image", conformsTo: []string{"public.data", "public.content"}},
+	{uti: "public.png", mimes: []string{"image/png"}, conformsTo: []string{"public.image"}},
+	{uti: "public.jpeg", mimes: []string{"image/jpeg"}, conformsTo: []string{"public.image"}},
+	{uti: "com.compuserve.gif", mimes: []string{"image/gif"}, conformsTo: []string{"public.image"}},
+	{uti: "public.tiff", mimes: []string{"image/tiff"}, conformsTo: []string{"public.image"}},
+	{uti: "com.microsoft.bmp", mimes: []string{"image/bmp"}, conformsTo: []string{"public.image"}},
+	{uti: "com.microsoft.ico", mimes: []string{"image/x-icon", "image/vnd.microsoft.icon"}, conformsTo: []string{"public.image"}},
+	{uti: "org.webmproject.webp", mimes: []string{"image/webp"}, conformsTo: []string{"public.image"}},
+	{uti: "public.heic", mimes: []string{"image/heic"}, conformsTo: []string{"public.heif-standard"}},
+	{uti: "public.heif-standard", mimes: []string{"image/heif"}, conformsTo: []string{"public.image"}},
+	{uti: "public.svg-image", mimes: []string{"image/svg+xml"}, conformsTo: []string{"public.image", "public.xml"}},
+
+	{uti: "public.audiovisual-content", conformsTo: []string{"public.data", "public.content"}},
+	{uti: "public.audio", conformsTo: []string{"public.audiovisual-content"}},
+	{uti: "public.mp3", mimes: []string{Mp3MimeType, "audio/mp3"}, conformsTo: []string{"public.audio"}},
+	{uti: "com.microsoft.waveform-audio", mimes: []string{"audio/wav", "audio/x-wav"}, conformsTo: []string{"public.audio"}},
+	{uti: "public.aiff-audio", mimes: []string{"audio/aiff"}, conformsTo: []string{"public.audio"}},
+	{uti: "org.xiph.flac", mimes: []string{"audio/flac"}, conformsTo: []string{"public.audio"}},
+	{uti: "public.movie", conformsTo: []string{"public.audiovisual-content"}},
+	{uti: "public.video", conformsTo: []string{"public.movie"}},
+	{uti: "public.mpeg-4", mimes: []string{"video/mp4"}, conformsTo: []string{"public.movie"}},
+	{uti: "com.apple.quicktime-movie", mimes: []string{"video/quicktime"}, conformsTo: []string{"public.movie"}},
+
+	{uti: "com.adobe.pdf", mimes: []string{"application/pdf"}, conformsTo: []string{"public.data", "public.composite-content"}},
+	{uti: "public.composite-content", conformsTo: []string{"public.content"}},
+	{uti: "public.archive", conformsTo: []string{"public.data"}},
+	{uti: "public.zip-archive", mimes: []string{ZipMimeType}, conformsTo: []string{"public.archive"}},
+	{uti: "org.gnu.gnu-zip-archive", mimes: []string{GzipMimeType}, conformsTo: []string{"public.archive"}},
+	{uti: "public.tar-archive", mimes: []string{TarMimeType}, conformsTo: []string{"public.archive"}},
+	{uti: "public.iso-image", mimes: []string{IsoMimeType}, conformsTo: []string{"public.data"}},
+	{uti: "org.openxmlformats.wordprocessingml.document", mimes: []string{DocxMimeType}, conformsTo: []string{"public.zip-archive", "public.composite-content"}},
+	{uti: "org.openxmlformats.spreadsheetml.sheet", mimes: []string{XlsxMimeType}, conformsTo: []string{"public.zip-archive", "public.composite-content"}},
+	{uti: "org.openxmlformats.presentationml.presentation", mimes: []string{PptxMimeType}, conformsTo: []string{"public.zip-archive", "public.composite-content"}},
+	{uti: "public.font", conformsTo: []string{"public.data"}},
+	{uti: "public.truetype-ttf-font", mimes: []string{"font/ttf"}, conformsTo: []string{"public.font"}},
+	{uti: "public.opentype-font", mimes: []string{"font/otf"}, conformsTo: []string{"public.font"}},
+}
+
+// utiTable holds the Uniform Type Identifier declarations of a Registry,
+// keyed by the lowercased identifier, along with the mime type to
+// identifier index
+type utiTable struct {
+	byUTI  map[string]utiDeclaration
+	byMime map[string]string
+	sync.RWMutex
+}
+
+// defaultUTIs returns the built-in Uniform Type Identifiers of a new
+// Registry
+func defaultUTIs() (t *utiTable) {
+	t = &utiTable{}
+	t.replace(gUTIDeclarations)
+	return
+}
+
+// replace discards all declarations and declares the given ones
+func (t *utiTable) replace(declarations []utiDeclaration) {
+	t.Lock()
+	defer t.Unlock()
+	t.byUTI = make(map[string]utiDeclaration, len(declarations))
+	t.byMime = make(map[string]string)
+	for _, d := range declarations {
+		t.declare(d)
+	}
+}
+
+// declare adds the `d` declaration, replacing any existing declaration of
+// the same identifier, must be called with the lock held
+func (t *utiTable) declare(d utiDeclaration) {
+	key := strings.ToLower(d.uti)
+	if existing, ok := t.byUTI[key]; ok {
+		for _, mime := range existing.mimes {
+			if t.byMime[mime] == key {
+				delete(t.byMime, mime)
+			}
+		}
+	}
+	t.byUTI[key] = d
+	for _, mime := range d.mimes {
+		t.byMime[mime] = key
+	}
+}
+
+// removeMime detaches the given `mediatype` from every declaration, the
+// identifiers themselves remain declared, returning true if the type was
+// present
+func (t *utiTable) removeMime(mediatype string) (removed bool) {
+	t.Lock()
+	defer t.Unlock()
+	if _, removed = t.byMime[mediatype]; !removed {
+		return
+	}
+	delete(t.byMime, mediatype)
+	for key, d := range t.byUTI {
+		mimes := make([]string, 0, len(d.mimes))
+		for _, mime := range d.mimes {
+			if mime != mediatype {
+				mimes = append(mimes, mime)
+			}
+		}
+		if len(mimes) != len(d.mimes) {
+			d.mimes = mimes
+			t.byUTI[key] = d
+		}
+	}
+	return
+}
+
+// RegisterUTI declares the given Uniform Type Identifier, such as
+// "public.png", with the `mime` type it is preferably exchanged as and the
+// identifiers it directly conforms to, replacing any existing declaration
+// of the `uti`. Abstract identifiers, such as "public.image", have no
+// `mime` type. Identifiers are matched without regard to case
+func (r *Registry) RegisterUTI(uti, mime string, conformsTo ...string) (err error) {
+	defer r.logRegistration(mime, &err)
+	if uti == "" || strings.ContainsAny(uti, " \t\r\n/") {
+		return fmt.Errorf("invalid uniform type identifier %q", uti)
+	}
+	d := utiDeclaration{uti: uti}
+	if mime != "" {
+		if err = checkMimeType(mime); err != nil {
+			return
+		}
+		d.mimes = []string{PruneCharset(mime)}
+	}
+	for _, parent := range conformsTo {
+		if parent == "" {
+			return errors.New("conformance identifiers must not be empty")
+		}
+		d.conformsTo = append(d.conformsTo, parent)
+	}
+	r.utis.Lock()
+	defer r.utis.Unlock()
+	r.utis.declare(d)
+	return
+}
+
+// UTIForType returns the Uniform Type Identifier declared for the given
+// `mime` type, with any registered alias replaced by its canonical type
+func (r *Registry) UTIForType(mime string) (uti string, ok bool) {
+	mediatype := PruneCharset(r.Canonical(mime))
+	r.utis.RLock()
+	defer r.utis.RUnlock()
+	var key string
+	if key, ok = r.utis.byMime[mediatype]; ok {
+		uti = r.utis.byUTI[key].uti
+	}
+	return
+}
+
+// TypeForUTI returns the preferred mime type declared for the given
+// Uniform Type Identifier, shaped by the OutputPolicy. Abstract identifiers
+// have no mime type
+func (r *Registry) TypeForUTI(uti string) (mime string, ok bool) {
+	r.utis.RLock()
+	d, found := r.utis.byUTI[strings.ToLower(uti)]
+	r.utis.RUnlock()
+	if ok = found && len(d.mimes) > 0; ok {
+		mime = r.output(d.mimes[0])
+	}
+	return
+}
+
+// UTIConformsTo returns true if the Uniform Type Identifier `uti` is the
+// same as, or directly or indirectly conforms to, the `parent` identifier.
+// For example "public.png" conforms to "public.image" and "public.data"
+func (r *Registry) UTIConformsTo(uti, parent string) (conforms bool) {
+	if strings.EqualFold(uti, parent) {
+		return uti != ""
+	}
+	r.UTIAncestors(uti)(func(ancestor string) bool {
+		conforms = strings.EqualFold(ancestor, parent)
+		return !conforms
+	})
+	return
+}
+
+// UTIAncestors returns an iterator over the identifiers that the Uniform
+// Type Identifier `uti` conforms to, breadth-first and not including `uti`
+// itself. Each identifier is yielded once
+//
+// The iterator has the same shape as the Go 1.23 iter.Seq[string] type and
+// can be used with range-over-func
+func (r *Registry) UTIAncestors(uti string) func(yield func(uti string) bool) {
+	return func(yield func(uti string) bool) {
+		r.utis.RLock()
+		var ancestors []string
+		seen := map[string]struct{}{strings.ToLower(uti): {}}
+		queue := []string{uti}
+		for len(queue) > 0 {
+			d := r.utis.byUTI[strings.ToLower(queue[0])]
+			queue = queue[1:]
+			for _, parent := range d.conformsTo {
+				key := strings.ToLower(parent)
+				if _, present := seen[key]; !present {
+					seen[key] = struct{}{}
+					if declared, ok := r.utis.byUTI[key]; ok {
+						parent = declared.uti
+					}
+					ancestors = append(ancestors, parent)
+					queue = append(queue, parent)
+				}
+			}
+		}
+		r.utis.RUnlock()
+		for _, ancestor := range ancestors {
+			if !yield(ancestor) {
+				return
+			}
+		}
+	}
+}
+
+// RegisterUTI declares a Uniform Type Identifier within the default
+// Registry, see Registry.RegisterUTI
+func RegisterUTI(uti, mime string, conformsTo ...string) (err error) {
+	return gRegistry.RegisterUTI(uti, mime, conformsTo...)
+}
+
+// UTIForType returns the Uniform Type Identifier of the given `mime` type
+// using the default Registry, see Registry.UTIForType
+func UTIForType(mime string) (uti string, ok bool) {
+	return gRegistry.UTIForType(mime)
+}
+
+// TypeForUTI returns the preferred mime type of the given Uniform Type
+// Identifier using the default Registry, see Registry.TypeForUTI
+func TypeForUTI(uti string) (mime string, ok bool) {
+	return gRegistry.TypeForUTI(uti)
+}
+
+// UTIConformsTo reports Uniform Type Identifier conformance using the
+// default Registry, see Registry.UTIConformsTo
+func UTIConformsTo(uti, parent string) (conforms bool) {
+	return gRegistry.UTIConformsTo(uti, parent)
+}
+
+// UTIAncestors returns an iterator over the identifiers that `uti` conforms
+// to using the default Registry, see Registry.UTIAncestors
+func UTIAncestors(uti string) func(yield func(uti string) bool) {
+	return gRegistry.UTIAncestors(uti)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func collectUTIs(seq func(yield func(uti string) bool)) (utis []string) {
+	seq(func(uti string) bool {
+		utis = append(utis, uti)
+		return true
+	})
+	return
+}
+
+func TestUTI(t *testing.T) {
+	Convey("built-in declarations", t, func() {
+		r := New()
+		uti, ok := r.UTIForType("image/png")
+		So(ok, ShouldBeTrue)
+		So(uti, ShouldEqual, "public.png")
+		uti, _ = r.UTIForType("application/xml; charset=utf-8")
+		So(uti, ShouldEqual, "public.xml")
+		uti, _ = r.UTIForType("text/x-markdown")
+		So(uti, ShouldEqual, "net.daringfireball.markdown")
+		_, ok = r.UTIForType("application/x-unheard-of")
+		So(ok, ShouldBeFalse)
+
+		mime, ok := r.TypeForUTI("Public.JPEG")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "image/jpeg")
+		mime, _ = r.TypeForUTI("public.xml")
+		So(mime, ShouldEqual, XmlMimeType)
+		_, ok = r.TypeForUTI("public.image")
+		So(ok, ShouldBeFalse)
+		_, ok = r.TypeForUTI("com.example.unknown")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("conformance", t, func() {
+		r := New()
+		So(collectUTIs(r.UTIAncestors("public.svg-image")), ShouldResemble, []string{
+			"public.image", "public.xml", "public.data", "public.content", "public.text", "public.item",
+		})
+		So(r.UTIConformsTo("public.png", "public.image"), ShouldBeTrue)
+		So(r.UTIConformsTo("public.png", "PUBLIC.DATA"), ShouldBeTrue)
+		So(r.UTIConformsTo("public.png", "public.png"), ShouldBeTrue)
+		So(r.UTIConformsTo("public.png", "public.text"), ShouldBeFalse)
+		So(r.UTIConformsTo("", ""), ShouldBeFalse)
+		So(collectUTIs(r.UTIAncestors("com.example.unknown")), ShouldBeEmpty)
+		So(UTIConformsTo("org.openxmlformats.wordprocessingml.document", "public.archive"), ShouldBeTrue)
+	})
+
+	Convey("RegisterUTI", t, func() {
+		r := New()
+		So(r.RegisterUTI("", "text/x-thing"), ShouldNotBeNil)
+		So(r.RegisterUTI("com.example thing", "text/x-thing"), ShouldNotBeNil)
+		So(r.RegisterUTI("com.example.thing", "not a type"), ShouldNotBeNil)
+		So(r.RegisterUTI("com.example.thing", "text/x-thing", ""), ShouldNotBeNil)
+
+		So(r.RegisterUTI("com.example.thing", "text/x-thing; charset=utf-8", "public.plain-text"), ShouldBeNil)
+		uti, _ := r.UTIForType("text/x-thing")
+		So(uti, ShouldEqual, "com.example.thing")
+		mime, _ := r.TypeForUTI("com.example.thing")
+		So(mime, ShouldEqual, "text/x-thing")
+		So(r.UTIConformsTo("com.example.thing", "public.text"), ShouldBeTrue)
+
+		// redeclaring replaces the mime type and conformance
+		So(r.RegisterUTI("com.example.thing", "application/x-thing", "public.data"), ShouldBeNil)
+		_, ok := r.UTIForType("text/x-thing")
+		So(ok, ShouldBeFalse)
+		So(r.UTIConformsTo("com.example.thing", "public.text"), ShouldBeFalse)
+
+		r.Reset()
+		_, ok = r.TypeForUTI("com.example.thing")
+		So(ok, ShouldBeFalse)
+		_, ok = r.TypeForUTI("public.png")
+		So(ok, ShouldBeTrue)
+	})
+}
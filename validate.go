@@ -0,0 +1,132 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"fmt"
+	goMime "mime"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+// gBuiltinTextTypes are the extensions and mime types registered with
+// RegisterTextType during package initialization
+var gBuiltinTextTypes = map[string]string{
+	EnjinExtension:    EnjinMimeType,
+	OrgModeExtension:  OrgModeMimeType,
+	MarkdownExtension: MarkdownMimeType,
+	VCardExtension:    VCardMimeType,
+	CalendarExtension: CalendarMimeType,
+	YamlExtension:     YamlMimeType,
+	TomlExtension:     TomlMimeType,
+}
+
+// Validate re-checks the integrity of the extension, charset, alias, filename
+// and glob registrations and returns all the problems found joined with
+// errors.Join, or nil if there are none. Registered mime types must parse,
+// charsets must be known IANA names and aliases must not chain or loop. For
+// the default Registry, any errors from package initialization are included
+// and the built-in text types are confirmed to be registered. Validate is
+// suitable for wiring into service readiness checks
+func (r *Registry) Validate() (err error) {
+	var problems []error
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if r == gRegistry {
+		gInitErrors.Lock()
+		problems = append(problems, gInitErrors.list...)
+		gInitErrors.Unlock()
+		for _, extension := range sortedKeys(gBuiltinTextTypes) {
+			mime := gBuiltinTextTypes[extension]
+			if value, ok := r.extensions.get(extension); !ok || PruneCharset(value) != mime {
+				report("built-in extension %q is not registered as %q", extension, mime)
+			}
+			if !r.IsKindOf(mime, TextMimeType) {
+				report("built-in text type %q is not registered as text", mime)
+			}
+		}
+	}
+
+	extensions := r.snapshot(ExtensionTable)
+	for _, extension := range sortedKeys(extensions) {
+		if extension == "" || strings.ContainsAny(extension, "/\\") {
+			report("invalid extension %q", extension)
+		}
+		if _, _, ee := goMime.ParseMediaType(extensions[extension]); ee != nil {
+			report("extension %q has an invalid mime type %q: %w", extension, extensions[extension], ee)
+		}
+	}
+
+	charsets := r.snapshot(CharsetTable)
+	for _, mime := range sortedKeys(charsets) {
+		if _, _, ee := goMime.ParseMediaType(mime); ee != nil {
+			report("charset registered for an invalid mime type %q: %w", mime, ee)
+		}
+		if _, ee := ianaindex.IANA.Encoding(charsets[mime]); ee != nil {
+			report("mime type %q has an unknown charset %q", mime, charsets[mime])
+		}
+	}
+
+	aliases := r.snapshot(AliasTable)
+	for _, alias := range sortedKeys(aliases) {
+		canonical := aliases[alias]
+		if _, _, ee := goMime.ParseMediaType(canonical); ee != nil {
+			report("alias %q has an invalid canonical mime type %q: %w", alias, canonical, ee)
+		} else if canonical == alias {
+			report("alias %q refers to itself", alias)
+		} else if _, chained := aliases[canonical]; chained {
+			report("alias %q refers to another alias %q", alias, canonical)
+		}
+	}
+
+	filenames := r.snapshot(FilenameTable)
+	for _, name := range sortedKeys(filenames) {
+		if name == "" || strings.ContainsAny(name, "/\\") {
+			report("invalid filename %q", name)
+		}
+		if _, _, ee := goMime.ParseMediaType(filenames[name]); ee != nil {
+			report("filename %q has an invalid mime type %q: %w", name, filenames[name], ee)
+		}
+	}
+
+	globs := r.snapshot(GlobTable)
+	for _, pattern := range sortedKeys(globs) {
+		if _, _, ee := goMime.ParseMediaType(globs[pattern]); ee != nil {
+			report("glob %q has an invalid mime type %q: %w", pattern, globs[pattern], ee)
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// Validate checks the integrity of the default Registry, see
+// Registry.Validate
+func Validate() (err error) {
+	return gRegistry.Validate()
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidate(t *testing.T) {
+	Convey("Validate", t, func() {
+		So(Validate(), ShouldBeNil)
+		So(New().Validate(), ShouldBeNil)
+
+		r := New()
+		r.SetExtension("bad", "not a mime type")
+		r.SetCharset("text/x-custom", "not-a-charset")
+		r.SetAlias("text/x-one", "text/x-two")
+		r.SetAlias("text/x-two", "text/x-three")
+		err := r.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, `extension "bad" has an invalid mime type "not a mime type": mime: expected slash after first token`+"\n"+
+			`mime type "text/x-custom" has an unknown charset "not-a-charset"`+"\n"+
+			`alias "text/x-one" refers to another alias "text/x-two"`)
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+)
+
+const (
+	// VCardMimeType is the mime type of RFC 6350 vCard contact files
+	VCardMimeType = "text/vcard"
+	// CalendarMimeType is the mime type of RFC 5545 iCalendar files
+	CalendarMimeType = "text/calendar"
+)
+
+const (
+	// VCardExtension defines the file extension associated with the
+	// VCardMimeType
+	VCardExtension = "vcf"
+	// CalendarExtension defines the file extension associated with the
+	// CalendarMimeType
+	CalendarExtension = "ics"
+)
+
+func registerCardTypes() {
+	// both formats are UTF-8 by default per their respective RFCs
+	initError(RegisterTextType(VCardMimeType, VCardExtension, VCardDetector))
+	initError(RegisterTextType(CalendarMimeType, CalendarExtension, CalendarDetector))
+}
+
+// VCardDetector returns true if the given `raw` content starts with a
+// BEGIN:VCARD line, ignoring any leading byte order mark and whitespace
+func VCardDetector(raw []byte, limit uint32) bool {
+	return hasBeginLine(raw, "VCARD")
+}
+
+// CalendarDetector returns true if the given `raw` content starts with a
+// BEGIN:VCALENDAR line, ignoring any leading byte order mark and whitespace
+func CalendarDetector(raw []byte, limit uint32) bool {
+	return hasBeginLine(raw, "VCALENDAR")
+}
+
+func hasBeginLine(raw []byte, component string) bool {
+	raw = bytes.TrimLeft(bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf")), " \t\r\n")
+	prefix := "BEGIN:" + component
+	if len(raw) < len(prefix) || !bytes.EqualFold(raw[:len(prefix)], []byte(prefix)) {
+		return false
+	}
+	rest := raw[len(prefix):]
+	return len(rest) == 0 || rest[0] == '\r' || rest[0] == '\n'
+}
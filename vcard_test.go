@@ -0,0 +1,53 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVCard(t *testing.T) {
+	vcard := []byte("\xef\xbb\xbfBEGIN:VCARD\r\nVERSION:4.0\r\nFN:Someone\r\nEND:VCARD\r\n")
+	ical := []byte("begin:vcalendar\nVERSION:2.0\nEND:VCALENDAR\n")
+
+	Convey("detectors", t, func() {
+		So(VCardDetector(vcard, 3072), ShouldBeTrue)
+		So(VCardDetector(ical, 3072), ShouldBeFalse)
+		So(VCardDetector([]byte("BEGIN:VCARDS\n"), 3072), ShouldBeFalse)
+		So(CalendarDetector(ical, 3072), ShouldBeTrue)
+		So(CalendarDetector(vcard, 3072), ShouldBeFalse)
+		So(CalendarDetector([]byte("BEGIN:VCALENDAR"), 3072), ShouldBeTrue)
+	})
+
+	Convey("hierarchy", t, func() {
+		for _, mime := range []string{VCardMimeType, CalendarMimeType} {
+			mt := mimetype.Lookup(mime)
+			So(mt, ShouldNotBeNil)
+			So(mt.Parent().Is(TextMimeType), ShouldBeTrue)
+		}
+	})
+
+	Convey("registration", t, func() {
+		So(FromPathOnly("contact.vcf"), ShouldEqual, "text/vcard; charset=utf-8")
+		So(FromPathOnly("event.ics"), ShouldEqual, "text/calendar; charset=utf-8")
+		charset, ok := GetCharset(VCardMimeType)
+		So(ok, ShouldBeTrue)
+		So(charset, ShouldEqual, "utf-8")
+		So(IsPlainText(CalendarMimeType), ShouldBeTrue)
+	})
+}
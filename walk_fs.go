@@ -0,0 +1,114 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"io/fs"
+	"path"
+)
+
+// WalkFS returns an iterator over the entries of `fsys` starting at `root`,
+// in the lexical order of fs.WalkDir, yielding each path along with its mime
+// type. The mime type of each entry is only resolved when the entry is
+// reached, first with MimeFromDirEntry and then by the content of regular
+// files not identified by name. Entries which cannot be read, including
+// directories which cannot be listed, are yielded once with an empty mime
+// type. Stopping the iteration stops the walk
+//
+// The iterator has the same shape as the Go 1.23 iter.Seq2[string, string]
+// type and can be used with range-over-func:
+//
+//	for path, mime := range mime.WalkFS(fsys, ".") {
+//	    ...
+//	}
+func (r *Registry) WalkFS(fsys fs.FS, root string) func(yield func(path, mime string) bool) {
+	return func(yield func(path, mime string) bool) {
+		if info, err := fs.Stat(fsys, root); err != nil {
+			yield(root, "")
+		} else {
+			r.walkFS(fsys, root, fs.FileInfoToDirEntry(info), yield)
+		}
+	}
+}
+
+// walkFS yields the entry `d` found at `name` and, for directories, walks
+// the entries within, returning false when the iteration is stopped
+func (r *Registry) walkFS(fsys fs.FS, name string, d fs.DirEntry, yield func(path, mime string) bool) (ok bool) {
+	if !d.IsDir() {
+		mime := r.MimeFromDirEntry(d)
+		if mime == "" && d.Type().IsRegular() {
+			mime = r.detectFS(fsys, name, "")
+		}
+		return yield(name, mime)
+	}
+
+	// list the directory before yielding it so that a failure is reported
+	// with the directory itself
+	entries, err := fs.ReadDir(fsys, name)
+	var mime string
+	if err == nil {
+		mime = r.MimeFromDirEntry(d)
+	}
+	if !yield(name, mime) {
+		return false
+	}
+	for _, entry := range entries {
+		if !r.walkFS(fsys, path.Join(name, entry.Name()), entry, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// MimeFS is the fs.FS version of Mime, classifying the file or directory at
+// `path` within `fsys`, such as an embed.FS, a zip.Reader or a
+// testing/fstest.MapFS. Directories and special files are reported with their
+// inode/* mime types, see ModeMime. Per-directory override files are not
+// supported by MimeFS
+func (r *Registry) MimeFS(fsys fs.FS, path string) (mime string) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return
+	} else if mime = ModeMime(info.Mode()); mime != "" {
+		return
+	} else if mime, _ = r.FromPathChecked(path); mime != "" && !r.IsPlainText(mime) {
+		return
+	}
+	return r.detectFS(fsys, path, mime)
+}
+
+// detectFS returns the mime type of the file at `path` within `fsys`, where
+// `byExtension` is the FromPathChecked result, see fromHead
+func (r *Registry) detectFS(fsys fs.FS, path, byExtension string) (mime string) {
+	fh, err := fsys.Open(path)
+	if err != nil {
+		return r.fromHead(fsys, path, byExtension, nil, err)
+	}
+	defer fh.Close()
+	head, err := readHeadN(fh, r.GetReadLimit())
+	return r.fromHead(fsys, path, byExtension, head, err)
+}
+
+// WalkFS returns an iterator over the entries of `fsys` using the default
+// Registry, see Registry.WalkFS
+func WalkFS(fsys fs.FS, root string) func(yield func(path, mime string) bool) {
+	return gRegistry.WalkFS(fsys, root)
+}
+
+// MimeFS classifies the file or directory at `path` within `fsys` using the
+// default Registry, see Registry.MimeFS
+func MimeFS(fsys fs.FS, path string) (mime string) {
+	return gRegistry.MimeFS(fsys, path)
+}
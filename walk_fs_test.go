@@ -0,0 +1,159 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// lockedFS is a fstest.MapFS which fails to list the "locked" directory
+type lockedFS struct {
+	fstest.MapFS
+}
+
+func (l lockedFS) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	if name == "locked" {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return l.MapFS.ReadDir(name)
+}
+
+// streamFS is a fstest.MapFS whose files do not implement io.ReaderAt
+type streamFS struct {
+	fstest.MapFS
+}
+
+func (s streamFS) Open(name string) (f fs.File, err error) {
+	if f, err = s.MapFS.Open(name); err == nil {
+		f = struct{ fs.File }{f}
+	}
+	return
+}
+
+func TestWalkFS(t *testing.T) {
+	png, _ := os.ReadFile("./testdata/empty-png")
+	fsys := fstest.MapFS{
+		"docs/notes.txt":  {Data: []byte("notes")},
+		"images/logo":     {Data: png},
+		"site/index.html": {Data: []byte("<html></html>")},
+	}
+
+	Convey("WalkFS", t, func() {
+		var paths, mimes []string
+		WalkFS(fsys, ".")(func(path, mime string) bool {
+			paths = append(paths, path)
+			mimes = append(mimes, mime)
+			return true
+		})
+		So(paths, ShouldResemble, []string{".", "docs", "docs/notes.txt", "images", "images/logo", "site", "site/index.html"})
+		So(mimes[0], ShouldEqual, DirectoryMimeType)
+		So(mimes[2], ShouldEqual, "text/plain; charset=utf-8")
+		So(mimes[4], ShouldEqual, "image/png")
+		So(mimes[6], ShouldEqual, "text/html; charset=utf-8")
+
+		Convey("stopping early", func() {
+			var images []string
+			WalkFS(fsys, ".")(func(path, mime string) bool {
+				if Match("image/*", mime) {
+					images = append(images, path)
+					return false
+				}
+				return true
+			})
+			So(images, ShouldResemble, []string{"images/logo"})
+		})
+
+		Convey("missing root", func() {
+			var seen []string
+			WalkFS(fsys, "nope")(func(path, mime string) bool {
+				seen = append(seen, path+"|"+mime)
+				return true
+			})
+			So(seen, ShouldResemble, []string{"nope|"})
+		})
+
+		Convey("unreadable directory", func() {
+			locked := lockedFS{MapFS: fstest.MapFS{
+				"locked/secret.txt": {Data: []byte("secret")},
+				"open/notes.txt":    {Data: []byte("notes")},
+			}}
+			_, err := fs.ReadDir(locked, "locked")
+			So(errors.Is(err, fs.ErrPermission), ShouldBeTrue)
+			var seen []string
+			WalkFS(locked, ".")(func(path, mime string) bool {
+				seen = append(seen, path+"|"+mime)
+				return true
+			})
+			So(seen, ShouldResemble, []string{
+				".|" + DirectoryMimeType,
+				"locked|",
+				"open|" + DirectoryMimeType,
+				"open/notes.txt|text/plain; charset=utf-8",
+			})
+		})
+	})
+
+	Convey("MimeFS", t, func() {
+		So(MimeFS(fsys, "."), ShouldEqual, DirectoryMimeType)
+		So(MimeFS(fsys, "docs"), ShouldEqual, DirectoryMimeType)
+		So(MimeFS(fsys, "docs/notes.txt"), ShouldEqual, "text/plain; charset=utf-8")
+		So(MimeFS(fsys, "images/logo"), ShouldEqual, "image/png")
+		So(MimeFS(fsys, "missing"), ShouldBeEmpty)
+
+		r := New()
+		r.SetResolver(func(path string, peek []byte) (string, bool) {
+			return "application/x-resolved", path == "images/logo"
+		})
+		So(r.MimeFS(fsys, "images/logo"), ShouldEqual, "application/x-resolved")
+
+		Convey("containers are read from the fs.FS", func() {
+			docx := makeTestContainer("[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>")
+			plain := makeTestContainer("readme.txt", "hello")
+
+			// a local file of the same name must never be consulted
+			cwd, _ := os.Getwd()
+			So(os.Chdir(t.TempDir()), ShouldBeNil)
+			defer func() { _ = os.Chdir(cwd) }()
+			So(os.WriteFile("upload", docx, 0o644), ShouldBeNil)
+
+			containers := fstest.MapFS{
+				"upload": {Data: plain},
+				"report": {Data: docx},
+			}
+			So(MimeFS(containers, "upload"), ShouldEqual, ZipMimeType)
+			So(MimeFS(containers, "report"), ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+			var walked []string
+			WalkFS(containers, ".")(func(path, mime string) bool {
+				walked = append(walked, path+"|"+mime)
+				return true
+			})
+			So(walked, ShouldResemble, []string{
+				".|" + DirectoryMimeType,
+				"report|application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+				"upload|" + ZipMimeType,
+			})
+
+			streamed := streamFS{MapFS: containers}
+			So(MimeFS(streamed, "upload"), ShouldEqual, ZipMimeType)
+			So(MimeFS(streamed, "report"), ShouldEqual, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+		})
+	})
+}
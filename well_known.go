@@ -0,0 +1,98 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+)
+
+const (
+	// MakefileMimeType defines the mime type used for make build files
+	MakefileMimeType = "text/x-makefile"
+	// DockerfileMimeType defines the mime type used for container build files
+	DockerfileMimeType = "text/x-dockerfile"
+)
+
+// defaultFilenames returns the built-in well-known file names, keyed in
+// lower case
+func defaultFilenames() (l *lookup) {
+	return newLookup(map[string]string{
+		"makefile":      MakefileMimeType + "; charset=utf-8",
+		"gnumakefile":   MakefileMimeType + "; charset=utf-8",
+		"dockerfile":    DockerfileMimeType + "; charset=utf-8",
+		"containerfile": DockerfileMimeType + "; charset=utf-8",
+		"license":       TextMimeType + "; charset=utf-8",
+		"licence":       TextMimeType + "; charset=utf-8",
+		"copying":       TextMimeType + "; charset=utf-8",
+		"authors":       TextMimeType + "; charset=utf-8",
+		"notice":        TextMimeType + "; charset=utf-8",
+		"readme":        TextMimeType + "; charset=utf-8",
+		"changelog":     TextMimeType + "; charset=utf-8",
+	})
+}
+
+// GetFilename returns the mime type associated with the well-known file
+// `name` using SetFilename. The exact `name` is checked first and then the
+// lower-cased `name`, so that "Makefile" and "LICENSE" match the built-in
+// "makefile" and "license" entries. The mime type returned is shaped by the
+// OutputPolicy
+func (r *Registry) GetFilename(name string) (mime string, ok bool) {
+	name = r.normalizePath(name)
+	if mime, ok = r.filenames.get(name); !ok {
+		mime, ok = r.filenames.get(strings.ToLower(name))
+	}
+	if ok {
+		mime = r.output(mime)
+	}
+	return
+}
+
+// SetFilename registers the given well-known file `name`, such as "Makefile"
+// or "LICENSE", with the given mime type string. Well-known names take
+// precedence over any extensions when classifying paths. If `mime` is empty,
+// any association with the name is cleared
+func (r *Registry) SetFilename(name, mime string) {
+	name = r.normalizePath(name)
+	if mime == "" {
+		r.filenames.unset(name)
+		return
+	}
+	r.filenames.set(name, mime)
+}
+
+// GetFilename returns the mime type associated with the well-known file
+// `name`, see Registry.GetFilename for details
+func GetFilename(name string) (mime string, ok bool) {
+	return gRegistry.GetFilename(name)
+}
+
+// SetFilename registers the given well-known file `name` with the given mime
+// type string. If `mime` is empty, any association with the name is cleared
+func SetFilename(name, mime string) {
+	gRegistry.SetFilename(name, mime)
+}
+
+// baseName returns the final element of the slash separated `path`, also
+// splitting on backslashes when Windows path handling is enabled
+func (r *Registry) baseName(path string) (name string) {
+	separators := "/"
+	if r.GetWindowsPaths() {
+		separators = `/\`
+	}
+	if idx := strings.LastIndexAny(path, separators); idx >= 0 {
+		return path[idx+1:]
+	}
+	return path
+}
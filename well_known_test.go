@@ -0,0 +1,71 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWellKnownFilenames(t *testing.T) {
+	Convey("Well-known filenames", t, func() {
+		r := New()
+		So(r.FromPathOnly("Makefile"), ShouldEqual, "text/x-makefile; charset=utf-8")
+		So(r.FromPathOnly("src/GNUmakefile"), ShouldEqual, "text/x-makefile; charset=utf-8")
+		So(r.FromPathOnly("/build/Dockerfile"), ShouldEqual, "text/x-dockerfile; charset=utf-8")
+		So(r.FromPathOnly("LICENSE"), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.FromPathOnly("COPYING"), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.FromPathOnly("LICENSE.md"), ShouldEqual, "text/markdown; charset=utf-8")
+		So(r.FromPathOnly("Unknownfile"), ShouldEqual, "")
+		So(r.IsPlainText(MakefileMimeType), ShouldBeTrue)
+
+		r.SetFilename("Jenkinsfile", "text/x-groovy")
+		So(r.FromPathOnly("ci/Jenkinsfile"), ShouldEqual, "text/x-groovy")
+		mime, ok := r.GetFilename("jenkinsfile")
+		So(ok, ShouldBeFalse)
+		So(mime, ShouldEqual, "")
+		So(Diff(New(), r).String(), ShouldEqual, "+ filename \"Jenkinsfile\": \"text/x-groovy\"\n")
+
+		var buf bytes.Buffer
+		So(r.GenerateGo(&buf, "mimes"), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `r.SetFilename("Jenkinsfile", "text/x-groovy")`)
+
+		r.SetFilename("Jenkinsfile", "")
+		So(r.FromPathOnly("Jenkinsfile"), ShouldEqual, "")
+		r.SetFilename("LICENSE", "text/x-license")
+		So(r.FromPathOnly("LICENSE"), ShouldEqual, "text/x-license")
+		So(r.FromPathOnly("License"), ShouldEqual, "text/plain; charset=utf-8")
+
+		r.SetFilename("bad", "not a type")
+		So(r.Validate(), ShouldNotBeNil)
+
+		r.SetWindowsPaths(true)
+		So(r.FromPathOnly(`C:\src\Makefile`), ShouldEqual, "text/x-makefile; charset=utf-8")
+
+		dir := t.TempDir()
+		So(os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0644), ShouldBeNil)
+		So(r.Mime(filepath.Join(dir, "Dockerfile")), ShouldEqual, "text/x-dockerfile; charset=utf-8")
+
+		SetFilename("Procfile", "text/x-procfile")
+		So(FromPathOnly("Procfile"), ShouldEqual, "text/x-procfile")
+		SetFilename("Procfile", "")
+		_, ok = GetFilename("Procfile")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+)
+
+// SplitWindowsName separates the base name of the given `path` from any NTFS
+// alternate data stream suffix and removes the trailing dots and spaces that
+// Windows silently ignores. For example "report.pdf. " returns "report.pdf"
+// and "file.txt:Zone.Identifier:$DATA" returns "file.txt" with the stream
+// "Zone.Identifier:$DATA". Any directory portion of `path` is preserved
+func SplitWindowsName(path string) (clean, stream string) {
+	var dir, base string
+	if idx := strings.LastIndexAny(path, `/\`); idx >= 0 {
+		dir, base = path[:idx+1], path[idx+1:]
+	} else {
+		dir, base = "", path
+	}
+	if len(dir) == 0 && len(base) >= 2 && base[1] == ':' && isDriveLetter(base[0]) {
+		// bare drive-relative names, ie: "C:file.txt"
+		dir, base = base[:2], base[2:]
+	}
+	base, stream, _ = strings.Cut(base, ":")
+	base = strings.TrimRight(base, ". ")
+	clean = dir + base
+	return
+}
+
+// SetWindowsPaths enables or disables Windows path handling, see
+// SplitWindowsName, which is applied to paths before extensions are
+// extracted. Windows path handling is enabled by default only when running
+// on Windows; enable it explicitly when classifying names received from
+// Windows clients
+func (r *Registry) SetWindowsPaths(enabled bool) {
+	r.windowsPaths.Store(enabled)
+}
+
+// GetWindowsPaths returns true if Windows path handling is enabled
+func (r *Registry) GetWindowsPaths() (enabled bool) {
+	return r.windowsPaths.Load()
+}
+
+// SetWindowsPaths enables or disables Windows path handling for the default
+// Registry
+func SetWindowsPaths(enabled bool) {
+	gRegistry.SetWindowsPaths(enabled)
+}
+
+// GetWindowsPaths returns true if Windows path handling is enabled for the
+// default Registry
+func GetWindowsPaths() (enabled bool) {
+	return gRegistry.GetWindowsPaths()
+}
+
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWindowsPaths(t *testing.T) {
+	Convey("SplitWindowsName", t, func() {
+		for _, tc := range []struct{ path, clean, stream string }{
+			{"report.pdf", "report.pdf", ""},
+			{"report.pdf.", "report.pdf", ""},
+			{"report.pdf . .", "report.pdf", ""},
+			{"file.txt:Zone.Identifier", "file.txt", "Zone.Identifier"},
+			{"file.txt:stream:$DATA", "file.txt", "stream:$DATA"},
+			{`C:\Users\me\file.txt:Zone.Identifier`, `C:\Users\me\file.txt`, "Zone.Identifier"},
+			{"C:file.txt.", "C:file.txt", ""},
+			{"dir.d/name", "dir.d/name", ""},
+		} {
+			clean, stream := SplitWindowsName(tc.path)
+			So(clean, ShouldEqual, tc.clean)
+			So(stream, ShouldEqual, tc.stream)
+		}
+	})
+
+	Convey("SetWindowsPaths", t, func() {
+		r := New()
+		So(r.GetWindowsPaths(), ShouldEqual, runtime.GOOS == "windows")
+		r.SetWindowsPaths(true)
+		So(r.FromPathOnly("report.txt."), ShouldEqual, "text/plain; charset=utf-8")
+		So(r.FromPathOnly("notes.txt:Zone.Identifier"), ShouldEqual, "text/plain; charset=utf-8")
+		r.SetWindowsPaths(false)
+		So(r.FromPathOnly("notes.txt:Zone.Identifier"), ShouldBeEmpty)
+
+		enabled := GetWindowsPaths()
+		SetWindowsPaths(!enabled)
+		So(GetWindowsPaths(), ShouldEqual, !enabled)
+		SetWindowsPaths(enabled)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"sync"
+)
+
+// gWinRegQuery returns the "Content Type" value of the HKEY_CLASSES_ROOT
+// key of the given `extension`, which includes the leading period. It is nil
+// on platforms without a Windows registry
+var gWinRegQuery func(extension string) (mime string, ok bool)
+
+// gWinRegCache holds the results of gWinRegQuery, including misses as empty
+// strings, as the Windows registry is slow to query and rarely changes
+var gWinRegCache = struct {
+	m map[string]string
+	sync.RWMutex
+}{m: map[string]string{}}
+
+// SetWindowsRegistry enables or disables the Windows registry lookup backend,
+// which resolves extensions that are unknown to both the Registry and the
+// standard library mime package using the
+// HKEY_CLASSES_ROOT\.<ext>\Content Type value. Unlike the standard library,
+// which only reads the Windows registry once during initialization, the
+// lookup happens on demand. Results are cached for the life of the process,
+// see ClearWindowsRegistryCache. The backend is disabled by default so that
+// results do not depend on the software installed, and it has no effect on
+// other platforms
+func (r *Registry) SetWindowsRegistry(enabled bool) {
+	r.windowsRegistry.Store(enabled)
+}
+
+// GetWindowsRegistry returns true if the Windows registry lookup backend is
+// enabled
+func (r *Registry) GetWindowsRegistry() (enabled bool) {
+	return r.windowsRegistry.Load()
+}
+
+// fromWindowsRegistry returns the cached Windows registry mime type of the
+// given `extension`, without the leading period
+func (r *Registry) fromWindowsRegistry(extension string) (mime string, ok bool) {
+	if !r.GetWindowsRegistry() || gWinRegQuery == nil || extension == "" {
+		return
+	}
+	key := "." + strings.ToLower(extension)
+	gWinRegCache.RLock()
+	mime, cached := gWinRegCache.m[key]
+	gWinRegCache.RUnlock()
+	if !cached {
+		if mime, ok = gWinRegQuery(key); !ok || checkMimeType(mime) != nil {
+			mime = ""
+		}
+		gWinRegCache.Lock()
+		gWinRegCache.m[key] = mime
+		gWinRegCache.Unlock()
+	}
+	ok = mime != ""
+	return
+}
+
+// ClearWindowsRegistryCache discards the cached Windows registry lookups, so
+// that changes to the Windows registry are seen
+func ClearWindowsRegistryCache() {
+	gWinRegCache.Lock()
+	defer gWinRegCache.Unlock()
+	clear(gWinRegCache.m)
+}
+
+// SetWindowsRegistry enables or disables the Windows registry lookup backend
+// of the default Registry, see Registry.SetWindowsRegistry
+func SetWindowsRegistry(enabled bool) {
+	gRegistry.SetWindowsRegistry(enabled)
+}
+
+// GetWindowsRegistry returns true if the Windows registry lookup backend of
+// the default Registry is enabled
+func GetWindowsRegistry() (enabled bool) {
+	return gRegistry.GetWindowsRegistry()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWindowsRegistry(t *testing.T) {
+	Convey("SetWindowsRegistry", t, func() {
+		saved := gWinRegQuery
+		defer func() {
+			gWinRegQuery = saved
+			ClearWindowsRegistryCache()
+		}()
+		ClearWindowsRegistryCache()
+		var queries []string
+		gWinRegQuery = func(extension string) (mime string, ok bool) {
+			queries = append(queries, extension)
+			switch extension {
+			case ".winreg":
+				return "application/x-winreg", true
+			case ".broken":
+				return "not a mime type", true
+			}
+			return
+		}
+
+		r := New()
+		So(r.GetWindowsRegistry(), ShouldBeFalse)
+		_, ok := r.GetExtension("winreg")
+		So(ok, ShouldBeFalse)
+		So(queries, ShouldBeEmpty)
+
+		r.SetWindowsRegistry(true)
+		So(r.GetWindowsRegistry(), ShouldBeTrue)
+		mime, ok := r.GetExtension("WinReg")
+		So(ok, ShouldBeTrue)
+		So(mime, ShouldEqual, "application/x-winreg")
+		So(r.FromPathOnly("file.winreg"), ShouldEqual, "application/x-winreg")
+		_, ok = r.GetExtension("broken")
+		So(ok, ShouldBeFalse)
+		_, ok = r.GetExtension("broken")
+		So(ok, ShouldBeFalse)
+		So(queries, ShouldResemble, []string{".winreg", ".broken"})
+
+		// registered and standard library extensions take precedence
+		r.SetExtension("winreg", "text/x-winreg")
+		mime, _ = r.GetExtension("winreg")
+		So(mime, ShouldEqual, "text/x-winreg")
+		So(r.FromPathOnly("file.html"), ShouldEqual, "text/html; charset=utf-8")
+		So(queries, ShouldHaveLength, 2)
+
+		ClearWindowsRegistryCache()
+		_, _ = r.GetExtension("broken")
+		So(queries, ShouldHaveLength, 3)
+
+		r.Reset()
+		So(r.GetWindowsRegistry(), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mime
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+func init() {
+	gWinRegQuery = queryWindowsRegistry
+}
+
+// queryWindowsRegistry reads the HKEY_CLASSES_ROOT\<extension>\Content Type
+// string value
+func queryWindowsRegistry(extension string) (mime string, ok bool) {
+	subkey, err := syscall.UTF16PtrFromString(extension)
+	if err != nil {
+		return
+	}
+	var key syscall.Handle
+	if err = syscall.RegOpenKeyEx(syscall.HKEY_CLASSES_ROOT, subkey, 0, syscall.KEY_READ, &key); err != nil {
+		return
+	}
+	defer syscall.RegCloseKey(key)
+
+	name, _ := syscall.UTF16PtrFromString("Content Type")
+	var kind, size uint32
+	if err = syscall.RegQueryValueEx(key, name, nil, &kind, nil, &size); err != nil || size == 0 {
+		return
+	} else if kind != syscall.REG_SZ && kind != syscall.REG_EXPAND_SZ {
+		return
+	}
+	buf := make([]uint16, size/2+1)
+	if err = syscall.RegQueryValueEx(key, name, nil, &kind, (*byte)(unsafe.Pointer(&buf[0])), &size); err != nil {
+		return
+	}
+	mime = syscall.UTF16ToString(buf)
+	ok = mime != ""
+	return
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+type xdgMimeInfo struct {
+	Types []xdgMimeType `xml:"mime-type"`
+}
+
+type xdgMimeType struct {
+	Type        string     `xml:"type,attr"`
+	SubClassOf  []xdgType  `xml:"sub-class-of"`
+	Aliases     []xdgType  `xml:"alias"`
+	Globs       []xdgGlob  `xml:"glob"`
+	Magic       []xdgMagic `xml:"magic"`
+	GenericIcon *xdgIcon   `xml:"generic-icon"`
+}
+
+type xdgType struct {
+	Type string `xml:"type,attr"`
+}
+
+type xdgIcon struct {
+	Name string `xml:"name,attr"`
+}
+
+type xdgGlob struct {
+	Pattern string `xml:"pattern,attr"`
+}
+
+type xdgMagic struct {
+	Priority int        `xml:"priority,attr"`
+	Matches  []xdgMatch `xml:"match"`
+}
+
+type xdgMatch struct {
+	Type    string     `xml:"type,attr"`
+	Value   string     `xml:"value,attr"`
+	Offset  string     `xml:"offset,attr"`
+	Mask    string     `xml:"mask,attr"`
+	Matches []xdgMatch `xml:"match"`
+}
+
+// magicRule is a compiled xdgMatch
+type magicRule struct {
+	start, end int
+	value      []byte
+	mask       []byte
+	children   []*magicRule
+}
+
+type xdgDetector struct {
+	mime     string
+	parent   string
+	ext      string
+	priority int
+	detect   func(raw []byte, limit uint32) bool
+}
+
+// loadXDG registers the contents of a freedesktop.org shared-mime-info XML
+// package, see FormatXDG
+func (r *Registry) loadXDG(reader io.Reader) (err error) {
+	var info xdgMimeInfo
+	if err = xml.NewDecoder(reader).Decode(&info); err != nil {
+		return
+	}
+
+	var entries [][2]string
+	var globs [][2]string
+	var aliases [][2]string
+	var icons [][2]string
+	var parents [][2]string
+	var detectors []xdgDetector
+	for _, mt := range info.Types {
+		if err = checkMimeType(mt.Type); err != nil {
+			return
+		}
+		var first string
+		for _, glob := range mt.Globs {
+			// simple "*.ext" globs map to extensions, all others to globs
+			if ext, ok := strings.CutPrefix(glob.Pattern, "*."); ok && ext != "" && !strings.ContainsAny(ext, "*?[") {
+				entries = append(entries, [2]string{ext, mt.Type})
+				if first == "" {
+					first = "." + ext
+				}
+			} else if _, err = path.Match(glob.Pattern, ""); err != nil || glob.Pattern == "" {
+				return fmt.Errorf("%s: invalid glob %q", mt.Type, glob.Pattern)
+			} else {
+				globs = append(globs, [2]string{glob.Pattern, mt.Type})
+			}
+		}
+		for _, alias := range mt.Aliases {
+			aliases = append(aliases, [2]string{alias.Type, mt.Type})
+		}
+		if mt.GenericIcon != nil && mt.GenericIcon.Name != "" {
+			icons = append(icons, [2]string{mt.Type, mt.GenericIcon.Name})
+		}
+		var parent string
+		if len(mt.SubClassOf) > 0 {
+			parent = mt.SubClassOf[0].Type
+			parents = append(parents, [2]string{mt.Type, parent})
+		}
+		for _, magic := range mt.Magic {
+			var rules []*magicRule
+			if rules, err = compileMagicRules(magic.Matches); err != nil {
+				return fmt.Errorf("%s: %w", mt.Type, err)
+			}
+			if len(rules) > 0 {
+				detectors = append(detectors, xdgDetector{
+					mime:     mt.Type,
+					parent:   parent,
+					ext:      first,
+					priority: magic.Priority,
+					detect:   magicDetector(rules),
+				})
+			}
+		}
+	}
+
+	r.setExtensions(entries)
+	for _, glob := range globs {
+		_ = r.SetGlob(glob[0], glob[1])
+	}
+	for _, alias := range aliases {
+		r.SetAlias(alias[0], alias[1])
+	}
+	for _, icon := range icons {
+		r.SetIconName(icon[0], icon[1])
+	}
+	for _, parent := range parents {
+		r.SetParent(parent[0], parent[1])
+	}
+	// detectors registered later are checked first, so register the lowest
+	// priority first
+	sort.SliceStable(detectors, func(i, j int) bool {
+		return detectors[i].priority < detectors[j].priority
+	})
+	for _, d := range detectors {
+		if r != gRegistry {
+			r.detectors.add(Detector{Mime: d.mime, Detect: d.detect, Priority: d.priority})
+		} else if parent := mimetype.Lookup(d.parent); d.parent != "" && parent != nil {
+			graftDetector(parent, d.detect, d.mime, d.ext)
+		} else {
+			graftDetector(nil, d.detect, d.mime, d.ext)
+		}
+	}
+	return
+}
+
+// compileMagicRules converts the xdgMatch elements into magicRule values
+func compileMagicRules(matches []xdgMatch) (rules []*magicRule, err error) {
+	for _, match := range matches {
+		rule := &magicRule{}
+		start, end, found := strings.Cut(match.Offset, ":")
+		if rule.start, err = strconv.Atoi(start); err != nil || rule.start < 0 {
+			return nil, fmt.Errorf("invalid magic offset %q", match.Offset)
+		}
+		rule.end = rule.start
+		if found {
+			if rule.end, err = strconv.Atoi(end); err != nil || rule.end < rule.start {
+				return nil, fmt.Errorf("invalid magic offset %q", match.Offset)
+			}
+		}
+		if rule.value, err = magicValue(match.Type, match.Value); err != nil {
+			return
+		}
+		if match.Mask != "" {
+			if rule.mask, err = magicValue(match.Type, match.Mask); err != nil {
+				return
+			} else if len(rule.mask) != len(rule.value) {
+				return nil, fmt.Errorf("magic mask %q does not match the value length", match.Mask)
+			}
+		}
+		if rule.children, err = compileMagicRules(match.Matches); err != nil {
+			return
+		}
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// magicValue returns the bytes to match for the given shared-mime-info
+// match `kind` and `value`
+func magicValue(kind, value string) (data []byte, err error) {
+	var size int
+	var order binary.ByteOrder = binary.BigEndian
+	switch kind {
+	case "string":
+		if strings.HasPrefix(value, "0x") {
+			// masks of string matches are given in hexadecimal
+			return hex.DecodeString(value[2:])
+		}
+		return unescapeMagic(value)
+	case "byte":
+		size = 1
+	case "big16":
+		size = 2
+	case "big32":
+		size = 4
+	case "little16", "host16":
+		size, order = 2, binary.LittleEndian
+	case "little32", "host32":
+		size, order = 4, binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("unsupported magic match type %q", kind)
+	}
+	var number uint64
+	if number, err = strconv.ParseUint(value, 0, size*8); err != nil {
+		return nil, fmt.Errorf("invalid magic %s value %q", kind, value)
+	}
+	data = make([]byte, 8)
+	order.PutUint64(data, number)
+	if order == binary.BigEndian {
+		return data[8-size:], nil
+	}
+	return data[:size], nil
+}
+
+// unescapeMagic decodes the C-style escapes of a string match value
+func unescapeMagic(value string) (data []byte, err error) {
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			data = append(data, value[i])
+			continue
+		}
+		i += 1
+		switch c := value[i]; {
+		case c == 'x':
+			j := i + 1
+			for j < len(value) && j < i+3 && isHexDigit(value[j]) {
+				j += 1
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("invalid hex escape in %q", value)
+			}
+			n, _ := strconv.ParseUint(value[i+1:j], 16, 8)
+			data = append(data, byte(n))
+			i = j - 1
+		case c >= '0' && c <= '7':
+			j := i
+			for j < len(value) && j < i+3 && value[j] >= '0' && value[j] <= '7' {
+				j += 1
+			}
+			n, _ := strconv.ParseUint(value[i:j], 8, 8)
+			data = append(data, byte(n))
+			i = j - 1
+		case c == 'n':
+			data = append(data, '\n')
+		case c == 'r':
+			data = append(data, '\r')
+		case c == 't':
+			data = append(data, '\t')
+		default:
+			data = append(data, c)
+		}
+	}
+	return
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// magicDetector returns a detector which matches if any of the `rules` match
+func magicDetector(rules []*magicRule) func(raw []byte, limit uint32) bool {
+	return func(raw []byte, limit uint32) bool {
+		if limit > 0 && len(raw) > int(limit) {
+			raw = raw[:limit]
+		}
+		return anyMagicRule(rules, raw)
+	}
+}
+
+func anyMagicRule(rules []*magicRule, raw []byte) bool {
+	for _, rule := range rules {
+		if rule.matches(raw) && (len(rule.children) == 0 || anyMagicRule(rule.children, raw)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the rule value is found at any offset within the
+// rule range
+func (m *magicRule) matches(raw []byte) bool {
+	for offset := m.start; offset <= m.end && offset+len(m.value) <= len(raw); offset++ {
+		window := raw[offset : offset+len(m.value)]
+		if m.mask == nil {
+			if bytes.Equal(window, m.value) {
+				return true
+			}
+			continue
+		}
+		matched := true
+		for i := range window {
+			if window[i]&m.mask[i] != m.value[i]&m.mask[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
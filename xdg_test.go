@@ -0,0 +1,104 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testXDGPackage = `<?xml version="1.0" encoding="UTF-8"?>
+<mime-info xmlns="http://www.freedesktop.org/standards/shared-mime-info">
+  <mime-type type="application/x-xdg-thing">
+    <comment>Thing document</comment>
+    <alias type="application/x-thing-legacy"/>
+    <glob pattern="*.xthing"/>
+    <glob pattern="THING-*"/>
+    <magic priority="50">
+      <match type="string" value="\x7fTHG" offset="0"/>
+      <match type="big16" value="0xcafe" offset="4:8">
+        <match type="string" value="v2" offset="10"/>
+      </match>
+    </magic>
+  </mime-type>
+  <mime-type type="text/x-xdg-notes">
+    <sub-class-of type="text/plain"/>
+    <glob pattern="*.xnotes"/>
+    <magic priority="80">
+      <match type="string" value="XDGNOTES" mask="0xffffffffdfdfdfdf" offset="0"/>
+    </magic>
+  </mime-type>
+</mime-info>
+`
+
+func TestLoadXDG(t *testing.T) {
+	Convey("FormatXDG", t, func() {
+		So(FormatXDG.String(), ShouldEqual, "xdg")
+
+		Convey("instance registry", func() {
+			r := New()
+			So(r.LoadFrom(strings.NewReader(testXDGPackage), FormatXDG), ShouldBeNil)
+			mime, ok := r.GetExtension("xthing")
+			So(ok, ShouldBeTrue)
+			So(mime, ShouldEqual, "application/x-xdg-thing")
+			So(r.FromPathOnly("docs/THING-2024"), ShouldEqual, "application/x-xdg-thing")
+			canonical, _ := r.GetAlias("application/x-thing-legacy")
+			So(canonical, ShouldEqual, "application/x-xdg-thing")
+
+			So(r.DetectBytes([]byte("\x7fTHG rest")), ShouldEqual, "application/x-xdg-thing")
+			So(r.DetectBytes([]byte("\x00\x00\x00\x00\x00\x00\xca\xfe\x00\x00v2")), ShouldEqual, "application/x-xdg-thing")
+			So(r.DetectBytes([]byte("\x00\x00\x00\x00\x00\x00\xca\xfe\x00\x00v1")), ShouldNotEqual, "application/x-xdg-thing")
+			So(r.IsKindOf("text/x-xdg-notes", TextMimeType), ShouldBeTrue)
+			So(r.DetectBytes([]byte("XDGNotes are here")), ShouldEqual, "text/x-xdg-notes; charset=utf-8")
+			So(DetectBytes([]byte("\x7fTHG rest")), ShouldNotEqual, "application/x-xdg-thing")
+		})
+
+		Convey("default registry hierarchy", func() {
+			So(LoadFrom(strings.NewReader(testXDGPackage), FormatXDG), ShouldBeNil)
+			defer SetExtension("xthing", "")
+			defer SetExtension("xnotes", "")
+			So(mimetype.Lookup("text/x-xdg-notes").Parent().Is(TextMimeType), ShouldBeTrue)
+			So(mimetype.Lookup("application/x-xdg-thing").Parent().Is(BinaryMimeType), ShouldBeTrue)
+			So(DetectBytes([]byte("\x7fTHG rest")), ShouldEqual, "application/x-xdg-thing")
+		})
+
+		Convey("errors", func() {
+			for _, broken := range []string{
+				`<mime-info`,
+				`<mime-info><mime-type type="nope"/></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="string" value="x" offset="-1"/></magic></mime-type></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="string" value="x" offset="4:2"/></magic></mime-type></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="regex" value="x" offset="0"/></magic></mime-type></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="byte" value="999" offset="0"/></magic></mime-type></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="string" value="ab" mask="0xff" offset="0"/></magic></mime-type></mime-info>`,
+				`<mime-info><mime-type type="a/b"><magic><match type="string" value="\xZZ" offset="0"/></magic></mime-type></mime-info>`,
+			} {
+				So(New().LoadFrom(strings.NewReader(broken), FormatXDG), ShouldNotBeNil)
+			}
+		})
+
+		Convey("escapes", func() {
+			data, err := unescapeMagic(`a\x41\101\n\r\t\\\0`)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "aAA\n\r\t\\\x00")
+			data, err = magicValue("little32", "0x01020304")
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []byte{4, 3, 2, 1})
+		})
+	})
+}
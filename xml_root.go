@@ -0,0 +1,120 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// defaultXMLRoots returns the built-in root element mappings of a new
+// Registry, keyed by xmlRootKey
+func defaultXMLRoots() (l *lookup) {
+	return newLookup(map[string]string{
+		xmlRootKey("http://www.w3.org/2000/svg", ""):                     "image/svg+xml",
+		xmlRootKey("http://www.w3.org/1999/XSL/Transform", ""):           "application/xslt+xml",
+		xmlRootKey("http://www.w3.org/1998/Math/MathML", ""):             "application/mathml+xml",
+		xmlRootKey("http://www.w3.org/1999/xhtml", "html"):               "application/xhtml+xml",
+		xmlRootKey("http://www.w3.org/1999/02/22-rdf-syntax-ns#", "RDF"): "application/rdf+xml",
+		xmlRootKey("http://www.w3.org/ns/SMIL", ""):                      "application/smil+xml",
+		xmlRootKey("http://xspf.org/ns/0/", "playlist"):                  "application/xspf+xml",
+		xmlRootKey("http://docbook.org/ns/docbook", ""):                  "application/docbook+xml",
+		xmlRootKey("", "plist"):                                          "application/x-plist",
+	})
+}
+
+// xmlRootKey returns the lookup key of a root element
+func xmlRootKey(namespace, local string) (key string) {
+	return namespace + " " + local
+}
+
+// RegisterXMLRoot associates XML content having the given root element
+// with the given `mime` type. After content is detected as generic XML, the
+// root element is looked up by both `namespace` and `local` name first,
+// then by `namespace` alone and then by `local` name alone, so either one
+// may be empty to match any value. If `mime` is empty, the association is
+// cleared
+func (r *Registry) RegisterXMLRoot(namespace, local, mime string) (err error) {
+	defer r.logRegistration(mime, &err)
+	if namespace == "" && local == "" {
+		return errors.New("namespace or local name arguments must not be empty")
+	}
+	key := xmlRootKey(namespace, local)
+	if mime == "" {
+		r.xmlRoots.unset(key)
+		return
+	} else if err = checkMimeType(mime); err != nil {
+		return
+	}
+	r.xmlRoots.set(key, PruneCharset(mime))
+	return
+}
+
+// RegisterXMLRoot associates an XML root element with the given `mime` type
+// within the default Registry, see Registry.RegisterXMLRoot
+func RegisterXMLRoot(namespace, local, mime string) (err error) {
+	return gRegistry.RegisterXMLRoot(namespace, local, mime)
+}
+
+// startsWithMarkup returns true if the `head` content begins with "<" after
+// any byte order mark and whitespace
+func startsWithMarkup(head []byte) bool {
+	_, size := DetectBOM(head)
+	trimmed := bytes.TrimSpace(head[size:])
+	return len(trimmed) > 0 && trimmed[0] == '<'
+}
+
+// fromXMLRoot returns the mime type registered for the root element of the
+// XML `head` content, which must begin with markup
+func (r *Registry) fromXMLRoot(head []byte) (mime string, ok bool) {
+	if !startsWithMarkup(head) {
+		return
+	}
+	_, size := DetectBOM(head)
+	decoder := xml.NewDecoder(bytes.NewReader(head[size:]))
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		// only the element names are needed, which are ASCII in practice
+		return input, nil
+	}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) > 0 {
+				// text before the root element, not xml
+				return
+			}
+		case xml.StartElement:
+			namespace, local := strings.TrimSpace(t.Name.Space), t.Name.Local
+			for _, key := range []string{
+				xmlRootKey(namespace, local),
+				xmlRootKey(namespace, ""),
+				xmlRootKey("", local),
+			} {
+				if mime, ok = r.xmlRoots.get(key); ok {
+					return
+				}
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mime
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestXMLRoot(t *testing.T) {
+	const prolog = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+
+	Convey("built-in roots", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		for _, check := range []struct {
+			data string
+			mime string
+		}{
+			{prolog + `<xsl:stylesheet version="1.0" xmlns:xsl="http://www.w3.org/1999/XSL/Transform"/>`, "application/xslt+xml"},
+			{prolog + `<math xmlns="http://www.w3.org/1998/Math/MathML"><mi>x</mi></math>`, "application/mathml+xml"},
+			{prolog + `<!-- a drawing -->` + "\n" + `<svg:svg xmlns:svg="http://www.w3.org/2000/svg"/>`, "image/svg+xml"},
+			{prolog + `<html xmlns="http://www.w3.org/1999/xhtml"><body/></html>`, "application/xhtml+xml"},
+			{prolog + `<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n" + `<plist version="1.0"><dict/></plist>`, "application/x-plist"},
+		} {
+			So(r.DetectBytes([]byte(check.data)), ShouldEqual, check.mime)
+		}
+		So(r.DetectBytes([]byte("notes\n<svg xmlns=\"http://www.w3.org/2000/svg\"/>")), ShouldNotEqual, "image/svg+xml")
+	})
+
+	Convey("RegisterXMLRoot", t, func() {
+		r := New()
+		r.SetOutputPolicy(BareType)
+		data := []byte(prolog + `<project xmlns="http://maven.apache.org/POM/4.0.0"><modelVersion/></project>`)
+		// generic xml may be claimed by catch-all text types
+		before := r.DetectBytes(data)
+		So(before, ShouldNotContainSubstring, "maven")
+
+		So(r.RegisterXMLRoot("", "", "application/x-maven+xml"), ShouldNotBeNil)
+		So(r.RegisterXMLRoot("http://maven.apache.org/POM/4.0.0", "project", "not a type"), ShouldNotBeNil)
+		So(r.RegisterXMLRoot("http://maven.apache.org/POM/4.0.0", "project", "application/x-maven+xml"), ShouldBeNil)
+		So(r.DetectBytes(data), ShouldEqual, "application/x-maven+xml")
+		So(DetectBytes(data), ShouldNotEqual, "application/x-maven+xml")
+
+		So(r.RegisterXMLRoot("http://maven.apache.org/POM/4.0.0", "project", ""), ShouldBeNil)
+		So(r.DetectBytes(data), ShouldEqual, before)
+
+		So(r.RegisterXMLRoot("", "project", "application/x-project+xml"), ShouldBeNil)
+		So(r.DetectBytes(data), ShouldEqual, "application/x-project+xml")
+		r.Reset()
+		r.SetOutputPolicy(BareType)
+		So(r.DetectBytes(data), ShouldEqual, before)
+
+		So(RegisterXMLRoot("", "", "text/xml"), ShouldNotBeNil)
+	})
+}